@@ -23,4 +23,4 @@ require (
 	golang.org/x/sys v0.30.0 // indirect
 )
 
-replace github.com/michimani/go-esa v0.3.0 => ../../
+replace github.com/michimani/go-esa => ../../
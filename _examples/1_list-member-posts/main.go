@@ -48,7 +48,7 @@ func main() {
 func listPostsByMember(ctx context.Context, client *gesa.Client, teamName, screenName string) ([]models.Post, error) {
 	in := &types.ListPostsInput{
 		TeamName: teamName,
-		Q:        fmt.Sprintf("user:%s", screenName),
+		Q:        post.NewQuery(post.User(screenName)).String(),
 		Sort:     types.ListPostsSortCreated,
 		Order:    types.ListPostsOrderDesc,
 		PerPage:  gesa.NewPageNumber(10),
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/michimani/go-esa/gesa"
+)
+
+const (
+	envAccessToken = "ESA_ACCESS_TOKEN"
+	envTeam        = "ESA_TEAM"
+	envProfile     = "GESA_PROFILE"
+	envConfig      = "GESA_CONFIG"
+)
+
+var errUsage = errors.New("invalid usage")
+
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+// session is the state of a command execution resolved from the global flags.
+type session struct {
+	client  *gesa.Client
+	team    string
+	stdin   io.Reader
+	stderr  io.Writer
+	printer *printer
+}
+
+type runFunc func(ctx context.Context, s *session, args []string) error
+
+type subcommand struct {
+	name    string
+	usage   string
+	summary string
+	run     runFunc
+}
+
+type command struct {
+	name    string
+	summary string
+	// run is used if the command has no subcommands.
+	run         runFunc
+	usage       string
+	subcommands []subcommand
+}
+
+var commands = []command{
+	postCommand,
+	commentCommand,
+	memberCommand,
+	tagCommand,
+	statsCommand,
+	emojiCommand,
+}
+
+type globalFlags struct {
+	profile string
+	config  string
+	team    string
+	token   string
+	output  string
+	baseURL string
+	debug   bool
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	gf := globalFlags{}
+	fs := flag.NewFlagSet("gesa", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&gf.profile, "profile", "", "profile name in the config file (env: "+envProfile+")")
+	fs.StringVar(&gf.config, "config", "", "path of the config file (env: "+envConfig+")")
+	fs.StringVar(&gf.team, "team", "", "team name (env: "+envTeam+")")
+	fs.StringVar(&gf.token, "token", "", "access token. It is visible in the process list, so prefer the "+envAccessToken+" environment variable")
+	fs.StringVar(&gf.output, "output", string(outputTable), "output format: table, json or yaml")
+	fs.StringVar(&gf.output, "o", string(outputTable), "shorthand for -output")
+	fs.StringVar(&gf.baseURL, "base-url", "", "base URL of the esa API")
+	fs.BoolVar(&gf.debug, "debug", false, "write requests and responses to stderr")
+	fs.Usage = func() { a.usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	rest := fs.Args()
+	if len(rest) == 0 || rest[0] == "help" {
+		a.usage(fs)
+		if len(rest) == 0 {
+			return errUsage
+		}
+		return nil
+	}
+
+	cmd, ok := findCommand(rest[0])
+	if !ok {
+		a.usage(fs)
+		return fmt.Errorf("unknown command %q", rest[0])
+	}
+
+	run, cmdArgs, err := a.resolveRun(cmd, rest[1:])
+	if err != nil {
+		return err
+	}
+
+	s, err := a.newSession(gf)
+	if err != nil {
+		return err
+	}
+
+	if err := run(ctx, s, cmdArgs); err != nil && !errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+
+	return nil
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func (a *app) resolveRun(cmd command, args []string) (runFunc, []string, error) {
+	if len(cmd.subcommands) == 0 {
+		return cmd.run, args, nil
+	}
+
+	if len(args) == 0 {
+		a.commandUsage(cmd)
+		return nil, nil, errUsage
+	}
+
+	for _, sc := range cmd.subcommands {
+		if sc.name == args[0] {
+			return sc.run, args[1:], nil
+		}
+	}
+
+	a.commandUsage(cmd)
+	return nil, nil, fmt.Errorf("unknown subcommand %q of %s", args[0], cmd.name)
+}
+
+func (a *app) newSession(gf globalFlags) (*session, error) {
+	format := outputFormat(gf.output)
+	if !format.IsValid() {
+		return nil, fmt.Errorf("invalid output format %q", gf.output)
+	}
+
+	configPath := firstNonEmpty(gf.config, a.getenv(envConfig))
+	if configPath == "" {
+		p, err := defaultConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		configPath = p
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	prof, err := cfg.profile(firstNonEmpty(gf.profile, a.getenv(envProfile)))
+	if err != nil {
+		return nil, err
+	}
+
+	token := firstNonEmpty(gf.token, a.getenv(envAccessToken), prof.AccessToken)
+	if token == "" {
+		return nil, fmt.Errorf("access token is not specified. Use -token, %s or the config file", envAccessToken)
+	}
+
+	in := &gesa.NewClientInput{
+		AccessToken: token,
+		BaseURL:     firstNonEmpty(gf.baseURL, prof.BaseURL),
+	}
+	if gf.debug {
+		in.Logger = slog.New(slog.NewTextHandler(a.stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	client, err := gesa.NewClient(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session{
+		client:  client,
+		team:    firstNonEmpty(gf.team, a.getenv(envTeam), prof.Team),
+		stdin:   a.stdin,
+		stderr:  a.stderr,
+		printer: &printer{w: a.stdout, format: format},
+	}, nil
+}
+
+// teamName returns the team name, or an error if it is not specified.
+func (s *session) teamName() (string, error) {
+	if s.team == "" {
+		return "", fmt.Errorf("team name is not specified. Use -team, %s or the config file", envTeam)
+	}
+	return s.team, nil
+}
+
+func (a *app) usage(fs *flag.FlagSet) {
+	w := a.stderr
+	fmt.Fprintln(w, "Usage: gesa [global flags] <command> [<subcommand>] [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	summaries := map[string]string{}
+	for _, c := range commands {
+		names = append(names, c.name)
+		summaries[c.name] = c.summary
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", n, summaries[n])
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+func (a *app) commandUsage(cmd command) {
+	w := a.stderr
+	fmt.Fprintf(w, "Usage of gesa %s:\n", cmd.name)
+	for _, sc := range cmd.subcommands {
+		fmt.Fprintf(w, "  gesa %s %s\n      %s\n", cmd.name, sc.usage, sc.summary)
+	}
+}
+
+// parseFlags parses the flags of the subcommand.
+// Unlike flag.FlagSet.Parse, flags after positional arguments are also parsed.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// readBody reads the body from the file. "-" means the standard input.
+func readBody(path string, stdin io.Reader) (string, error) {
+	if path == "-" {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// splitList splits a comma separated value, ignoring empty elements.
+func splitList(s string) []string {
+	list := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// collect returns the items of the page, or of all pages if all is true.
+func collect[In gesa.IPaginationInput, Out gesa.IPaginationOutput[T], T any](ctx context.Context, in In, fetch gesa.PageFetcher[In, Out], all bool) ([]T, error) {
+	if !all {
+		out, err := fetch(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		return out.PageItems(), nil
+	}
+
+	items := []T{}
+	for item, err := range gesa.Paginate(ctx, in, fetch) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
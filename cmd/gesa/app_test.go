@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Auth   string
+	Body   string
+}
+
+func newTestServer(t *testing.T, responses map[string]string, reqs *[]recordedRequest) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*reqs = append(*reqs, recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Auth:   r.Header.Get("Authorization"),
+			Body:   string(b),
+		})
+
+		body, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not_found","message":"Not found"}`))
+			return
+		}
+		if body == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func runApp(t *testing.T, env map[string]string, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	a := &app{
+		stdin:  strings.NewReader(stdin),
+		stdout: stdout,
+		stderr: stderr,
+		getenv: func(k string) string { return env[k] },
+	}
+	err := a.run(context.Background(), args)
+	return stdout.String(), stderr.String(), err
+}
+
+func Test_app_run(t *testing.T) {
+	responses := map[string]string{
+		"GET /v1/teams/docs/posts":             `{"posts":[{"number":1,"full_name":"dev/hello","wip":false,"url":"https://docs.esa.io/posts/1"}],"next_page":null}`,
+		"GET /v1/teams/docs/posts/1":           `{"number":1,"full_name":"dev/hello","tags":["a","b"]}`,
+		"POST /v1/teams/docs/posts":            `{"number":2,"full_name":"dev/new"}`,
+		"PATCH /v1/teams/docs/posts/2":         `{"number":2,"full_name":"dev/updated"}`,
+		"DELETE /v1/teams/docs/posts/2":        ``,
+		"GET /v1/teams/docs/comments":          `{"comments":[{"id":10,"post_number":1,"body_md":"LGTM"}]}`,
+		"POST /v1/teams/docs/posts/1/comments": `{"id":11,"post_number":1,"body_md":"from stdin"}`,
+		"GET /v1/teams/docs/members":           `{"members":[{"screen_name":"alice","name":"Alice","role":"owner","posts_count":3}]}`,
+		"GET /v1/teams/docs/tags":              `{"tags":[{"name":"go","posts_count":5}]}`,
+		"GET /v1/teams/docs/stats":             `{"members":3,"posts":10}`,
+		"GET /v1/teams/docs/emojis":            `{"emojis":[{"code":"party","aliases":["p"],"category":"Custom","url":"https://example.com/party.png"}]}`,
+	}
+
+	cases := []struct {
+		name          string
+		env           map[string]string
+		stdin         string
+		args          []string
+		expectStdout  []string
+		expectStderr  []string
+		expectRequest *recordedRequest
+		wantErr       bool
+	}{
+		{
+			name:         "post list: table",
+			args:         []string{"-team", "docs", "post", "list", "-q", "user:alice"},
+			expectStdout: []string{"NUMBER", "dev/hello", "https://docs.esa.io/posts/1"},
+			expectRequest: &recordedRequest{
+				Method: http.MethodGet, Path: "/v1/teams/docs/posts", Query: "q=user%3Aalice", Auth: "Bearer test-token",
+			},
+		},
+		{
+			name:         "post get: json",
+			args:         []string{"-team", "docs", "-o", "json", "post", "get", "1"},
+			expectStdout: []string{`"full_name": "dev/hello"`},
+			expectRequest: &recordedRequest{
+				Method: http.MethodGet, Path: "/v1/teams/docs/posts/1", Auth: "Bearer test-token",
+			},
+		},
+		{
+			name:         "post get: yaml, team from env",
+			env:          map[string]string{"ESA_TEAM": "docs"},
+			args:         []string{"-o", "yaml", "post", "get", "1"},
+			expectStdout: []string{"full_name: dev/hello", "- a"},
+		},
+		{
+			name:         "post get: debug log to stderr",
+			args:         []string{"-team", "docs", "-debug", "-o", "json", "post", "get", "1"},
+			expectStdout: []string{`"full_name": "dev/hello"`},
+			expectStderr: []string{`msg="esa API request"`, `msg="esa API response"`, "[REDACTED]"},
+		},
+		{
+			name:         "post create: body from stdin",
+			stdin:        "# hello",
+			args:         []string{"-team", "docs", "post", "create", "-name", "new", "-tags", "a, b", "-wip", "-body-file", "-"},
+			expectStdout: []string{"dev/new"},
+			expectRequest: &recordedRequest{
+				Method: http.MethodPost, Path: "/v1/teams/docs/posts", Auth: "Bearer test-token",
+				Body: `{"post":{"name":"new","body_md":"# hello","tags":["a","b"],"wip":true}}`,
+			},
+		},
+		{
+			name:         "post update: flags after the number",
+			args:         []string{"-team", "docs", "post", "update", "2", "-wip=false", "-message", "ship"},
+			expectStdout: []string{"dev/updated"},
+			expectRequest: &recordedRequest{
+				Method: http.MethodPatch, Path: "/v1/teams/docs/posts/2", Auth: "Bearer test-token",
+				Body: `{"post":{"wip":false,"message":"ship"}}`,
+			},
+		},
+		{
+			name:         "post delete",
+			args:         []string{"-team", "docs", "post", "delete", "2"},
+			expectStdout: []string{"DELETED"},
+		},
+		{
+			name:         "comment list: team comments",
+			args:         []string{"-team", "docs", "comment", "list"},
+			expectStdout: []string{"LGTM"},
+		},
+		{
+			name:         "comment create",
+			stdin:        "from stdin",
+			args:         []string{"-team", "docs", "comment", "create", "1", "-body-file", "-"},
+			expectStdout: []string{"from stdin"},
+			expectRequest: &recordedRequest{
+				Method: http.MethodPost, Path: "/v1/teams/docs/posts/1/comments", Auth: "Bearer test-token",
+				Body: `{"comment":{"body_md":"from stdin"}}`,
+			},
+		},
+		{
+			name:         "member list",
+			args:         []string{"-team", "docs", "member", "list"},
+			expectStdout: []string{"alice", "owner"},
+		},
+		{
+			name:         "tag list",
+			args:         []string{"-team", "docs", "tag", "list"},
+			expectStdout: []string{"go", "5"},
+		},
+		{
+			name:         "stats",
+			args:         []string{"-team", "docs", "stats"},
+			expectStdout: []string{"members", "10"},
+		},
+		{
+			name:         "emoji list",
+			args:         []string{"-team", "docs", "emoji", "list"},
+			expectStdout: []string{"party", "Custom"},
+		},
+		{
+			name:    "ng: api error",
+			args:    []string{"-team", "docs", "post", "get", "404"},
+			wantErr: true,
+		},
+		{
+			name:    "ng: no team",
+			args:    []string{"post", "list"},
+			wantErr: true,
+		},
+		{
+			name:    "ng: invalid post number",
+			args:    []string{"-team", "docs", "post", "get", "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "ng: no command",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "ng: unknown command",
+			args:    []string{"unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "ng: unknown subcommand",
+			args:    []string{"post", "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "ng: invalid output",
+			args:    []string{"-o", "xml", "-team", "docs", "stats"},
+			wantErr: true,
+		},
+		{
+			name:    "ng: post create without name",
+			args:    []string{"-team", "docs", "post", "create"},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			reqs := []recordedRequest{}
+			s := newTestServer(tt, responses, &reqs)
+
+			env := map[string]string{
+				"ESA_ACCESS_TOKEN": "test-token",
+				"GESA_CONFIG":      filepath.Join(tt.TempDir(), "not-exists.yaml"),
+			}
+			for k, v := range c.env {
+				env[k] = v
+			}
+
+			args := append([]string{"-base-url", s.URL}, c.args...)
+			stdout, stderr, err := runApp(tt, env, c.stdin, args...)
+			if c.wantErr {
+				asst.Error(err)
+				return
+			}
+
+			asst.NoError(err)
+			for _, e := range c.expectStdout {
+				asst.Contains(stdout, e)
+			}
+			for _, e := range c.expectStderr {
+				asst.Contains(stderr, e)
+			}
+			asst.NotContains(stderr, "test-token")
+			if c.expectRequest != nil {
+				if asst.Len(reqs, 1) {
+					asst.Equal(*c.expectRequest, reqs[0])
+				}
+			}
+		})
+	}
+}
+
+func Test_app_run_profile(t *testing.T) {
+	asst := assert.New(t)
+	reqs := []recordedRequest{}
+	s := newTestServer(t, map[string]string{
+		"GET /v1/teams/work-team/stats": `{"members":3}`,
+	}, &reqs)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	cfg := "default_profile: home\nprofiles:\n  home:\n    access_token: home-token\n    team: home-team\n  work:\n    access_token: work-token\n    team: work-team\n    base_url: " + s.URL + "\n"
+	asst.NoError(os.WriteFile(path, []byte(cfg), 0600))
+
+	stdout, _, err := runApp(t, map[string]string{}, "", "-config", path, "-profile", "work", "-o", "json", "stats")
+	asst.NoError(err)
+
+	out := map[string]any{}
+	asst.NoError(json.Unmarshal([]byte(stdout), &out))
+	asst.Equal(float64(3), out["members"])
+	if asst.Len(reqs, 1) {
+		asst.Equal("Bearer work-token", reqs[0].Auth)
+	}
+
+	_, _, err = runApp(t, map[string]string{}, "", "-config", path, "-profile", "unknown", "stats")
+	asst.Error(err)
+}
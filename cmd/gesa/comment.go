@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/michimani/go-esa/esaapi/comment"
+	"github.com/michimani/go-esa/esaapi/comment/types"
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/gesa"
+)
+
+var commentCommand = command{
+	name:    "comment",
+	summary: "List, get, create, update and delete comments",
+	subcommands: []subcommand{
+		{name: "list", usage: "list [<post_number>] [-page n] [-per-page n] [-all]", summary: "List comments of a post, or of the team if post_number is omitted", run: runCommentList},
+		{name: "get", usage: "get <comment_id>", summary: "Get a comment", run: runCommentGet},
+		{name: "create", usage: "create <post_number> -body-file file|-", summary: "Create a comment", run: runCommentCreate},
+		{name: "update", usage: "update <comment_id> -body-file file|-", summary: "Update a comment", run: runCommentUpdate},
+		{name: "delete", usage: "delete <comment_id>", summary: "Delete a comment", run: runCommentDelete},
+	},
+}
+
+func runCommentList(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("comment list", s.stderr)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "number of comments per page")
+	all := fs.Bool("all", false, "list comments of all pages")
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	comments := []models.Comment{}
+	if len(pos) == 0 {
+		in := &types.ListTeamCommentsInput{
+			TeamName: team,
+			Page:     gesa.NewPageNumber(*page),
+			PerPage:  gesa.NewPageNumber(*perPage),
+		}
+		fetch := func(ctx context.Context, in *types.ListTeamCommentsInput) (*types.ListTeamCommentsOutput, error) {
+			return comment.ListTeamComments(ctx, s.client, in)
+		}
+		if comments, err = collect(ctx, in, fetch, *all); err != nil {
+			return err
+		}
+	} else {
+		number, err := postNumberArg(pos)
+		if err != nil {
+			return err
+		}
+		in := &types.ListPostCommentsInput{
+			TeamName:   team,
+			PostNumber: number,
+			Page:       gesa.NewPageNumber(*page),
+			PerPage:    gesa.NewPageNumber(*perPage),
+		}
+		fetch := func(ctx context.Context, in *types.ListPostCommentsInput) (*types.ListPostCommentsOutput, error) {
+			return comment.ListPostComments(ctx, s.client, in)
+		}
+		if comments, err = collect(ctx, in, fetch, *all); err != nil {
+			return err
+		}
+	}
+
+	t := &table{header: []string{"ID", "POST NUMBER", "CREATED BY", "CREATED AT", "BODY"}}
+	for _, c := range comments {
+		t.rows = append(t.rows, []string{strconv.Itoa(c.ID), strconv.Itoa(c.PostNumber), c.CreatedBy.ScreenName, formatTime(c.CreatedAt), truncate(c.BodyMD, 50)})
+	}
+
+	return s.printer.print(comments, t)
+}
+
+func runCommentGet(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("comment get", s.stderr)
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := intArg(pos, "comment_id")
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	out, err := comment.GetComment(ctx, s.client, &types.GetCommentInput{TeamName: team, CommentID: id})
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out.Comment, commentTable(&out.Comment))
+}
+
+func runCommentCreate(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("comment create", s.stderr)
+	bodyFile := fs.String("body-file", "", "file of the body in Markdown, - means the standard input")
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	number, err := postNumberArg(pos)
+	if err != nil {
+		return err
+	}
+
+	if *bodyFile == "" {
+		return errors.New("-body-file is required")
+	}
+	body, err := readBody(*bodyFile, s.stdin)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	out, err := comment.CreateComment(ctx, s.client, &types.CreateCommentInput{TeamName: team, PostNumber: number, BodyMD: body})
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out.Comment, commentTable(&out.Comment))
+}
+
+func runCommentUpdate(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("comment update", s.stderr)
+	bodyFile := fs.String("body-file", "", "file of the body in Markdown, - means the standard input")
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := intArg(pos, "comment_id")
+	if err != nil {
+		return err
+	}
+
+	if *bodyFile == "" {
+		return errors.New("-body-file is required")
+	}
+	body, err := readBody(*bodyFile, s.stdin)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	out, err := comment.UpdateComment(ctx, s.client, &types.UpdateCommentInput{TeamName: team, CommentID: id, BodyMD: gesa.String(body)})
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out.Comment, commentTable(&out.Comment))
+}
+
+func runCommentDelete(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("comment delete", s.stderr)
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := intArg(pos, "comment_id")
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	if _, err := comment.DeleteComment(ctx, s.client, &types.DeleteCommentInput{TeamName: team, CommentID: id}); err != nil {
+		return err
+	}
+
+	result := map[string]any{"id": id, "deleted": true}
+	return s.printer.print(result, &table{header: []string{"ID", "DELETED"}, rows: [][]string{{strconv.Itoa(id), "true"}}})
+}
+
+func commentTable(c *models.Comment) *table {
+	return &table{
+		header: []string{"FIELD", "VALUE"},
+		rows: [][]string{
+			{"id", strconv.Itoa(c.ID)},
+			{"post_number", strconv.Itoa(c.PostNumber)},
+			{"created_by", c.CreatedBy.ScreenName},
+			{"created_at", formatTime(c.CreatedAt)},
+			{"updated_at", formatTime(c.UpdatedAt)},
+			{"url", c.URL},
+			{"body_md", c.BodyMD},
+		},
+	}
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
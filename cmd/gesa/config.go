@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config is the content of the config file.
+//
+//	default_profile: work
+//	profiles:
+//	  work:
+//	    access_token: xxxxx
+//	    team: docs
+type config struct {
+	DefaultProfile string              `yaml:"default_profile"`
+	Profiles       map[string]*profile `yaml:"profiles"`
+}
+
+type profile struct {
+	AccessToken string `yaml:"access_token"`
+	Team        string `yaml:"team"`
+	BaseURL     string `yaml:"base_url"`
+}
+
+// defaultConfigPath returns $XDG_CONFIG_HOME/gesa/config.yaml or the equivalent of the OS.
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gesa", "config.yaml"), nil
+}
+
+// loadConfig reads the config file.
+// If the file does not exist, an empty config is returned.
+func loadConfig(path string) (*config, error) {
+	c := &config{Profiles: map[string]*profile{}}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]*profile{}
+	}
+
+	return c, nil
+}
+
+// profile returns the profile of the name.
+// If the name is empty, the default profile is returned.
+func (c *config) profile(name string) (*profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		if name == "default" || name == c.DefaultProfile {
+			return &profile{}, nil
+		}
+		return nil, fmt.Errorf("profile %q is not found", name)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadConfig(t *testing.T) {
+	cases := []struct {
+		name        string
+		content     *string
+		profile     string
+		expect      *profile
+		wantErr     bool
+		wantProfErr bool
+	}{
+		{
+			name:    "ok: not exists",
+			content: nil,
+			profile: "",
+			expect:  &profile{},
+		},
+		{
+			name:    "ok: default profile",
+			content: ptr("default_profile: work\nprofiles:\n  work:\n    access_token: token\n    team: docs\n"),
+			profile: "",
+			expect:  &profile{AccessToken: "token", Team: "docs"},
+		},
+		{
+			name:    "ok: profile named default",
+			content: ptr("profiles:\n  default:\n    access_token: token\n"),
+			profile: "",
+			expect:  &profile{AccessToken: "token"},
+		},
+		{
+			name:        "ng: unknown profile",
+			content:     ptr("profiles:\n  work:\n    access_token: token\n"),
+			profile:     "home",
+			wantProfErr: true,
+		},
+		{
+			name:    "ng: invalid yaml",
+			content: ptr("profiles: ["),
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			path := filepath.Join(tt.TempDir(), "config.yaml")
+			if c.content != nil {
+				asst.NoError(os.WriteFile(path, []byte(*c.content), 0600))
+			}
+
+			cfg, err := loadConfig(path)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(cfg)
+				return
+			}
+			asst.NoError(err)
+
+			p, err := cfg.profile(c.profile)
+			if c.wantProfErr {
+				asst.Error(err)
+				asst.Nil(p)
+				return
+			}
+			asst.NoError(err)
+			asst.Equal(c.expect, p)
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/michimani/go-esa/esaapi/emoji"
+	"github.com/michimani/go-esa/esaapi/emoji/types"
+	"github.com/michimani/go-esa/gesa"
+)
+
+var emojiCommand = command{
+	name:    "emoji",
+	summary: "List, create and delete emojis",
+	subcommands: []subcommand{
+		{name: "list", usage: "list", summary: "List emojis", run: runEmojiList},
+		{name: "create", usage: "create <code> (-image-file file | -origin-code code)", summary: "Create an emoji, or an alias of an existing emoji", run: runEmojiCreate},
+		{name: "delete", usage: "delete <code>", summary: "Delete an emoji", run: runEmojiDelete},
+	},
+}
+
+func runEmojiList(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("emoji list", s.stderr)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	out, err := emoji.ListEmojis(ctx, s.client, &types.ListEmojisInput{TeamName: team})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"CODE", "ALIASES", "CATEGORY", "URL"}}
+	for _, e := range out.Emojis {
+		t.rows = append(t.rows, []string{e.Code, strings.Join(e.Aliases, ","), e.Category, e.URL})
+	}
+
+	return s.printer.print(out.Emojis, t)
+}
+
+func runEmojiCreate(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("emoji create", s.stderr)
+	imageFile := fs.String("image-file", "", "image file of the new emoji")
+	originCode := fs.String("origin-code", "", "code of the existing emoji to add the alias to")
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(pos) != 1 {
+		return errors.New("code is required")
+	}
+	if (*imageFile == "") == (*originCode == "") {
+		return errors.New("either -image-file or -origin-code is required")
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	in := &types.CreateEmojiInput{TeamName: team, Code: pos[0]}
+	if *imageFile != "" {
+		b, err := os.ReadFile(*imageFile)
+		if err != nil {
+			return err
+		}
+		in.Image = gesa.String(base64.StdEncoding.EncodeToString(b))
+	} else {
+		in.OriginCode = gesa.String(*originCode)
+	}
+
+	out, err := emoji.CreateEmoji(ctx, s.client, in)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out, &table{header: []string{"CODE"}, rows: [][]string{{out.Code}}})
+}
+
+func runEmojiDelete(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("emoji delete", s.stderr)
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(pos) != 1 {
+		return errors.New("code is required")
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	if _, err := emoji.DeleteEmoji(ctx, s.client, &types.DeleteEmojiInput{TeamName: team, Code: pos[0]}); err != nil {
+		return err
+	}
+
+	result := map[string]any{"code": pos[0], "deleted": true}
+	return s.printer.print(result, &table{header: []string{"CODE", "DELETED"}, rows: [][]string{{pos[0], "true"}}})
+}
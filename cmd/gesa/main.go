@@ -0,0 +1,32 @@
+// Command gesa is a command-line tool for the esa API built on the esaapi packages.
+//
+// Usage:
+//
+//	gesa [global flags] <command> [<subcommand>] [flags] [args]
+//
+// Run "gesa help" to see the available commands.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	}
+
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "gesa:", err)
+		os.Exit(1)
+	}
+}
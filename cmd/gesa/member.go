@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/michimani/go-esa/esaapi/member"
+	"github.com/michimani/go-esa/esaapi/member/types"
+	"github.com/michimani/go-esa/gesa"
+)
+
+var memberCommand = command{
+	name:    "member",
+	summary: "List members",
+	subcommands: []subcommand{
+		{name: "list", usage: "list [-sort sort] [-order order] [-page n] [-per-page n] [-all]", summary: "List members", run: runMemberList},
+	},
+}
+
+func runMemberList(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("member list", s.stderr)
+	sort := fs.String("sort", "", "sort: posts_count, joined or last_accessed")
+	order := fs.String("order", "", "order: desc or asc")
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "number of members per page")
+	all := fs.Bool("all", false, "list members of all pages")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	in := &types.ListMembersInput{
+		TeamName: team,
+		Sort:     types.ListMembersSort(*sort),
+		Order:    types.ListMembersOrder(*order),
+		Page:     gesa.NewPageNumber(*page),
+		PerPage:  gesa.NewPageNumber(*perPage),
+	}
+	fetch := func(ctx context.Context, in *types.ListMembersInput) (*types.ListMembersOutput, error) {
+		return member.ListMembers(ctx, s.client, in)
+	}
+	members, err := collect(ctx, in, fetch, *all)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"SCREEN NAME", "NAME", "ROLE", "POSTS", "JOINED AT"}}
+	for _, m := range members {
+		t.rows = append(t.rows, []string{m.ScreenName, m.Name, m.Role, strconv.Itoa(m.PostsCount), formatTime(m.JoinedAt)})
+	}
+
+	return s.printer.print(members, t)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+	outputYAML  outputFormat = "yaml"
+)
+
+func (f outputFormat) IsValid() bool {
+	return f == outputTable || f == outputJSON || f == outputYAML
+}
+
+// table is the representation of the output in the table format.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+type printer struct {
+	w      io.Writer
+	format outputFormat
+}
+
+// print writes v in the JSON or YAML format, or t in the table format.
+func (p *printer) print(v any, t *table) error {
+	switch p.format {
+	case outputJSON:
+		e := json.NewEncoder(p.w)
+		e.SetIndent("", "  ")
+		return e.Encode(v)
+	case outputYAML:
+		// Convert via JSON to use the same field names as the esa API.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var m any
+		if err := json.Unmarshal(b, &m); err != nil {
+			return err
+		}
+		e := yaml.NewEncoder(p.w)
+		e.SetIndent(2)
+		if err := e.Encode(m); err != nil {
+			return err
+		}
+		return e.Close()
+	default:
+		return p.printTable(t)
+	}
+}
+
+func (p *printer) printTable(t *table) error {
+	if t == nil {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	if len(t.header) > 0 {
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	}
+	for _, r := range t.rows {
+		cells := make([]string, len(r))
+		for i, c := range r {
+			cells[i] = sanitizeCell(c)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func sanitizeCell(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
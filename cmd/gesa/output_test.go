@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_printer_print(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	v := []item{{Name: "a", Count: 1}, {Name: "b\tc", Count: 22}}
+	tbl := &table{
+		header: []string{"NAME", "COUNT"},
+		rows:   [][]string{{"a", "1"}, {"b\tc", "22"}},
+	}
+
+	cases := []struct {
+		name   string
+		format outputFormat
+		expect string
+	}{
+		{
+			name:   "table",
+			format: outputTable,
+			expect: "NAME  COUNT\na     1\nb c   22\n",
+		},
+		{
+			name:   "json",
+			format: outputJSON,
+			expect: "[\n  {\n    \"name\": \"a\",\n    \"count\": 1\n  },\n  {\n    \"name\": \"b\\tc\",\n    \"count\": 22\n  }\n]\n",
+		},
+		{
+			name:   "yaml",
+			format: outputYAML,
+			expect: "- count: 1\n  name: a\n- count: 22\n  name: \"b\\tc\"\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			buf := new(bytes.Buffer)
+			p := &printer{w: buf, format: c.format}
+			asst.NoError(p.print(v, tbl))
+			asst.Equal(c.expect, buf.String())
+		})
+	}
+}
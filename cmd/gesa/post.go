@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/gesa"
+)
+
+var postCommand = command{
+	name:    "post",
+	summary: "List, get, create, update and delete posts",
+	subcommands: []subcommand{
+		{name: "list", usage: "list [-q query] [-sort sort] [-order order] [-page n] [-per-page n] [-all]", summary: "List posts", run: runPostList},
+		{name: "get", usage: "get <post_number>", summary: "Get a post", run: runPostGet},
+		{name: "create", usage: "create -name name [-body-file file|-] [-category c] [-tags a,b] [-wip] [-message m]", summary: "Create a post", run: runPostCreate},
+		{name: "update", usage: "update <post_number> [-name name] [-body-file file|-] [-category c] [-tags a,b] [-wip=bool] [-message m]", summary: "Update a post", run: runPostUpdate},
+		{name: "delete", usage: "delete <post_number>", summary: "Delete a post", run: runPostDelete},
+	},
+}
+
+const timeFormat = time.RFC3339
+
+func runPostList(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("post list", s.stderr)
+	q := fs.String("q", "", "search query")
+	sort := fs.String("sort", "", "sort: updated, created, number, stars, watches, comments or best_match")
+	order := fs.String("order", "", "order: desc or asc")
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "number of posts per page")
+	all := fs.Bool("all", false, "list posts of all pages")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	in := &types.ListPostsInput{
+		TeamName: team,
+		Q:        *q,
+		Sort:     types.ListPostsSort(*sort),
+		Order:    types.ListPostsOrder(*order),
+		Page:     gesa.NewPageNumber(*page),
+		PerPage:  gesa.NewPageNumber(*perPage),
+	}
+
+	fetch := func(ctx context.Context, in *types.ListPostsInput) (*types.ListPostsOutput, error) {
+		return post.ListPosts(ctx, s.client, in)
+	}
+	posts, err := collect(ctx, in, fetch, *all)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"NUMBER", "FULL NAME", "WIP", "UPDATED AT", "URL"}}
+	for _, p := range posts {
+		t.rows = append(t.rows, []string{strconv.Itoa(p.Number), p.FullName, strconv.FormatBool(p.Wip), formatTime(p.UpdatedAt), p.URL})
+	}
+
+	return s.printer.print(posts, t)
+}
+
+func runPostGet(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("post get", s.stderr)
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	number, err := postNumberArg(pos)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	out, err := post.GetPost(ctx, s.client, &types.GetPostInput{TeamName: team, PostNumber: number})
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out.Post, postTable(&out.Post))
+}
+
+type postFlags struct {
+	name     *string
+	bodyFile *string
+	category *string
+	tags     *string
+	wip      *bool
+	message  *string
+}
+
+func newPostFlags(fs *flag.FlagSet) *postFlags {
+	return &postFlags{
+		name:     fs.String("name", "", "post name"),
+		bodyFile: fs.String("body-file", "", "file of the body in Markdown, - means the standard input"),
+		category: fs.String("category", "", "category"),
+		tags:     fs.String("tags", "", "comma separated tags"),
+		wip:      fs.Bool("wip", false, "whether the post is WIP"),
+		message:  fs.String("message", "", "message of the revision"),
+	}
+}
+
+// isSet reports whether the flag is specified in the command line.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func runPostCreate(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("post create", s.stderr)
+	pf := newPostFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *pf.name == "" {
+		return errors.New("-name is required")
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	in := &types.CreatePostInput{
+		TeamName: team,
+		Name:     *pf.name,
+	}
+	if *pf.bodyFile != "" {
+		body, err := readBody(*pf.bodyFile, s.stdin)
+		if err != nil {
+			return err
+		}
+		in.BodyMD = gesa.String(body)
+	}
+	if isSet(fs, "category") {
+		in.Category = gesa.String(*pf.category)
+	}
+	if isSet(fs, "tags") {
+		in.Tags = stringPointers(splitList(*pf.tags))
+	}
+	if isSet(fs, "wip") {
+		in.Wip = gesa.Bool(*pf.wip)
+	}
+	if isSet(fs, "message") {
+		in.Message = gesa.String(*pf.message)
+	}
+
+	out, err := post.CreatePost(ctx, s.client, in)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out.Post, postTable(&out.Post))
+}
+
+func runPostUpdate(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("post update", s.stderr)
+	pf := newPostFlags(fs)
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	number, err := postNumberArg(pos)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	in := &types.UpdatePostInput{
+		TeamName:   team,
+		PostNumber: number,
+		Name:       *pf.name,
+	}
+	if *pf.bodyFile != "" {
+		body, err := readBody(*pf.bodyFile, s.stdin)
+		if err != nil {
+			return err
+		}
+		in.BodyMD = gesa.String(body)
+	}
+	if isSet(fs, "category") {
+		in.Category = gesa.String(*pf.category)
+	}
+	if isSet(fs, "tags") {
+		in.Tags = stringPointers(splitList(*pf.tags))
+	}
+	if isSet(fs, "wip") {
+		in.Wip = gesa.Bool(*pf.wip)
+	}
+	if isSet(fs, "message") {
+		in.Message = gesa.String(*pf.message)
+	}
+
+	out, err := post.UpdatePost(ctx, s.client, in)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(out.Post, postTable(&out.Post))
+}
+
+func runPostDelete(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("post delete", s.stderr)
+	pos, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	number, err := postNumberArg(pos)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	if _, err := post.DeletePost(ctx, s.client, &types.DeletePostInput{TeamName: team, PostNumber: number}); err != nil {
+		return err
+	}
+
+	result := map[string]any{"number": number, "deleted": true}
+	return s.printer.print(result, &table{header: []string{"NUMBER", "DELETED"}, rows: [][]string{{strconv.Itoa(number), "true"}}})
+}
+
+func postTable(p *models.Post) *table {
+	return &table{
+		header: []string{"FIELD", "VALUE"},
+		rows: [][]string{
+			{"number", strconv.Itoa(p.Number)},
+			{"full_name", p.FullName},
+			{"wip", strconv.FormatBool(p.Wip)},
+			{"tags", strings.Join(p.Tags, ",")},
+			{"revision_number", strconv.Itoa(p.RevisionNumber)},
+			{"created_by", p.CreatedBy.ScreenName},
+			{"updated_by", p.UpdatedBy.ScreenName},
+			{"created_at", formatTime(p.CreatedAt)},
+			{"updated_at", formatTime(p.UpdatedAt)},
+			{"url", p.URL},
+		},
+	}
+}
+
+func postNumberArg(args []string) (int, error) {
+	return intArg(args, "post_number")
+}
+
+func intArg(args []string, name string) (int, error) {
+	if len(args) != 1 {
+		return 0, errors.New(name + " is required")
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, errors.New("invalid " + name + ": " + args[0])
+	}
+
+	return n, nil
+}
+
+func stringPointers(list []string) []*string {
+	ps := make([]*string, 0, len(list))
+	for _, v := range list {
+		ps = append(ps, gesa.String(v))
+	}
+	return ps
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeFormat)
+}
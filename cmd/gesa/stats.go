@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/michimani/go-esa/esaapi/stats"
+	"github.com/michimani/go-esa/esaapi/stats/types"
+)
+
+var statsCommand = command{
+	name:    "stats",
+	summary: "Show statistics of the team",
+	usage:   "stats",
+	run:     runStats,
+}
+
+func runStats(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("stats", s.stderr)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	out, err := stats.GetStats(ctx, s.client, &types.GetStatsInput{TeamName: team})
+	if err != nil {
+		return err
+	}
+
+	t := &table{
+		header: []string{"FIELD", "VALUE"},
+		rows: [][]string{
+			{"members", strconv.Itoa(out.Members)},
+			{"posts", strconv.Itoa(out.Posts)},
+			{"posts_wip", strconv.Itoa(out.PostsWip)},
+			{"posts_shipped", strconv.Itoa(out.PostsShipped)},
+			{"comments", strconv.Itoa(out.Comments)},
+			{"stars", strconv.Itoa(out.Stars)},
+			{"daily_active_users", strconv.Itoa(out.DailyActiveUsers)},
+			{"weekly_active_users", strconv.Itoa(out.WeeklyActiveUsers)},
+			{"monthly_active_users", strconv.Itoa(out.MonthlyActiveUsers)},
+		},
+	}
+
+	return s.printer.print(out, t)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/michimani/go-esa/esaapi/tag"
+	"github.com/michimani/go-esa/esaapi/tag/types"
+	"github.com/michimani/go-esa/gesa"
+)
+
+var tagCommand = command{
+	name:    "tag",
+	summary: "List tags",
+	subcommands: []subcommand{
+		{name: "list", usage: "list [-page n] [-per-page n] [-all]", summary: "List tags", run: runTagList},
+	},
+}
+
+func runTagList(ctx context.Context, s *session, args []string) error {
+	fs := newFlagSet("tag list", s.stderr)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "number of tags per page")
+	all := fs.Bool("all", false, "list tags of all pages")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	team, err := s.teamName()
+	if err != nil {
+		return err
+	}
+
+	in := &types.ListTagsInput{
+		TeamName: team,
+		Page:     gesa.NewPageNumber(*page),
+		PerPage:  gesa.NewPageNumber(*perPage),
+	}
+	fetch := func(ctx context.Context, in *types.ListTagsInput) (*types.ListTagsOutput, error) {
+		return tag.ListTags(ctx, s.client, in)
+	}
+	tags, err := collect(ctx, in, fetch, *all)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"NAME", "POSTS"}}
+	for _, tg := range tags {
+		t.rows = append(t.rows, []string{tg.Name, strconv.Itoa(tg.PostsCount)})
+	}
+
+	return s.printer.print(tags, t)
+}
@@ -0,0 +1,18 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/category"
+	"github.com/michimani/go-esa/esaapi/category/types"
+)
+
+// CategoryService calls the APIs of the category package.
+type CategoryService service
+
+// BatchMove calls category.BatchMove.
+func (s *CategoryService) BatchMove(ctx context.Context, in *types.BatchMoveInput) (*types.BatchMoveOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return category.BatchMove(ctx, s.client, p)
+}
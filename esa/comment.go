@@ -0,0 +1,53 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/comment"
+	"github.com/michimani/go-esa/esaapi/comment/types"
+)
+
+// CommentService calls the APIs of the comment package.
+type CommentService service
+
+// List calls comment.ListTeamComments.
+func (s *CommentService) List(ctx context.Context, in *types.ListTeamCommentsInput) (*types.ListTeamCommentsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return comment.ListTeamComments(ctx, s.client, p)
+}
+
+// ListForPost calls comment.ListPostComments.
+func (s *CommentService) ListForPost(ctx context.Context, in *types.ListPostCommentsInput) (*types.ListPostCommentsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return comment.ListPostComments(ctx, s.client, p)
+}
+
+// Get calls comment.GetComment.
+func (s *CommentService) Get(ctx context.Context, in *types.GetCommentInput) (*types.GetCommentOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return comment.GetComment(ctx, s.client, p)
+}
+
+// Create calls comment.CreateComment.
+func (s *CommentService) Create(ctx context.Context, in *types.CreateCommentInput) (*types.CreateCommentOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return comment.CreateComment(ctx, s.client, p)
+}
+
+// Update calls comment.UpdateComment.
+func (s *CommentService) Update(ctx context.Context, in *types.UpdateCommentInput) (*types.UpdateCommentOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return comment.UpdateComment(ctx, s.client, p)
+}
+
+// Delete calls comment.DeleteComment.
+func (s *CommentService) Delete(ctx context.Context, in *types.DeleteCommentInput) (*types.DeleteCommentOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return comment.DeleteComment(ctx, s.client, p)
+}
@@ -0,0 +1,32 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/emoji"
+	"github.com/michimani/go-esa/esaapi/emoji/types"
+)
+
+// EmojiService calls the APIs of the emoji package.
+type EmojiService service
+
+// List calls emoji.ListEmojis.
+func (s *EmojiService) List(ctx context.Context, in *types.ListEmojisInput) (*types.ListEmojisOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return emoji.ListEmojis(ctx, s.client, p)
+}
+
+// Create calls emoji.CreateEmoji.
+func (s *EmojiService) Create(ctx context.Context, in *types.CreateEmojiInput) (*types.CreateEmojiOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return emoji.CreateEmoji(ctx, s.client, p)
+}
+
+// Delete calls emoji.DeleteEmoji.
+func (s *EmojiService) Delete(ctx context.Context, in *types.DeleteEmojiInput) (*types.DeleteEmojiOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return emoji.DeleteEmoji(ctx, s.client, p)
+}
@@ -0,0 +1,108 @@
+// Package esa provides a team-scoped facade over the esaapi packages.
+//
+// The services fill TeamName of the inputs with the name of the team, and return the
+// output types of the esaapi packages as they are.
+//
+//	team := esa.New(client).Team("docs")
+//	out, err := team.Posts.List(ctx, &types.ListPostsInput{Q: "in:dev"})
+package esa
+
+import (
+	"context"
+
+	teamapi "github.com/michimani/go-esa/esaapi/team"
+	teamtypes "github.com/michimani/go-esa/esaapi/team/types"
+	"github.com/michimani/go-esa/esaapi/user"
+	usertypes "github.com/michimani/go-esa/esaapi/user/types"
+	"github.com/michimani/go-esa/gesa"
+)
+
+// Client is the entry point of the facade.
+type Client struct {
+	client gesa.IAPIClient
+}
+
+// New returns the facade over the client.
+func New(c gesa.IAPIClient) *Client {
+	return &Client{client: c}
+}
+
+// APIClient returns the underlying client.
+func (c *Client) APIClient() gesa.IAPIClient {
+	return c.client
+}
+
+// ListTeams calls team.ListTeams.
+func (c *Client) ListTeams(ctx context.Context, in *teamtypes.ListTeamsInput) (*teamtypes.ListTeamsOutput, error) {
+	return teamapi.ListTeams(ctx, c.client, clone(in))
+}
+
+// GetMe calls user.GetMe.
+func (c *Client) GetMe(ctx context.Context, in *usertypes.GetMeInput) (*usertypes.GetMeOutput, error) {
+	return user.GetMe(ctx, c.client, clone(in))
+}
+
+// Team is the set of the services of a team.
+type Team struct {
+	name   string
+	client gesa.IAPIClient
+
+	Posts       *PostService
+	Comments    *CommentService
+	Revisions   *RevisionService
+	Stars       *StarService
+	Watches     *WatchService
+	Members     *MemberService
+	Tags        *TagService
+	Emojis      *EmojiService
+	Invitations *InvitationService
+	Categories  *CategoryService
+	Stats       *StatsService
+}
+
+// service is the common state of the services. Each service is defined as this type
+// to share it, e.g. "type PostService service".
+type service struct {
+	client   gesa.IAPIClient
+	teamName string
+}
+
+// Team returns the services of the team.
+func (c *Client) Team(name string) *Team {
+	s := &service{client: c.client, teamName: name}
+	return &Team{
+		name:        name,
+		client:      c.client,
+		Posts:       (*PostService)(s),
+		Comments:    (*CommentService)(s),
+		Revisions:   (*RevisionService)(s),
+		Stars:       (*StarService)(s),
+		Watches:     (*WatchService)(s),
+		Members:     (*MemberService)(s),
+		Tags:        (*TagService)(s),
+		Emojis:      (*EmojiService)(s),
+		Invitations: (*InvitationService)(s),
+		Categories:  (*CategoryService)(s),
+		Stats:       (*StatsService)(s),
+	}
+}
+
+// Name returns the name of the team.
+func (t *Team) Name() string {
+	return t.name
+}
+
+// Get calls team.GetTeam.
+func (t *Team) Get(ctx context.Context) (*teamtypes.GetTeamOutput, error) {
+	return teamapi.GetTeam(ctx, t.client, &teamtypes.GetTeamInput{TeamName: t.name})
+}
+
+// clone returns a shallow copy of the input, so that filling TeamName does not modify
+// the input of the caller. A nil input is treated as the zero value.
+func clone[T any](in *T) *T {
+	p := new(T)
+	if in != nil {
+		*p = *in
+	}
+	return p
+}
@@ -0,0 +1,411 @@
+package esa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/michimani/go-esa/esa"
+	categorytypes "github.com/michimani/go-esa/esaapi/category/types"
+	commenttypes "github.com/michimani/go-esa/esaapi/comment/types"
+	emojitypes "github.com/michimani/go-esa/esaapi/emoji/types"
+	invitationtypes "github.com/michimani/go-esa/esaapi/invitation/types"
+	membertypes "github.com/michimani/go-esa/esaapi/member/types"
+	"github.com/michimani/go-esa/esaapi/models"
+	posttypes "github.com/michimani/go-esa/esaapi/post/types"
+	revisiontypes "github.com/michimani/go-esa/esaapi/revision/types"
+	startypes "github.com/michimani/go-esa/esaapi/star/types"
+	tagtypes "github.com/michimani/go-esa/esaapi/tag/types"
+	watchtypes "github.com/michimani/go-esa/esaapi/watch/types"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Team(t *testing.T) {
+	ctx := context.Background()
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddToken("alice-token", "alice")
+	s.AddTeam(models.Team{Name: "other"})
+	p := s.AddPost("docs", models.Post{Name: "hello", Category: "dev", BodyMD: "# hello\n"})
+	cm, _ := s.AddComment("docs", p.Number, models.Comment{BodyMD: "LGTM"})
+	s.AddMember("docs", models.Member{ScreenName: "bob"})
+
+	gc, err := s.NewClient("alice-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := esa.New(gc).Team("docs")
+
+	cases := []struct {
+		name         string
+		call         func() error
+		expectMethod string
+		expectPath   string
+	}{
+		{
+			name:         "team",
+			call:         func() error { _, err := team.Get(ctx); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs",
+		},
+		{
+			name: "posts: list",
+			call: func() error {
+				// TeamName of the input is overwritten
+				out, err := team.Posts.List(ctx, &posttypes.ListPostsInput{TeamName: "other", Q: "in:dev"})
+				if err == nil && len(out.Posts) != 1 {
+					t.Errorf("got %d posts", len(out.Posts))
+				}
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts",
+		},
+		{
+			name:         "posts: list with nil input",
+			call:         func() error { _, err := team.Posts.List(ctx, nil); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts",
+		},
+		{
+			name:         "posts: get",
+			call:         func() error { _, err := team.Posts.Get(ctx, &posttypes.GetPostInput{PostNumber: p.Number}); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts/1",
+		},
+		{
+			name:         "posts: create",
+			call:         func() error { _, err := team.Posts.Create(ctx, &posttypes.CreatePostInput{Name: "new"}); return err },
+			expectMethod: "POST", expectPath: "/v1/teams/docs/posts",
+		},
+		{
+			name: "posts: update",
+			call: func() error {
+				_, err := team.Posts.Update(ctx, &posttypes.UpdatePostInput{PostNumber: 2, Name: "renamed"})
+				return err
+			},
+			expectMethod: "PATCH", expectPath: "/v1/teams/docs/posts/2",
+		},
+		{
+			name: "posts: modify",
+			call: func() error {
+				_, err := team.Posts.Modify(ctx, p.Number, func(p *models.Post) error {
+					p.BodyMD += "world\n"
+					return nil
+				})
+				return err
+			},
+			expectMethod: "PATCH", expectPath: "/v1/teams/docs/posts/1",
+		},
+		{
+			name:         "posts: delete",
+			call:         func() error { _, err := team.Posts.Delete(ctx, &posttypes.DeletePostInput{PostNumber: 2}); return err },
+			expectMethod: "DELETE", expectPath: "/v1/teams/docs/posts/2",
+		},
+		{
+			name: "revisions: list",
+			call: func() error {
+				_, err := team.Revisions.List(ctx, &revisiontypes.ListRevisionsInput{PostNumber: p.Number})
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts/1/revisions",
+		},
+		{
+			name: "revisions: diff",
+			call: func() error {
+				out, err := team.Revisions.Diff(ctx, &revisiontypes.DiffRevisionsInput{PostNumber: p.Number, From: 1, To: 2})
+				if err == nil && out.Diff == "" {
+					t.Error("diff is empty")
+				}
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts/1/revisions/2",
+		},
+		{
+			name:         "comments: list",
+			call:         func() error { _, err := team.Comments.List(ctx, nil); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/comments",
+		},
+		{
+			name: "comments: list for post",
+			call: func() error {
+				_, err := team.Comments.ListForPost(ctx, &commenttypes.ListPostCommentsInput{PostNumber: p.Number})
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts/1/comments",
+		},
+		{
+			name: "comments: create",
+			call: func() error {
+				_, err := team.Comments.Create(ctx, &commenttypes.CreateCommentInput{PostNumber: p.Number, BodyMD: "thanks"})
+				return err
+			},
+			expectMethod: "POST", expectPath: "/v1/teams/docs/posts/1/comments",
+		},
+		{
+			name: "comments: get",
+			call: func() error {
+				_, err := team.Comments.Get(ctx, &commenttypes.GetCommentInput{CommentID: cm.ID})
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/comments/1",
+		},
+		{
+			name: "comments: update",
+			call: func() error {
+				_, err := team.Comments.Update(ctx, &commenttypes.UpdateCommentInput{CommentID: cm.ID, BodyMD: gesa.String("LGTM!")})
+				return err
+			},
+			expectMethod: "PATCH", expectPath: "/v1/teams/docs/comments/1",
+		},
+		{
+			name: "stars: post",
+			call: func() error {
+				_, err := team.Stars.CreatePostStar(ctx, &startypes.CreatePostStarInput{PostNumber: p.Number, Body: "great"})
+				return err
+			},
+			expectMethod: "POST", expectPath: "/v1/teams/docs/posts/1/star",
+		},
+		{
+			name: "stars: list post stargazers",
+			call: func() error {
+				_, err := team.Stars.ListPostStargazers(ctx, &startypes.ListPostStargazersInput{PostNumber: p.Number})
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts/1/stargazers",
+		},
+		{
+			name: "stars: comment",
+			call: func() error {
+				_, err := team.Stars.CreateCommentStar(ctx, &startypes.CreateCommentStarInput{CommentID: cm.ID, Body: "great"})
+				return err
+			},
+			expectMethod: "POST", expectPath: "/v1/teams/docs/comments/1/star",
+		},
+		{
+			name: "stars: delete comment star",
+			call: func() error {
+				_, err := team.Stars.DeleteCommentStar(ctx, &startypes.DeleteCommentStarInput{CommentID: cm.ID})
+				return err
+			},
+			expectMethod: "DELETE", expectPath: "/v1/teams/docs/comments/1/star",
+		},
+		{
+			name: "comments: delete",
+			call: func() error {
+				_, err := team.Comments.Delete(ctx, &commenttypes.DeleteCommentInput{CommentID: cm.ID})
+				return err
+			},
+			expectMethod: "DELETE", expectPath: "/v1/teams/docs/comments/1",
+		},
+		{
+			name: "watches: create",
+			call: func() error {
+				_, err := team.Watches.Create(ctx, &watchtypes.CreateWatchInput{PostNumber: p.Number})
+				return err
+			},
+			expectMethod: "POST", expectPath: "/v1/teams/docs/posts/1/watch",
+		},
+		{
+			name: "watches: list",
+			call: func() error {
+				_, err := team.Watches.List(ctx, &watchtypes.ListWatchersInput{PostNumber: p.Number})
+				return err
+			},
+			expectMethod: "GET", expectPath: "/v1/teams/docs/posts/1/watchers",
+		},
+		{
+			name: "watches: delete",
+			call: func() error {
+				_, err := team.Watches.Delete(ctx, &watchtypes.DeleteWatchInput{PostNumber: p.Number})
+				return err
+			},
+			expectMethod: "DELETE", expectPath: "/v1/teams/docs/posts/1/watch",
+		},
+		{
+			name:         "members: list",
+			call:         func() error { _, err := team.Members.List(ctx, nil); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/members",
+		},
+		{
+			name: "members: delete",
+			call: func() error {
+				_, err := team.Members.Delete(ctx, &membertypes.DeleteMemberInput{ScreenNameOrEmail: "bob"})
+				return err
+			},
+			expectMethod: "DELETE", expectPath: "/v1/teams/docs/members/bob",
+		},
+		{
+			name:         "tags: list",
+			call:         func() error { _, err := team.Tags.List(ctx, &tagtypes.ListTagsInput{}); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/tags",
+		},
+		{
+			name: "emojis: create",
+			call: func() error {
+				_, err := team.Emojis.Create(ctx, &emojitypes.CreateEmojiInput{Code: "party", Image: gesa.String("aW1hZ2U=")})
+				return err
+			},
+			expectMethod: "POST", expectPath: "/v1/teams/docs/emojis",
+		},
+		{
+			name:         "emojis: list",
+			call:         func() error { _, err := team.Emojis.List(ctx, nil); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/emojis",
+		},
+		{
+			name: "emojis: delete",
+			call: func() error {
+				_, err := team.Emojis.Delete(ctx, &emojitypes.DeleteEmojiInput{Code: "party"})
+				return err
+			},
+			expectMethod: "DELETE", expectPath: "/v1/teams/docs/emojis/party",
+		},
+		{
+			name: "categories: batch move",
+			call: func() error {
+				_, err := team.Categories.BatchMove(ctx, &categorytypes.BatchMoveInput{From: "/dev/", To: "/archived/dev/"})
+				return err
+			},
+			expectMethod: "POST", expectPath: "/v1/teams/docs/categories/batch_move",
+		},
+		{
+			name:         "stats",
+			call:         func() error { _, err := team.Stats.Get(ctx); return err },
+			expectMethod: "GET", expectPath: "/v1/teams/docs/stats",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.NoError(c.call())
+
+			reqs := s.Requests()
+			if asst.NotEmpty(reqs) {
+				last := reqs[len(reqs)-1]
+				asst.Equal(c.expectMethod, last.Method)
+				asst.Equal(c.expectPath, last.Path)
+			}
+		})
+	}
+}
+
+func Test_InvitationService(t *testing.T) {
+	ctx := context.Background()
+
+	// esatest does not support the invitation APIs.
+	reqs := []string{}
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"invitations":[]}`))
+		default:
+			w.Write([]byte(`{"url":"https://docs.esa.io/team/invitations/member-xxx/join","invitations":[]}`))
+		}
+	}))
+	defer hs.Close()
+
+	gc, err := gesa.NewClient(&gesa.NewClientInput{AccessToken: "token", BaseURL: hs.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invitations := esa.New(gc).Team("docs").Invitations
+
+	cases := []struct {
+		name   string
+		call   func() error
+		expect string
+	}{
+		{
+			name:   "get url",
+			call:   func() error { _, err := invitations.GetURL(ctx); return err },
+			expect: "GET /v1/teams/docs/invitation",
+		},
+		{
+			name:   "regenerate url",
+			call:   func() error { _, err := invitations.RegenerateURL(ctx); return err },
+			expect: "POST /v1/teams/docs/invitation_regenerator",
+		},
+		{
+			name:   "list email",
+			call:   func() error { _, err := invitations.ListEmail(ctx, nil); return err },
+			expect: "GET /v1/teams/docs/invitations",
+		},
+		{
+			name: "create email",
+			call: func() error {
+				_, err := invitations.CreateEmail(ctx, &invitationtypes.CreateEmailInvitationsInput{Emails: []string{"bob@example.com"}})
+				return err
+			},
+			expect: "POST /v1/teams/docs/invitations",
+		},
+		{
+			name: "delete email",
+			call: func() error {
+				_, err := invitations.DeleteEmail(ctx, &invitationtypes.DeleteEmailInvitationInput{Code: "abc"})
+				return err
+			},
+			expect: "DELETE /v1/teams/docs/invitations/abc",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.NoError(c.call())
+			if asst.NotEmpty(reqs) {
+				asst.Equal(c.expect, reqs[len(reqs)-1])
+			}
+		})
+	}
+}
+
+func Test_Team_DoesNotModifyInput(t *testing.T) {
+	asst := assert.New(t)
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddToken("alice-token", "alice")
+	s.AddTeam(models.Team{Name: "docs"})
+
+	gc, err := s.NewClient("alice-token")
+	asst.NoError(err)
+
+	in := &posttypes.ListPostsInput{Q: "in:dev"}
+	_, err = esa.New(gc).Team("docs").Posts.List(context.Background(), in)
+	asst.NoError(err)
+	asst.Equal(&posttypes.ListPostsInput{Q: "in:dev"}, in)
+}
+
+func Test_Client(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddToken("alice-token", "alice")
+	s.AddTeam(models.Team{Name: "docs"})
+
+	gc, err := s.NewClient("alice-token")
+	asst.NoError(err)
+	c := esa.New(gc)
+
+	asst.Equal(gesa.IAPIClient(gc), c.APIClient())
+	asst.Equal("docs", c.Team("docs").Name())
+
+	me, err := c.GetMe(ctx, nil)
+	if asst.NoError(err) {
+		asst.Equal("alice", me.ScreenName)
+	}
+
+	teams, err := c.ListTeams(ctx, nil)
+	if asst.NoError(err) {
+		asst.NotEmpty(teams.Teams)
+	}
+}
@@ -0,0 +1,42 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/invitation"
+	"github.com/michimani/go-esa/esaapi/invitation/types"
+)
+
+// InvitationService calls the APIs of the invitation package.
+type InvitationService service
+
+// GetURL calls invitation.GetURLInvitation.
+func (s *InvitationService) GetURL(ctx context.Context) (*types.GetURLInvitationOutput, error) {
+	return invitation.GetURLInvitation(ctx, s.client, &types.GetURLInvitationInput{TeamName: s.teamName})
+}
+
+// RegenerateURL calls invitation.RegenerateURLInvitation.
+func (s *InvitationService) RegenerateURL(ctx context.Context) (*types.RegenerateURLInvitationOutput, error) {
+	return invitation.RegenerateURLInvitation(ctx, s.client, &types.RegenerateURLInvitationInput{TeamName: s.teamName})
+}
+
+// ListEmail calls invitation.ListEmailInvitations.
+func (s *InvitationService) ListEmail(ctx context.Context, in *types.ListEmailInvitationsInput) (*types.ListEmailInvitationsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return invitation.ListEmailInvitations(ctx, s.client, p)
+}
+
+// CreateEmail calls invitation.CreateEmailInvitations.
+func (s *InvitationService) CreateEmail(ctx context.Context, in *types.CreateEmailInvitationsInput) (*types.CreateEmailInvitationsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return invitation.CreateEmailInvitations(ctx, s.client, p)
+}
+
+// DeleteEmail calls invitation.DeleteEmailInvitation.
+func (s *InvitationService) DeleteEmail(ctx context.Context, in *types.DeleteEmailInvitationInput) (*types.DeleteEmailInvitationOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return invitation.DeleteEmailInvitation(ctx, s.client, p)
+}
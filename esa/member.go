@@ -0,0 +1,25 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/member"
+	"github.com/michimani/go-esa/esaapi/member/types"
+)
+
+// MemberService calls the APIs of the member package.
+type MemberService service
+
+// List calls member.ListMembers.
+func (s *MemberService) List(ctx context.Context, in *types.ListMembersInput) (*types.ListMembersOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return member.ListMembers(ctx, s.client, p)
+}
+
+// Delete calls member.DeleteMember.
+func (s *MemberService) Delete(ctx context.Context, in *types.DeleteMemberInput) (*types.DeleteMemberOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return member.DeleteMember(ctx, s.client, p)
+}
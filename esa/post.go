@@ -0,0 +1,51 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+)
+
+// PostService calls the APIs of the post package.
+type PostService service
+
+// List calls post.ListPosts.
+func (s *PostService) List(ctx context.Context, in *types.ListPostsInput) (*types.ListPostsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return post.ListPosts(ctx, s.client, p)
+}
+
+// Get calls post.GetPost.
+func (s *PostService) Get(ctx context.Context, in *types.GetPostInput) (*types.GetPostOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return post.GetPost(ctx, s.client, p)
+}
+
+// Create calls post.CreatePost.
+func (s *PostService) Create(ctx context.Context, in *types.CreatePostInput) (*types.CreatePostOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return post.CreatePost(ctx, s.client, p)
+}
+
+// Update calls post.UpdatePost.
+func (s *PostService) Update(ctx context.Context, in *types.UpdatePostInput) (*types.UpdatePostOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return post.UpdatePost(ctx, s.client, p)
+}
+
+// Delete calls post.DeletePost.
+func (s *PostService) Delete(ctx context.Context, in *types.DeletePostInput) (*types.DeletePostOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return post.DeletePost(ctx, s.client, p)
+}
+
+// Modify calls post.Modify.
+func (s *PostService) Modify(ctx context.Context, postNumber int, fn post.ModifyFunc, opts ...post.ModifyOption) (*types.UpdatePostOutput, error) {
+	return post.Modify(ctx, s.client, s.teamName, postNumber, fn, opts...)
+}
@@ -0,0 +1,32 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/revision"
+	"github.com/michimani/go-esa/esaapi/revision/types"
+)
+
+// RevisionService calls the APIs of the revision package.
+type RevisionService service
+
+// List calls revision.ListRevisions.
+func (s *RevisionService) List(ctx context.Context, in *types.ListRevisionsInput) (*types.ListRevisionsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return revision.ListRevisions(ctx, s.client, p)
+}
+
+// Get calls revision.GetRevision.
+func (s *RevisionService) Get(ctx context.Context, in *types.GetRevisionInput) (*types.GetRevisionOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return revision.GetRevision(ctx, s.client, p)
+}
+
+// Diff calls revision.DiffRevisions.
+func (s *RevisionService) Diff(ctx context.Context, in *types.DiffRevisionsInput) (*types.DiffRevisionsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return revision.DiffRevisions(ctx, s.client, p)
+}
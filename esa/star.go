@@ -0,0 +1,53 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/star"
+	"github.com/michimani/go-esa/esaapi/star/types"
+)
+
+// StarService calls the APIs of the star package.
+type StarService service
+
+// ListPostStargazers calls star.ListPostStargazers.
+func (s *StarService) ListPostStargazers(ctx context.Context, in *types.ListPostStargazersInput) (*types.ListPostStargazersOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return star.ListPostStargazers(ctx, s.client, p)
+}
+
+// CreatePostStar calls star.CreatePostStar.
+func (s *StarService) CreatePostStar(ctx context.Context, in *types.CreatePostStarInput) (*types.CreatePostStarOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return star.CreatePostStar(ctx, s.client, p)
+}
+
+// DeletePostStar calls star.DeletePostStar.
+func (s *StarService) DeletePostStar(ctx context.Context, in *types.DeletePostStarInput) (*types.DeletePostStarOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return star.DeletePostStar(ctx, s.client, p)
+}
+
+// ListCommentStargazers calls star.ListCommentStargazers.
+func (s *StarService) ListCommentStargazers(ctx context.Context, in *types.ListCommentStargazersInput) (*types.ListCommentStargazersOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return star.ListCommentStargazers(ctx, s.client, p)
+}
+
+// CreateCommentStar calls star.CreateCommentStar.
+func (s *StarService) CreateCommentStar(ctx context.Context, in *types.CreateCommentStarInput) (*types.CreateCommentStarOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return star.CreateCommentStar(ctx, s.client, p)
+}
+
+// DeleteCommentStar calls star.DeleteCommentStar.
+func (s *StarService) DeleteCommentStar(ctx context.Context, in *types.DeleteCommentStarInput) (*types.DeleteCommentStarOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return star.DeleteCommentStar(ctx, s.client, p)
+}
@@ -0,0 +1,16 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/stats"
+	"github.com/michimani/go-esa/esaapi/stats/types"
+)
+
+// StatsService calls the APIs of the stats package.
+type StatsService service
+
+// Get calls stats.GetStats.
+func (s *StatsService) Get(ctx context.Context) (*types.GetStatsOutput, error) {
+	return stats.GetStats(ctx, s.client, &types.GetStatsInput{TeamName: s.teamName})
+}
@@ -0,0 +1,18 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/tag"
+	"github.com/michimani/go-esa/esaapi/tag/types"
+)
+
+// TagService calls the APIs of the tag package.
+type TagService service
+
+// List calls tag.ListTags.
+func (s *TagService) List(ctx context.Context, in *types.ListTagsInput) (*types.ListTagsOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return tag.ListTags(ctx, s.client, p)
+}
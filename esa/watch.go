@@ -0,0 +1,32 @@
+package esa
+
+import (
+	"context"
+
+	"github.com/michimani/go-esa/esaapi/watch"
+	"github.com/michimani/go-esa/esaapi/watch/types"
+)
+
+// WatchService calls the APIs of the watch package.
+type WatchService service
+
+// List calls watch.ListWatchers.
+func (s *WatchService) List(ctx context.Context, in *types.ListWatchersInput) (*types.ListWatchersOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return watch.ListWatchers(ctx, s.client, p)
+}
+
+// Create calls watch.CreateWatch.
+func (s *WatchService) Create(ctx context.Context, in *types.CreateWatchInput) (*types.CreateWatchOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return watch.CreateWatch(ctx, s.client, p)
+}
+
+// Delete calls watch.DeleteWatch.
+func (s *WatchService) Delete(ctx context.Context, in *types.DeleteWatchInput) (*types.DeleteWatchOutput, error) {
+	p := clone(in)
+	p.TeamName = s.teamName
+	return watch.DeleteWatch(ctx, s.client, p)
+}
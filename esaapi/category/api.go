@@ -13,9 +13,9 @@ const (
 
 // BatchMove calls moving posts from a category to other API.
 // POST v1/teams/:team_name/categories/batch_move
-func BatchMove(ctx context.Context, c *gesa.Client, p *types.BatchMoveInput) (*types.BatchMoveOutput, error) {
+func BatchMove(ctx context.Context, c gesa.IAPIClient, p *types.BatchMoveInput) (*types.BatchMoveOutput, error) {
 	res := &types.BatchMoveOutput{}
-	if err := c.CallAPI(ctx, batchMoveEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "category.BatchMove"), batchMoveEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -2,8 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/michimani/go-esa/internal"
@@ -20,12 +18,12 @@ type BatchMoveInput struct {
 
 func (p *BatchMoveInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.From == "" || p.To == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "BatchMoveInput.TeamName, BatchMoveInput.From, BatchMoveInput.To")
+		return nil, internal.NewRequiredParameterEmptyError("BatchMoveInput.TeamName, BatchMoveInput.From, BatchMoveInput.To")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
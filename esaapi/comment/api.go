@@ -18,9 +18,9 @@ const (
 
 // ListPostComments calls getting all comments in a post API.
 // GET /:esa_api_version/teams/:team_name/posts/:post_number/comments
-func ListPostComments(ctx context.Context, c *gesa.Client, p *types.ListPostCommentsInput) (*types.ListPostCommentsOutput, error) {
+func ListPostComments(ctx context.Context, c gesa.IAPIClient, p *types.ListPostCommentsInput) (*types.ListPostCommentsOutput, error) {
 	res := &types.ListPostCommentsOutput{}
-	if err := c.CallAPI(ctx, listPostCommentsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "comment.ListPostComments"), listPostCommentsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -29,9 +29,9 @@ func ListPostComments(ctx context.Context, c *gesa.Client, p *types.ListPostComm
 
 // GetComment calls getting a comment API.
 // GET /:esa_api_version/teams/:team_name/comments/:comment_id
-func GetComment(ctx context.Context, c *gesa.Client, p *types.GetCommentInput) (*types.GetCommentOutput, error) {
+func GetComment(ctx context.Context, c gesa.IAPIClient, p *types.GetCommentInput) (*types.GetCommentOutput, error) {
 	res := &types.GetCommentOutput{}
-	if err := c.CallAPI(ctx, getCommentEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "comment.GetComment"), getCommentEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -40,9 +40,9 @@ func GetComment(ctx context.Context, c *gesa.Client, p *types.GetCommentInput) (
 
 // CreateComment calls creating a comment API.
 // POST /:esa_api_version/teams/:team_name/posts/:post_number/comments
-func CreateComment(ctx context.Context, c *gesa.Client, p *types.CreateCommentInput) (*types.CreateCommentOutput, error) {
+func CreateComment(ctx context.Context, c gesa.IAPIClient, p *types.CreateCommentInput) (*types.CreateCommentOutput, error) {
 	res := &types.CreateCommentOutput{}
-	if err := c.CallAPI(ctx, createCommentEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "comment.CreateComment"), createCommentEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -51,9 +51,9 @@ func CreateComment(ctx context.Context, c *gesa.Client, p *types.CreateCommentIn
 
 // UpdateComment calls updating a comment API.
 // PATCH /:esa_api_version/teams/:team_name/comments/:comment_id
-func UpdateComment(ctx context.Context, c *gesa.Client, p *types.UpdateCommentInput) (*types.UpdateCommentOutput, error) {
+func UpdateComment(ctx context.Context, c gesa.IAPIClient, p *types.UpdateCommentInput) (*types.UpdateCommentOutput, error) {
 	res := &types.UpdateCommentOutput{}
-	if err := c.CallAPI(ctx, updateCommentEndpoint, "PATCH", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "comment.UpdateComment"), updateCommentEndpoint, "PATCH", p, res); err != nil {
 		return nil, err
 	}
 
@@ -62,9 +62,9 @@ func UpdateComment(ctx context.Context, c *gesa.Client, p *types.UpdateCommentIn
 
 // DeleteComment calls deleting a comment API.
 // DELETE /:esa_api_version/teams/:team_name/comments/:comment_id
-func DeleteComment(ctx context.Context, c *gesa.Client, p *types.DeleteCommentInput) (*types.DeleteCommentOutput, error) {
+func DeleteComment(ctx context.Context, c gesa.IAPIClient, p *types.DeleteCommentInput) (*types.DeleteCommentOutput, error) {
 	res := &types.DeleteCommentOutput{}
-	if err := c.CallAPI(ctx, deleteCommentEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "comment.DeleteComment"), deleteCommentEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
@@ -73,9 +73,9 @@ func DeleteComment(ctx context.Context, c *gesa.Client, p *types.DeleteCommentIn
 
 // ListTeamComments calls getting all comments in team API.
 // GET /v1/teams/:team_name/comments
-func ListTeamComments(ctx context.Context, c *gesa.Client, p *types.ListTeamCommentsInput) (*types.ListTeamCommentsOutput, error) {
+func ListTeamComments(ctx context.Context, c gesa.IAPIClient, p *types.ListTeamCommentsInput) (*types.ListTeamCommentsOutput, error) {
 	res := &types.ListTeamCommentsOutput{}
-	if err := c.CallAPI(ctx, listTeamCommentsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "comment.ListTeamComments"), listTeamCommentsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
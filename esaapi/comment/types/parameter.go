@@ -2,8 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -35,14 +33,18 @@ func (p *ListPostCommentsInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListPostCommentsInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListPostCommentsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListPostCommentsInput.TeamName, ListPostCommentsInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("ListPostCommentsInput.TeamName, ListPostCommentsInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -67,12 +69,12 @@ type GetCommentInput struct {
 
 func (p *GetCommentInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.CommentID == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "GetCommentInput.TeamName, GetCommentInput.CommentID")
+		return nil, internal.NewRequiredParameterEmptyError("GetCommentInput.TeamName, GetCommentInput.CommentID")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":comment_id", Value: strconv.Itoa(p.CommentID)})
@@ -107,18 +109,18 @@ type createCommentPayloadComment struct {
 
 func (p *CreateCommentInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateCommentInput.TeamName, CreateCommentInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("CreateCommentInput.TeamName, CreateCommentInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
 
 	if p.BodyMD == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateCommentInput.BodyMD")
+		return nil, internal.NewRequiredParameterEmptyError("CreateCommentInput.BodyMD")
 	}
 
 	payload := &createCommentPayload{
@@ -163,12 +165,12 @@ type updateCommentPayloadComment struct {
 
 func (p *UpdateCommentInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.CommentID == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "UpdateCommentInput.TeamName, UpdateCommentInput.CommentID")
+		return nil, internal.NewRequiredParameterEmptyError("UpdateCommentInput.TeamName, UpdateCommentInput.CommentID")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":comment_id", Value: strconv.Itoa(p.CommentID)})
@@ -201,12 +203,12 @@ type DeleteCommentInput struct {
 
 func (p *DeleteCommentInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.CommentID == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeleteCommentInput.TeamName, DeleteCommentInput.CommentID")
+		return nil, internal.NewRequiredParameterEmptyError("DeleteCommentInput.TeamName, DeleteCommentInput.CommentID")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":comment_id", Value: strconv.Itoa(p.CommentID)})
@@ -241,14 +243,18 @@ func (p *ListTeamCommentsInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListTeamCommentsInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListTeamCommentsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListTeamCommentsInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("ListTeamCommentsInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -52,6 +52,27 @@ func Test_ListPostCommentsInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListPostCommentsInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListPostCommentsInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListPostCommentsInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListPostCommentsInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListPostCommentsInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListPostCommentsInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -522,6 +543,27 @@ func Test_ListTeamCommentsInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListTeamCommentsInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListTeamCommentsInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListTeamCommentsInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListTeamCommentsInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListTeamCommentsInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListTeamCommentsInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
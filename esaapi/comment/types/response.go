@@ -28,6 +28,14 @@ func (r *ListPostCommentsOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListPostCommentsOutput) PageItems() []models.Comment {
+	return r.Comments
+}
+
+func (r *ListPostCommentsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 // GetCommentOutput is struct for the response of
 // GET /v1/teams/:team_name/comments/:comment_id
 type GetCommentOutput struct {
@@ -102,3 +110,11 @@ func (r *ListTeamCommentsOutput) SetRateLimitInfo(h http.Header) {
 		r.RateLimitInfo = rri
 	}
 }
+
+func (r *ListTeamCommentsOutput) PageItems() []models.Comment {
+	return r.Comments
+}
+
+func (r *ListTeamCommentsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
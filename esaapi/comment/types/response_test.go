@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/michimani/go-esa/esaapi/comment/types"
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
 )
@@ -692,3 +693,75 @@ func Test_ListTeamCommentsOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListPostCommentsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListPostCommentsOutput
+		expect []models.Comment
+	}{
+		{"ok", &types.ListPostCommentsOutput{Comments: []models.Comment{{ID: 1}, {ID: 2}}}, []models.Comment{{ID: 1}, {ID: 2}}},
+		{"ok: empty", &types.ListPostCommentsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListPostCommentsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListPostCommentsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListPostCommentsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListPostCommentsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
+
+func Test_ListTeamCommentsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListTeamCommentsOutput
+		expect []models.Comment
+	}{
+		{"ok", &types.ListTeamCommentsOutput{Comments: []models.Comment{{ID: 1}, {ID: 2}}}, []models.Comment{{ID: 1}, {ID: 2}}},
+		{"ok: empty", &types.ListTeamCommentsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListTeamCommentsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListTeamCommentsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListTeamCommentsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListTeamCommentsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
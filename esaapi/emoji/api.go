@@ -15,9 +15,9 @@ const (
 
 // ListEmojis calls getting all emojis in the team API.
 // GET /v1/teams/:team_name/emojis
-func ListEmojis(ctx context.Context, c *gesa.Client, p *types.ListEmojisInput) (*types.ListEmojisOutput, error) {
+func ListEmojis(ctx context.Context, c gesa.IAPIClient, p *types.ListEmojisInput) (*types.ListEmojisOutput, error) {
 	res := &types.ListEmojisOutput{}
-	if err := c.CallAPI(ctx, listEmojisEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "emoji.ListEmojis"), listEmojisEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -26,9 +26,9 @@ func ListEmojis(ctx context.Context, c *gesa.Client, p *types.ListEmojisInput) (
 
 // CreateEmoji calls creating new emoji API.
 // POST /v1/teams/:team_name/emojis
-func CreateEmoji(ctx context.Context, c *gesa.Client, p *types.CreateEmojiInput) (*types.CreateEmojiOutput, error) {
+func CreateEmoji(ctx context.Context, c gesa.IAPIClient, p *types.CreateEmojiInput) (*types.CreateEmojiOutput, error) {
 	res := &types.CreateEmojiOutput{}
-	if err := c.CallAPI(ctx, createEmojiEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "emoji.CreateEmoji"), createEmojiEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -37,9 +37,9 @@ func CreateEmoji(ctx context.Context, c *gesa.Client, p *types.CreateEmojiInput)
 
 // DeleteEmoji calls deleting a emoji API.
 // DELETE /v1/teams/:team_name/emojis/:code
-func DeleteEmoji(ctx context.Context, c *gesa.Client, p *types.DeleteEmojiInput) (*types.DeleteEmojiOutput, error) {
+func DeleteEmoji(ctx context.Context, c gesa.IAPIClient, p *types.DeleteEmojiInput) (*types.DeleteEmojiOutput, error) {
 	res := &types.DeleteEmojiOutput{}
-	if err := c.CallAPI(ctx, deleteEmojiEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "emoji.DeleteEmoji"), deleteEmojiEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
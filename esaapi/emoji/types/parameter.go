@@ -2,8 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/michimani/go-esa/internal"
@@ -15,12 +13,12 @@ type ListEmojisInput struct {
 
 func (p *ListEmojisInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListEmojisInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("ListEmojisInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -51,12 +49,12 @@ type createEmojiPayloadEmoji struct {
 
 func (p *CreateEmojiInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.Code == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateEmojiInput.TeamName, CreateEmojiInput.Code")
+		return nil, internal.NewRequiredParameterEmptyError("CreateEmojiInput.TeamName, CreateEmojiInput.Code")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -87,12 +85,12 @@ type DeleteEmojiInput struct {
 
 func (p *DeleteEmojiInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.Code == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeleteEmojiInput.TeamName, DeleteEmojiInput.Code")
+		return nil, internal.NewRequiredParameterEmptyError("DeleteEmojiInput.TeamName, DeleteEmojiInput.Code")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":code", Value: p.Code})
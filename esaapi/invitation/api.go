@@ -17,9 +17,9 @@ const (
 
 // GetURLInvitation calls getting a invitation URL API.
 // GET /v1/teams/:team_name/invitation
-func GetURLInvitation(ctx context.Context, c *gesa.Client, p *types.GetURLInvitationInput) (*types.GetURLInvitationOutput, error) {
+func GetURLInvitation(ctx context.Context, c gesa.IAPIClient, p *types.GetURLInvitationInput) (*types.GetURLInvitationOutput, error) {
 	res := &types.GetURLInvitationOutput{}
-	if err := c.CallAPI(ctx, getURLInvitationEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "invitation.GetURLInvitation"), getURLInvitationEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -28,9 +28,9 @@ func GetURLInvitation(ctx context.Context, c *gesa.Client, p *types.GetURLInvita
 
 // RegenerateURLInvitation calls regenerating a invitation URL API.
 // POST /v1/teams/:team_name/invitation_regenerator
-func RegenerateURLInvitation(ctx context.Context, c *gesa.Client, p *types.RegenerateURLInvitationInput) (*types.RegenerateURLInvitationOutput, error) {
+func RegenerateURLInvitation(ctx context.Context, c gesa.IAPIClient, p *types.RegenerateURLInvitationInput) (*types.RegenerateURLInvitationOutput, error) {
 	res := &types.RegenerateURLInvitationOutput{}
-	if err := c.CallAPI(ctx, regenerateURLInvitationEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "invitation.RegenerateURLInvitation"), regenerateURLInvitationEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -39,9 +39,9 @@ func RegenerateURLInvitation(ctx context.Context, c *gesa.Client, p *types.Regen
 
 // ListEmailInvitations calls getting list of all email invitations API.
 // POST /v1/teams/:team_name/invitations
-func ListEmailInvitations(ctx context.Context, c *gesa.Client, p *types.ListEmailInvitationsInput) (*types.ListEmailInvitationsOutput, error) {
+func ListEmailInvitations(ctx context.Context, c gesa.IAPIClient, p *types.ListEmailInvitationsInput) (*types.ListEmailInvitationsOutput, error) {
 	res := &types.ListEmailInvitationsOutput{}
-	if err := c.CallAPI(ctx, listEmailInvitationsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "invitation.ListEmailInvitations"), listEmailInvitationsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -50,9 +50,9 @@ func ListEmailInvitations(ctx context.Context, c *gesa.Client, p *types.ListEmai
 
 // CreateEmailInvitations calls create email invitations API.
 // POST /v1/teams/:team_name/invitations
-func CreateEmailInvitations(ctx context.Context, c *gesa.Client, p *types.CreateEmailInvitationsInput) (*types.CreateEmailInvitationsOutput, error) {
+func CreateEmailInvitations(ctx context.Context, c gesa.IAPIClient, p *types.CreateEmailInvitationsInput) (*types.CreateEmailInvitationsOutput, error) {
 	res := &types.CreateEmailInvitationsOutput{}
-	if err := c.CallAPI(ctx, createEmailInvitationsEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "invitation.CreateEmailInvitations"), createEmailInvitationsEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -61,9 +61,9 @@ func CreateEmailInvitations(ctx context.Context, c *gesa.Client, p *types.Create
 
 // DeleteEmailInvitation calls delete email invitation API.
 // DELETE /v1/teams/:team_name/invitations/:code
-func DeleteEmailInvitation(ctx context.Context, c *gesa.Client, p *types.DeleteEmailInvitationInput) (*types.DeleteEmailInvitationOutput, error) {
+func DeleteEmailInvitation(ctx context.Context, c gesa.IAPIClient, p *types.DeleteEmailInvitationInput) (*types.DeleteEmailInvitationOutput, error) {
 	res := &types.DeleteEmailInvitationOutput{}
-	if err := c.CallAPI(ctx, deleteEmailInvitationEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "invitation.DeleteEmailInvitation"), deleteEmailInvitationEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
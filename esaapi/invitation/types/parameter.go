@@ -2,8 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/michimani/go-esa/gesa"
@@ -16,12 +14,12 @@ type GetURLInvitationInput struct {
 
 func (p *GetURLInvitationInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "GetURLInvitationInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("GetURLInvitationInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -38,12 +36,12 @@ type RegenerateURLInvitationInput struct {
 
 func (p *RegenerateURLInvitationInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "RegenerateURLInvitationInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("RegenerateURLInvitationInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -75,14 +73,18 @@ func (p *ListEmailInvitationsInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListEmailInvitationsInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListEmailInvitationsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListEmailInvitationsInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("ListEmailInvitationsInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -113,17 +115,17 @@ type createEmailInvitationsPayloadEmailInvitations struct {
 
 func (p *CreateEmailInvitationsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateEmailInvitationsInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("CreateEmailInvitationsInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
 	if len(p.Emails) == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateEmailInvitationsInput.Emails")
+		return nil, internal.NewRequiredParameterEmptyError("CreateEmailInvitationsInput.Emails")
 	}
 	payload := &createEmailInvitationsPayload{
 		Member: createEmailInvitationsPayloadEmailInvitations{
@@ -150,12 +152,12 @@ type DeleteEmailInvitationInput struct {
 
 func (p *DeleteEmailInvitationInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.Code == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeleteEmailInvitationInput.TeamName, DeleteEmailInvitationInput.Code")
+		return nil, internal.NewRequiredParameterEmptyError("DeleteEmailInvitationInput.TeamName, DeleteEmailInvitationInput.Code")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":code", Value: p.Code})
@@ -146,6 +146,27 @@ func Test_ListEmailInvitationsInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListEmailInvitationsInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListEmailInvitationsInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListEmailInvitationsInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListEmailInvitationsInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListEmailInvitationsInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListEmailInvitationsInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
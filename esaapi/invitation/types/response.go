@@ -50,6 +50,14 @@ func (r *ListEmailInvitationsOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListEmailInvitationsOutput) PageItems() []models.EmailInvitations {
+	return r.Invitations
+}
+
+func (r *ListEmailInvitationsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 type CreateEmailInvitationsOutput struct {
 	Invitations []models.EmailInvitations `json:"invitations"`
 
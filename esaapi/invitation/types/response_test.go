@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/michimani/go-esa/esaapi/invitation/types"
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
 )
@@ -578,3 +579,39 @@ func Test_DeleteEmailInvitationOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListEmailInvitationsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListEmailInvitationsOutput
+		expect []models.EmailInvitations
+	}{
+		{"ok", &types.ListEmailInvitationsOutput{Invitations: []models.EmailInvitations{{Code: "code1"}, {Code: "code2"}}}, []models.EmailInvitations{{Code: "code1"}, {Code: "code2"}}},
+		{"ok: empty", &types.ListEmailInvitationsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListEmailInvitationsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListEmailInvitationsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListEmailInvitationsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListEmailInvitationsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
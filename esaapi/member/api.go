@@ -14,9 +14,9 @@ const (
 
 // ListMembers calls getting members API.
 // GET /:esa_api_version/teams/:team_name/members
-func ListMembers(ctx context.Context, c *gesa.Client, p *types.ListMembersInput) (*types.ListMembersOutput, error) {
+func ListMembers(ctx context.Context, c gesa.IAPIClient, p *types.ListMembersInput) (*types.ListMembersOutput, error) {
 	res := &types.ListMembersOutput{}
-	if err := c.CallAPI(ctx, listMembersEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "member.ListMembers"), listMembersEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -25,9 +25,9 @@ func ListMembers(ctx context.Context, c *gesa.Client, p *types.ListMembersInput)
 
 // DeleteMember calls deleting a member API.
 // DELETE /:esa_api_version/teams/:team_name/members/:screen_name_or_email
-func DeleteMember(ctx context.Context, c *gesa.Client, p *types.DeleteMemberInput) (*types.DeleteMemberOutput, error) {
+func DeleteMember(ctx context.Context, c gesa.IAPIClient, p *types.DeleteMemberInput) (*types.DeleteMemberOutput, error) {
 	res := &types.DeleteMemberOutput{}
-	if err := c.CallAPI(ctx, deleteMemberEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "member.DeleteMember"), deleteMemberEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
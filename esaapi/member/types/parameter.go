@@ -1,9 +1,6 @@
 package types
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/michimani/go-esa/gesa"
 	"github.com/michimani/go-esa/internal"
 )
@@ -55,14 +52,18 @@ func (p *ListMembersInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListMembersInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListMembersInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListMembersInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("ListMembersInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -91,12 +92,12 @@ type DeleteMemberInput struct {
 
 func (p *DeleteMemberInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.ScreenNameOrEmail == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeleteMemberInput.TeamName, DeleteMemberInput.ScreenNameOrEmail")
+		return nil, internal.NewRequiredParameterEmptyError("DeleteMemberInput.TeamName, DeleteMemberInput.ScreenNameOrEmail")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":screen_name_or_email", Value: p.ScreenNameOrEmail})
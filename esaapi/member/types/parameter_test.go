@@ -90,6 +90,27 @@ func Test_ListMembersInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListMembersInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListMembersInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListMembersInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListMembersInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListMembersInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListMembersInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -26,6 +26,14 @@ func (r *ListMembersOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListMembersOutput) PageItems() []models.Member {
+	return r.Members
+}
+
+func (r *ListMembersOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 type DeleteMemberOutput struct {
 	RateLimitInfo *gesa.RateLimitInformation `json:"-"`
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/michimani/go-esa/esaapi/member/types"
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
 )
@@ -236,3 +237,39 @@ func Test_DeleteMemberOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListMembersOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListMembersOutput
+		expect []models.Member
+	}{
+		{"ok", &types.ListMembersOutput{Members: []models.Member{{ScreenName: "user1"}, {ScreenName: "user2"}}}, []models.Member{{ScreenName: "user1"}, {ScreenName: "user2"}}},
+		{"ok: empty", &types.ListMembersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListMembersOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListMembersOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListMembersOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListMembersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
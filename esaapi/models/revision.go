@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Revision struct {
+	Number    int        `json:"number"`
+	BodyMD    string     `json:"body_md"`
+	CreatedAt *time.Time `json:"created_at"`
+	User      User       `json:"user"`
+}
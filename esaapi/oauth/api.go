@@ -0,0 +1,87 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/michimani/go-esa/esaapi/oauth/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+)
+
+const (
+	authorizeEndpoint   = "https://api.esa.io/oauth/authorize"
+	createTokenEndpoint = "https://api.esa.io/oauth/token"
+	revokeTokenEndpoint = "https://api.esa.io/oauth/revoke"
+)
+
+// AuthorizeURL returns the URL of the authorization page to redirect users to.
+// The base URL of the client is used if the client is not nil.
+// GET /oauth/authorize
+func AuthorizeURL(c *gesa.Client, p *types.AuthorizeURLInput) (string, error) {
+	eap, err := p.EsaAPIParameter()
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := authorizeEndpoint
+	if base := c.BaseURL(); base != "" {
+		endpoint = strings.Replace(endpoint, gesa.DefaultBaseURL, base, 1)
+	}
+
+	return internal.ResolveEndpoint(endpoint, eap.Path, eap.Query), nil
+}
+
+// CreateToken calls issuing an access token API with an authorization code.
+// The client does not need an access token, so the one generated by
+// gesa.NewUnauthenticatedClient can be used.
+// POST /oauth/token
+func CreateToken(ctx context.Context, c gesa.IAPIClient, p *types.CreateTokenInput) (*types.CreateTokenOutput, error) {
+	res := &types.CreateTokenOutput{}
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "oauth.CreateToken"), createTokenEndpoint, "POST", p, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RevokeToken calls revoking an access token API.
+// POST /oauth/revoke
+func RevokeToken(ctx context.Context, c gesa.IAPIClient, p *types.RevokeTokenInput) (*types.RevokeTokenOutput, error) {
+	res := &types.RevokeTokenOutput{}
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "oauth.RevokeToken"), revokeTokenEndpoint, "POST", p, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// NewClient generates *gesa.Client with the access token issued by CreateToken.
+// The other fields of NewClientInput are used as they are.
+func NewClient(token *types.CreateTokenOutput, in *gesa.NewClientInput) (*gesa.Client, error) {
+	if token == nil || token.AccessToken == "" {
+		return nil, errors.New("access token is empty")
+	}
+
+	ci := gesa.NewClientInput{}
+	if in != nil {
+		ci = *in
+	}
+	ci.AccessToken = token.AccessToken
+
+	return gesa.NewClient(&ci)
+}
+
+// GenerateState returns a random string to be used as the state parameter
+// for protection against CSRF.
+func GenerateState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
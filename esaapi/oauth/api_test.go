@@ -0,0 +1,118 @@
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/oauth"
+	"github.com/michimani/go-esa/esaapi/oauth/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthorizeURL(t *testing.T) {
+	customClient, _ := gesa.NewUnauthenticatedClient(&gesa.NewClientInput{BaseURL: "http://localhost:8080"})
+
+	cases := []struct {
+		name    string
+		client  *gesa.Client
+		p       *types.AuthorizeURLInput
+		expect  string
+		wantErr bool
+	}{
+		{
+			name:   "ok: nil client",
+			client: nil,
+			p: &types.AuthorizeURLInput{
+				ClientID:    "test-client-id",
+				RedirectURI: "https://example.com/callback",
+				Scopes:      []types.Scope{types.ScopeRead, types.ScopeWrite},
+				State:       "test-state",
+			},
+			expect: "https://api.esa.io/oauth/authorize?client_id=test-client-id&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback&response_type=code&scope=read+write&state=test-state",
+		},
+		{
+			name:   "ok: custom base url",
+			client: customClient,
+			p: &types.AuthorizeURLInput{
+				ClientID:    "test-client-id",
+				RedirectURI: "https://example.com/callback",
+			},
+			expect: "http://localhost:8080/oauth/authorize?client_id=test-client-id&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback&response_type=code",
+		},
+		{
+			name:    "ng: required parameters are empty",
+			client:  nil,
+			p:       &types.AuthorizeURLInput{},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			u, err := oauth.AuthorizeURL(c.client, c.p)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Empty(u)
+				return
+			}
+			asst.NoError(err)
+			asst.Equal(c.expect, u)
+		})
+	}
+}
+
+func Test_NewClient(t *testing.T) {
+	cases := []struct {
+		name    string
+		token   *types.CreateTokenOutput
+		in      *gesa.NewClientInput
+		wantErr bool
+	}{
+		{
+			name:  "ok",
+			token: &types.CreateTokenOutput{AccessToken: "test-token"},
+			in:    &gesa.NewClientInput{BaseURL: "http://localhost:8080"},
+		},
+		{
+			name:  "ok: nil input",
+			token: &types.CreateTokenOutput{AccessToken: "test-token"},
+			in:    nil,
+		},
+		{
+			name:    "ng: empty token",
+			token:   &types.CreateTokenOutput{},
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil token",
+			token:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			client, err := oauth.NewClient(c.token, c.in)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(client)
+				return
+			}
+			asst.NoError(err)
+			asst.Equal(c.token.AccessToken, client.AccessToken())
+		})
+	}
+}
+
+func Test_GenerateState(t *testing.T) {
+	asst := assert.New(t)
+	s1, err := oauth.GenerateState()
+	asst.NoError(err)
+	s2, err := oauth.GenerateState()
+	asst.NoError(err)
+
+	asst.Len(s1, 43)
+	asst.NotEqual(s1, s2)
+}
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/michimani/go-esa/internal"
+)
+
+type Scope string
+
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+)
+
+type AuthorizeURLInput struct {
+	// Query parameters
+	ClientID    string // required
+	RedirectURI string // required
+	Scopes      []Scope
+	State       string
+}
+
+func (p *AuthorizeURLInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	if p == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+
+	if p.ClientID == "" || p.RedirectURI == "" {
+		return nil, internal.NewRequiredParameterEmptyError("AuthorizeURLInput.ClientID, AuthorizeURLInput.RedirectURI")
+	}
+
+	qp := internal.QueryParameterList{
+		{Key: "client_id", Value: p.ClientID},
+		{Key: "redirect_uri", Value: p.RedirectURI},
+		{Key: "response_type", Value: "code"},
+	}
+	if len(p.Scopes) > 0 {
+		scopes := make([]string, 0, len(p.Scopes))
+		for _, s := range p.Scopes {
+			scopes = append(scopes, string(s))
+		}
+		qp = append(qp, internal.QueryParameter{Key: "scope", Value: strings.Join(scopes, " ")})
+	}
+	if p.State != "" {
+		qp = append(qp, internal.QueryParameter{Key: "state", Value: p.State})
+	}
+
+	return &internal.EsaAPIParameter{
+		Path:  internal.PathParameterList{},
+		Query: qp,
+		Body:  nil,
+	}, nil
+}
+
+type CreateTokenInput struct {
+	// Payload
+	ClientID     string // required
+	ClientSecret string // required
+	Code         string // required
+	RedirectURI  string // required
+}
+
+type createTokenPayload struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code"`
+	RedirectURI  string `json:"redirect_uri"`
+}
+
+func (p *CreateTokenInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	if p == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+
+	if p.ClientID == "" || p.ClientSecret == "" || p.Code == "" || p.RedirectURI == "" {
+		return nil, internal.NewRequiredParameterEmptyError("CreateTokenInput.ClientID, CreateTokenInput.ClientSecret, CreateTokenInput.Code, CreateTokenInput.RedirectURI")
+	}
+
+	payload := &createTokenPayload{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		GrantType:    "authorization_code",
+		Code:         p.Code,
+		RedirectURI:  p.RedirectURI,
+	}
+
+	json, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &internal.EsaAPIParameter{
+		Path:  internal.PathParameterList{},
+		Query: internal.QueryParameterList{},
+		Body:  strings.NewReader(string(json)),
+	}, nil
+}
+
+type RevokeTokenInput struct {
+	// Payload
+	ClientID     string // required
+	ClientSecret string // required
+	Token        string // required
+}
+
+type revokeTokenPayload struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Token        string `json:"token"`
+}
+
+func (p *RevokeTokenInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	if p == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+
+	if p.ClientID == "" || p.ClientSecret == "" || p.Token == "" {
+		return nil, internal.NewRequiredParameterEmptyError("RevokeTokenInput.ClientID, RevokeTokenInput.ClientSecret, RevokeTokenInput.Token")
+	}
+
+	payload := &revokeTokenPayload{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		Token:        p.Token,
+	}
+
+	json, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &internal.EsaAPIParameter{
+		Path:  internal.PathParameterList{},
+		Query: internal.QueryParameterList{},
+		Body:  strings.NewReader(string(json)),
+	}, nil
+}
@@ -0,0 +1,199 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/oauth/types"
+	"github.com/michimani/go-esa/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthorizeURLInput_EsaAPIParameter(t *testing.T) {
+	cases := []struct {
+		name    string
+		p       *types.AuthorizeURLInput
+		expect  *internal.EsaAPIParameter
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			p: &types.AuthorizeURLInput{
+				ClientID:    "test-client-id",
+				RedirectURI: "https://example.com/callback",
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{},
+				Query: internal.QueryParameterList{
+					{Key: "client_id", Value: "test-client-id"},
+					{Key: "redirect_uri", Value: "https://example.com/callback"},
+					{Key: "response_type", Value: "code"},
+				},
+			},
+		},
+		{
+			name: "ok: with all",
+			p: &types.AuthorizeURLInput{
+				ClientID:    "test-client-id",
+				RedirectURI: "https://example.com/callback",
+				Scopes:      []types.Scope{types.ScopeRead, types.ScopeWrite},
+				State:       "test-state",
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{},
+				Query: internal.QueryParameterList{
+					{Key: "client_id", Value: "test-client-id"},
+					{Key: "redirect_uri", Value: "https://example.com/callback"},
+					{Key: "response_type", Value: "code"},
+					{Key: "scope", Value: "read write"},
+					{Key: "state", Value: "test-state"},
+				},
+			},
+		},
+		{
+			name: "ng: not has required parameter: has only ClientID",
+			p: &types.AuthorizeURLInput{
+				ClientID: "test-client-id",
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name: "ng: not has required parameter: has only RedirectURI",
+			p: &types.AuthorizeURLInput{
+				RedirectURI: "https://example.com/callback",
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil",
+			p:       nil,
+			expect:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ep, err := c.p.EsaAPIParameter()
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(ep)
+				return
+			}
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_CreateTokenInput_EsaAPIParameter(t *testing.T) {
+	cases := []struct {
+		name    string
+		p       *types.CreateTokenInput
+		expect  *internal.EsaAPIParameter
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			p: &types.CreateTokenInput{
+				ClientID:     "test-client-id",
+				ClientSecret: "test-client-secret",
+				Code:         "test-code",
+				RedirectURI:  "https://example.com/callback",
+			},
+			expect: &internal.EsaAPIParameter{
+				Path:  internal.PathParameterList{},
+				Query: internal.QueryParameterList{},
+				Body:  strings.NewReader(`{"client_id":"test-client-id","client_secret":"test-client-secret","grant_type":"authorization_code","code":"test-code","redirect_uri":"https://example.com/callback"}`),
+			},
+		},
+		{
+			name: "ng: not has required parameter: no Code",
+			p: &types.CreateTokenInput{
+				ClientID:     "test-client-id",
+				ClientSecret: "test-client-secret",
+				RedirectURI:  "https://example.com/callback",
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: not has required parameter",
+			p:       &types.CreateTokenInput{},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil",
+			p:       nil,
+			expect:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ep, err := c.p.EsaAPIParameter()
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(ep)
+				return
+			}
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_RevokeTokenInput_EsaAPIParameter(t *testing.T) {
+	cases := []struct {
+		name    string
+		p       *types.RevokeTokenInput
+		expect  *internal.EsaAPIParameter
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			p: &types.RevokeTokenInput{
+				ClientID:     "test-client-id",
+				ClientSecret: "test-client-secret",
+				Token:        "test-token",
+			},
+			expect: &internal.EsaAPIParameter{
+				Path:  internal.PathParameterList{},
+				Query: internal.QueryParameterList{},
+				Body:  strings.NewReader(`{"client_id":"test-client-id","client_secret":"test-client-secret","token":"test-token"}`),
+			},
+		},
+		{
+			name: "ng: not has required parameter: no Token",
+			p: &types.RevokeTokenInput{
+				ClientID:     "test-client-id",
+				ClientSecret: "test-client-secret",
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil",
+			p:       nil,
+			expect:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ep, err := c.p.EsaAPIParameter()
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(ep)
+				return
+			}
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
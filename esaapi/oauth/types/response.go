@@ -0,0 +1,36 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/michimani/go-esa/gesa"
+)
+
+// CreateTokenOutput is struct for the response of
+// POST /oauth/token
+type CreateTokenOutput struct {
+	AccessToken string          `json:"access_token"`
+	TokenType   string          `json:"token_type"`
+	Scope       string          `json:"scope"`
+	CreatedAt   *gesa.Timestamp `json:"created_at,omitempty"`
+
+	RateLimitInfo *gesa.RateLimitInformation `json:"-"`
+}
+
+func (r *CreateTokenOutput) SetRateLimitInfo(h http.Header) {
+	if rri, err := gesa.GetRateLimitInformation(h); err == nil {
+		r.RateLimitInfo = rri
+	}
+}
+
+// RevokeTokenOutput is struct for the response of
+// POST /oauth/revoke
+type RevokeTokenOutput struct {
+	RateLimitInfo *gesa.RateLimitInformation `json:"-"`
+}
+
+func (r *RevokeTokenOutput) SetRateLimitInfo(h http.Header) {
+	if rri, err := gesa.GetRateLimitInformation(h); err == nil {
+		r.RateLimitInfo = rri
+	}
+}
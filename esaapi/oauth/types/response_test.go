@@ -0,0 +1,238 @@
+package types_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/oauth/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateTokenOutput_SetRateLimitInfo(t *testing.T) {
+	resetTimestamp := gesa.Timestamp(100000000)
+
+	cases := []struct {
+		name string
+		h    http.Header
+		want *types.CreateTokenOutput
+	}{
+		{
+			name: "normal",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: limit is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     0,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: remaining is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 0,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: reset is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     nil,
+				},
+			},
+		},
+		{
+			name: "error: invalid rate limit limit value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"a"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit remaining value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"a"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit reset value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"a"},
+			},
+			want: &types.CreateTokenOutput{
+				RateLimitInfo: nil,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			res := &types.CreateTokenOutput{}
+			res.SetRateLimitInfo(c.h)
+
+			asst.Equal(c.want.RateLimitInfo, res.RateLimitInfo)
+		})
+	}
+}
+
+func Test_RevokeTokenOutput_SetRateLimitInfo(t *testing.T) {
+	resetTimestamp := gesa.Timestamp(100000000)
+
+	cases := []struct {
+		name string
+		h    http.Header
+		want *types.RevokeTokenOutput
+	}{
+		{
+			name: "normal",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: limit is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     0,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: remaining is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 0,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: reset is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     nil,
+				},
+			},
+		},
+		{
+			name: "error: invalid rate limit limit value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"a"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit remaining value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"a"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit reset value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"a"},
+			},
+			want: &types.RevokeTokenOutput{
+				RateLimitInfo: nil,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			res := &types.RevokeTokenOutput{}
+			res.SetRateLimitInfo(c.h)
+
+			asst.Equal(c.want.RateLimitInfo, res.RateLimitInfo)
+		})
+	}
+}
@@ -13,9 +13,9 @@ const (
 
 // GetOAuthTokenInfo calls getting OAuth token information API.
 // GET /oauth/token/info
-func GetOAuthTokenInfo(ctx context.Context, c *gesa.Client, p *types.GetOAuthTokenInfoInput) (*types.GetOAuthTokenInfoOutput, error) {
+func GetOAuthTokenInfo(ctx context.Context, c gesa.IAPIClient, p *types.GetOAuthTokenInfoInput) (*types.GetOAuthTokenInfoOutput, error) {
 	res := &types.GetOAuthTokenInfoOutput{}
-	if err := c.CallAPI(ctx, getOAuthTokenInfoEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "oauthtoken.GetOAuthTokenInfo"), getOAuthTokenInfoEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
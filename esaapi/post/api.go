@@ -17,9 +17,9 @@ const (
 
 // ListPosts calls getting posts API.
 // GET /:esa_api_version/teams/:team_name/posts
-func ListPosts(ctx context.Context, c *gesa.Client, p *types.ListPostsInput) (*types.ListPostsOutput, error) {
+func ListPosts(ctx context.Context, c gesa.IAPIClient, p *types.ListPostsInput) (*types.ListPostsOutput, error) {
 	res := &types.ListPostsOutput{}
-	if err := c.CallAPI(ctx, listPostsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "post.ListPosts"), listPostsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -28,9 +28,9 @@ func ListPosts(ctx context.Context, c *gesa.Client, p *types.ListPostsInput) (*t
 
 // GetPost calls getting a post API.
 // GET /:esa_api_version/teams/:team_name/posts/:post_number
-func GetPost(ctx context.Context, c *gesa.Client, p *types.GetPostInput) (*types.GetPostOutput, error) {
+func GetPost(ctx context.Context, c gesa.IAPIClient, p *types.GetPostInput) (*types.GetPostOutput, error) {
 	res := &types.GetPostOutput{}
-	if err := c.CallAPI(ctx, getPostEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "post.GetPost"), getPostEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -39,9 +39,9 @@ func GetPost(ctx context.Context, c *gesa.Client, p *types.GetPostInput) (*types
 
 // CreatePost calls creating a new post API.
 // POST /:esa_api_version/teams/:team_name/posts
-func CreatePost(ctx context.Context, c *gesa.Client, p *types.CreatePostInput) (*types.CreatePostOutput, error) {
+func CreatePost(ctx context.Context, c gesa.IAPIClient, p *types.CreatePostInput) (*types.CreatePostOutput, error) {
 	res := &types.CreatePostOutput{}
-	if err := c.CallAPI(ctx, createPostEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "post.CreatePost"), createPostEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -50,9 +50,9 @@ func CreatePost(ctx context.Context, c *gesa.Client, p *types.CreatePostInput) (
 
 // UpdatePost calls updating a post API.
 // PATCH /:esa_api_version/teams/:team_name/posts/:post_number
-func UpdatePost(ctx context.Context, c *gesa.Client, p *types.UpdatePostInput) (*types.UpdatePostOutput, error) {
+func UpdatePost(ctx context.Context, c gesa.IAPIClient, p *types.UpdatePostInput) (*types.UpdatePostOutput, error) {
 	res := &types.UpdatePostOutput{}
-	if err := c.CallAPI(ctx, updatePostEndpoint, "PATCH", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "post.UpdatePost"), updatePostEndpoint, "PATCH", p, res); err != nil {
 		return nil, err
 	}
 
@@ -61,9 +61,9 @@ func UpdatePost(ctx context.Context, c *gesa.Client, p *types.UpdatePostInput) (
 
 // DeletePost calls updating a post API.
 // DELETE /:esa_api_version/teams/:team_name/posts/:post_number
-func DeletePost(ctx context.Context, c *gesa.Client, p *types.DeletePostInput) (*types.DeletePostOutput, error) {
+func DeletePost(ctx context.Context, c gesa.IAPIClient, p *types.DeletePostInput) (*types.DeletePostOutput, error) {
 	res := &types.DeletePostOutput{}
-	if err := c.CallAPI(ctx, deletePostEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "post.DeletePost"), deletePostEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,47 @@
+package post_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esamerge"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+)
+
+func ExampleModify() {
+	ctx := context.Background()
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddToken("alice-token", "alice")
+	s.AddToken("bob-token", "bob")
+	s.AddPost("docs", models.Post{Name: "tasks", BodyMD: "# Tasks\n- [ ] deploy\n\n# Notes\n"})
+	c, _ := s.NewClient("alice-token")
+	bob, _ := s.NewClient("bob-token")
+
+	out, err := post.Modify(ctx, c, "docs", 1, func(p *models.Post) error {
+		// bob updates the post in the meantime
+		post.UpdatePost(ctx, bob, &types.UpdatePostInput{TeamName: "docs", PostNumber: 1, BodyMD: gesa.String("# Tasks\n- [x] deploy\n\n# Notes\n")})
+
+		p.BodyMD += "- released v1\n"
+		return nil
+	}, post.WithMergeFunc(func(ctx context.Context, ce *post.ConflictError) (string, error) {
+		return esamerge.Merge(ce.Base, ce.Remote, ce.Local).Resolve(esamerge.PreferLocal)
+	}))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(out.BodyMD)
+	// Output:
+	// # Tasks
+	// - [x] deploy
+	//
+	// # Notes
+	// - released v1
+}
@@ -0,0 +1,168 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaapi/revision"
+	revisiontypes "github.com/michimani/go-esa/esaapi/revision/types"
+	"github.com/michimani/go-esa/gesa"
+)
+
+// DefaultModifyMaxAttempts is the default number of updates sent by Modify.
+const DefaultModifyMaxAttempts = 3
+
+// ModifyFunc edits the post fetched by Modify.
+// The changes of Name, BodyMD, Tags, Category and Wip are sent.
+type ModifyFunc func(p *models.Post) error
+
+// MergeFunc resolves the conflict and returns the body to send.
+type MergeFunc func(ctx context.Context, conflict *ConflictError) (string, error)
+
+// ConflictError is returned by Modify when the post was updated by someone else
+// after it was fetched, and esa merged the bodies with conflict markers ("overlapped").
+type ConflictError struct {
+	TeamName   string
+	PostNumber int
+
+	// Base is the body of the revision the modification is based on.
+	Base string
+	// Local is the body sent by the modification.
+	Local string
+	// Remote is the body updated by someone else, which is the body of the revision
+	// before the one esa merged. It is the input of a three-way merge with Base and Local.
+	Remote string
+	// Post is the post updated by esa. Its BodyMD is the merged body that may contain conflict markers.
+	Post *models.Post
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("the post %d of %s was updated by someone else and has conflicts (revision %d)",
+		e.PostNumber, e.TeamName, e.Post.RevisionNumber)
+}
+
+// Is reports whether the target is gesa.ErrConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == gesa.ErrConflict
+}
+
+// ModifyOption configures Modify.
+type ModifyOption func(*modifyConfig)
+
+type modifyConfig struct {
+	merge       MergeFunc
+	maxAttempts int
+	message     *string
+}
+
+// WithMergeFunc sets the function that resolves conflicts.
+// Without it, Modify returns *ConflictError on the first conflict.
+func WithMergeFunc(f MergeFunc) ModifyOption {
+	return func(c *modifyConfig) {
+		c.merge = f
+	}
+}
+
+// WithMaxAttempts sets the number of updates sent, including the first one (default: DefaultModifyMaxAttempts).
+func WithMaxAttempts(n int) ModifyOption {
+	return func(c *modifyConfig) {
+		c.maxAttempts = n
+	}
+}
+
+// WithMessage sets the message of the revisions.
+func WithMessage(message string) ModifyOption {
+	return func(c *modifyConfig) {
+		c.message = gesa.String(message)
+	}
+}
+
+// Modify performs read-modify-write of the post with optimistic locking.
+// It fetches the post, edits it with the function, and updates it with the original revision.
+// If the post was updated by someone else in the meantime, esa stores the merged body with
+// conflict markers. Then the body updated by someone else is fetched from the revisions,
+// the merge function is called with *ConflictError, and the returned
+// body is sent based on the merged revision, until the update succeeds without conflicts
+// or the number of attempts is exhausted.
+func Modify(ctx context.Context, c gesa.IAPIClient, teamName string, postNumber int, fn ModifyFunc, opts ...ModifyOption) (*types.UpdatePostOutput, error) {
+	cfg := &modifyConfig{maxAttempts: DefaultModifyMaxAttempts}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	got, err := GetPost(ctx, c, &types.GetPostInput{TeamName: teamName, PostNumber: postNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	current := got.Post
+	edited := got.Post
+	edited.Tags = slices.Clone(got.Tags)
+	if err := fn(&edited); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		// The tags are never nil, so that removing all tags is sent as an empty array.
+		tags := make([]*string, 0, len(edited.Tags))
+		for _, t := range edited.Tags {
+			tags = append(tags, gesa.String(t))
+		}
+
+		in := &types.UpdatePostInput{
+			TeamName:   teamName,
+			PostNumber: postNumber,
+			Name:       edited.Name,
+			BodyMD:     gesa.String(edited.BodyMD),
+			Tags:       tags,
+			Category:   gesa.String(edited.Category),
+			Wip:        gesa.Bool(edited.Wip),
+			Message:    cfg.message,
+			OriginalRevision: &types.OriginalRevision{
+				BodyMD: gesa.String(current.BodyMD),
+				Number: gesa.Int(current.RevisionNumber),
+				User:   gesa.String(current.UpdatedBy.ScreenName),
+			},
+		}
+
+		res, err := UpdatePost(ctx, c, in)
+		if err != nil {
+			return nil, err
+		}
+		if !res.Overlapped {
+			return res, nil
+		}
+
+		remote, err := revision.GetRevision(ctx, c, &revisiontypes.GetRevisionInput{
+			TeamName:       teamName,
+			PostNumber:     postNumber,
+			RevisionNumber: res.RevisionNumber - 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		conflict := &ConflictError{
+			TeamName:   teamName,
+			PostNumber: postNumber,
+			Base:       current.BodyMD,
+			Local:      edited.BodyMD,
+			Remote:     remote.BodyMD,
+			Post:       &res.Post,
+		}
+		if cfg.merge == nil || attempt >= cfg.maxAttempts {
+			return nil, conflict
+		}
+
+		body, err := cfg.merge(ctx, conflict)
+		if err != nil {
+			return nil, err
+		}
+
+		current = res.Post
+		edited.BodyMD = body
+	}
+}
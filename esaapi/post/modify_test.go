@@ -0,0 +1,219 @@
+package post_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Modify(t *testing.T) {
+	errEdit := errors.New("edit error")
+
+	// updateConcurrently updates the body as another user, which causes a conflict.
+	updateConcurrently := func(c *gesa.Client, body string) {
+		_, err := post.UpdatePost(context.Background(), c, &types.UpdatePostInput{
+			TeamName: "docs", PostNumber: 1, BodyMD: gesa.String(body),
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	cases := []struct {
+		name string
+		// fn edits the post. bob is the client of another user.
+		fn     func(bob *gesa.Client) post.ModifyFunc
+		merge  func(bob *gesa.Client, calls *int) post.MergeFunc
+		opts   []post.ModifyOption
+		number int
+
+		expectBody     string
+		expectTags     []string
+		expectMerges   int
+		expectConflict bool
+		// expectLocal and expectRemote are the local and remote bodies of the conflict.
+		expectLocal  string
+		expectRemote string
+		expectErr    error
+		wantErr      bool
+	}{
+		{
+			name: "ok",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error {
+					p.BodyMD += "world\n"
+					p.Tags = append(p.Tags, "b")
+					return nil
+				}
+			},
+			opts:       []post.ModifyOption{post.WithMessage("append")},
+			expectBody: "hello\nworld\n",
+			expectTags: []string{"a", "b"},
+		},
+		{
+			name: "ok: all tags are removed",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error {
+					p.Tags = nil
+					return nil
+				}
+			},
+			expectBody: "hello\n",
+			expectTags: []string{},
+		},
+		{
+			name: "ok: conflict is resolved by the merge function",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error {
+					updateConcurrently(bob, "hello\nfrom bob\n")
+					p.BodyMD += "from alice\n"
+					return nil
+				}
+			},
+			merge: func(bob *gesa.Client, calls *int) post.MergeFunc {
+				return func(ctx context.Context, c *post.ConflictError) (string, error) {
+					*calls++
+					return "hello\nfrom bob\nfrom alice\n", nil
+				}
+			},
+			expectBody:   "hello\nfrom bob\nfrom alice\n",
+			expectTags:   []string{"a"},
+			expectMerges: 1,
+		},
+		{
+			name: "ng: conflict without the merge function",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error {
+					updateConcurrently(bob, "hello\nfrom bob\n")
+					p.BodyMD += "from alice\n"
+					return nil
+				}
+			},
+			expectConflict: true,
+			expectLocal:    "hello\nfrom alice\n",
+			expectRemote:   "hello\nfrom bob\n",
+			wantErr:        true,
+		},
+		{
+			name: "ng: conflicts until the attempts are exhausted",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error {
+					updateConcurrently(bob, "hello\nfrom bob\n")
+					p.BodyMD += "from alice\n"
+					return nil
+				}
+			},
+			merge: func(bob *gesa.Client, calls *int) post.MergeFunc {
+				return func(ctx context.Context, c *post.ConflictError) (string, error) {
+					*calls++
+					updateConcurrently(bob, "bob again\n")
+					return "resolved\n", nil
+				}
+			},
+			opts:           []post.ModifyOption{post.WithMaxAttempts(2)},
+			expectMerges:   1,
+			expectConflict: true,
+			expectLocal:    "resolved\n",
+			expectRemote:   "bob again\n",
+			wantErr:        true,
+		},
+		{
+			name: "ng: merge error",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error {
+					updateConcurrently(bob, "hello\nfrom bob\n")
+					p.BodyMD += "from alice\n"
+					return nil
+				}
+			},
+			merge: func(bob *gesa.Client, calls *int) post.MergeFunc {
+				return func(ctx context.Context, c *post.ConflictError) (string, error) {
+					*calls++
+					return "", errEdit
+				}
+			},
+			expectMerges: 1,
+			expectErr:    errEdit,
+			wantErr:      true,
+		},
+		{
+			name: "ng: edit error",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error { return errEdit }
+			},
+			expectErr: errEdit,
+			wantErr:   true,
+		},
+		{
+			name: "ng: not found",
+			fn: func(bob *gesa.Client) post.ModifyFunc {
+				return func(p *models.Post) error { return nil }
+			},
+			number:  404,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			s := esatest.NewServer()
+			defer s.Close()
+			s.AddToken("alice-token", "alice")
+			s.AddToken("bob-token", "bob")
+			s.AddPost("docs", models.Post{Name: "hello", BodyMD: "hello\n", Tags: []string{"a"}})
+
+			alice, _ := s.NewClient("alice-token")
+			bob, _ := s.NewClient("bob-token")
+
+			merges := 0
+			opts := c.opts
+			if c.merge != nil {
+				opts = append(opts, post.WithMergeFunc(c.merge(bob, &merges)))
+			}
+			number := c.number
+			if number == 0 {
+				number = 1
+			}
+
+			out, err := post.Modify(context.Background(), alice, "docs", number, c.fn(bob), opts...)
+			asst.Equal(c.expectMerges, merges)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(out)
+				if c.expectErr != nil {
+					asst.ErrorIs(err, c.expectErr)
+				}
+
+				var ce *post.ConflictError
+				asst.Equal(c.expectConflict, errors.As(err, &ce))
+				asst.Equal(c.expectConflict, errors.Is(err, gesa.ErrConflict))
+				if ce != nil {
+					asst.Equal("docs", ce.TeamName)
+					asst.Equal(1, ce.PostNumber)
+					asst.Equal(c.expectLocal, ce.Local)
+					asst.Equal(c.expectRemote, ce.Remote)
+					asst.Contains(ce.Post.BodyMD, "<<<<<<<")
+					p, _ := s.Post("docs", 1)
+					asst.Equal(p.RevisionNumber, ce.Post.RevisionNumber)
+				}
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expectBody, out.BodyMD)
+			asst.Equal(c.expectTags, out.Tags)
+			p, _ := s.Post("docs", 1)
+			asst.Equal(c.expectBody, p.BodyMD)
+			asst.ElementsMatch(c.expectTags, p.Tags)
+		})
+	}
+}
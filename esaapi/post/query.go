@@ -0,0 +1,446 @@
+package post
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Qualifier is the qualifier of a term of the search query, such as "user" of "user:michimani".
+type Qualifier string
+
+const (
+	QualifierKeyword   Qualifier = ""
+	QualifierTitle     Qualifier = "title"
+	QualifierBody      Qualifier = "body"
+	QualifierCategory  Qualifier = "category"
+	QualifierIn        Qualifier = "in"
+	QualifierOn        Qualifier = "on"
+	QualifierTag       Qualifier = "tag"
+	QualifierSharp     Qualifier = "#"
+	QualifierUser      Qualifier = "user"
+	QualifierUpdatedBy Qualifier = "updated_by"
+	QualifierComment   Qualifier = "comment"
+	QualifierStarred   Qualifier = "starred"
+	QualifierWatched   Qualifier = "watched"
+	QualifierWip       Qualifier = "wip"
+	QualifierKind      Qualifier = "kind"
+	QualifierCreated   Qualifier = "created"
+	QualifierUpdated   Qualifier = "updated"
+	QualifierStars     Qualifier = "stars"
+	QualifierWatches   Qualifier = "watches"
+	QualifierComments  Qualifier = "comments"
+)
+
+var qualifiers = map[Qualifier]struct{}{
+	QualifierTitle:     {},
+	QualifierBody:      {},
+	QualifierCategory:  {},
+	QualifierIn:        {},
+	QualifierOn:        {},
+	QualifierTag:       {},
+	QualifierUser:      {},
+	QualifierUpdatedBy: {},
+	QualifierComment:   {},
+	QualifierStarred:   {},
+	QualifierWatched:   {},
+	QualifierWip:       {},
+	QualifierKind:      {},
+	QualifierCreated:   {},
+	QualifierUpdated:   {},
+	QualifierStars:     {},
+	QualifierWatches:   {},
+	QualifierComments:  {},
+}
+
+// IsComparable returns true if the value of the qualifier can have a comparison operator.
+func (q Qualifier) IsComparable() bool {
+	switch q {
+	case QualifierCreated, QualifierUpdated, QualifierStars, QualifierWatches, QualifierComments:
+		return true
+	}
+	return false
+}
+
+// Operator is the comparison operator of dates and counts, such as ">" of "stars:>3".
+type Operator string
+
+const (
+	OperatorEqual          Operator = ""
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+	OperatorLess           Operator = "<"
+	OperatorLessOrEqual    Operator = "<="
+)
+
+// operators is ordered so that the longer operators are matched first.
+var operators = []Operator{OperatorGreaterOrEqual, OperatorLessOrEqual, OperatorGreater, OperatorLess}
+
+func (o Operator) IsValid() bool {
+	switch o {
+	case OperatorEqual, OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual:
+		return true
+	}
+	return false
+}
+
+const (
+	KindStock = "stock"
+	KindFlow  = "flow"
+
+	// QueryDateLayout is the layout of dates in the search query.
+	QueryDateLayout = "2006-01-02"
+)
+
+// Term is a term of the search query.
+type Term struct {
+	Qualifier Qualifier
+	Operator  Operator
+	Value     string
+	Negate    bool
+}
+
+// Not returns the negated term.
+func (t Term) Not() Term {
+	t.Negate = !t.Negate
+	return t
+}
+
+// String renders the term, quoting the value if needed.
+func (t Term) String() string {
+	sb := new(strings.Builder)
+	if t.Negate {
+		sb.WriteString("-")
+	}
+
+	switch t.Qualifier {
+	case QualifierKeyword:
+		// A keyword such as "user:foo" is quoted not to be parsed as a qualifier.
+		if strings.Contains(t.Value, ":") {
+			sb.WriteString(forceQuote(t.Value))
+		} else {
+			sb.WriteString(quote(t.Value))
+		}
+	case QualifierSharp:
+		sb.WriteString("#")
+		sb.WriteString(quote(t.Value))
+	default:
+		sb.WriteString(string(t.Qualifier))
+		sb.WriteString(":")
+		sb.WriteString(string(t.Operator))
+		sb.WriteString(quote(t.Value))
+	}
+
+	return sb.String()
+}
+
+// Keyword, Title and the other functions below return the term of each qualifier.
+func Keyword(s string) Term   { return Term{Qualifier: QualifierKeyword, Value: s} }
+func Title(s string) Term     { return Term{Qualifier: QualifierTitle, Value: s} }
+func Body(s string) Term      { return Term{Qualifier: QualifierBody, Value: s} }
+func Category(s string) Term  { return Term{Qualifier: QualifierCategory, Value: s} }
+func In(s string) Term        { return Term{Qualifier: QualifierIn, Value: s} }
+func On(s string) Term        { return Term{Qualifier: QualifierOn, Value: s} }
+func Tag(s string) Term       { return Term{Qualifier: QualifierTag, Value: s} }
+func Sharp(s string) Term     { return Term{Qualifier: QualifierSharp, Value: s} }
+func User(s string) Term      { return Term{Qualifier: QualifierUser, Value: s} }
+func UpdatedBy(s string) Term { return Term{Qualifier: QualifierUpdatedBy, Value: s} }
+func Comment(s string) Term   { return Term{Qualifier: QualifierComment, Value: s} }
+func Kind(s string) Term      { return Term{Qualifier: QualifierKind, Value: s} }
+
+func Starred(b bool) Term { return Term{Qualifier: QualifierStarred, Value: strconv.FormatBool(b)} }
+func Watched(b bool) Term { return Term{Qualifier: QualifierWatched, Value: strconv.FormatBool(b)} }
+func Wip(b bool) Term     { return Term{Qualifier: QualifierWip, Value: strconv.FormatBool(b)} }
+
+// Created returns the term of the created date. The time is formatted in its location.
+func Created(op Operator, t time.Time) Term {
+	return Term{Qualifier: QualifierCreated, Operator: op, Value: t.Format(QueryDateLayout)}
+}
+
+// Updated returns the term of the updated date. The time is formatted in its location.
+func Updated(op Operator, t time.Time) Term {
+	return Term{Qualifier: QualifierUpdated, Operator: op, Value: t.Format(QueryDateLayout)}
+}
+
+func Stars(op Operator, n int) Term {
+	return Term{Qualifier: QualifierStars, Operator: op, Value: strconv.Itoa(n)}
+}
+
+func Watches(op Operator, n int) Term {
+	return Term{Qualifier: QualifierWatches, Operator: op, Value: strconv.Itoa(n)}
+}
+
+func Comments(op Operator, n int) Term {
+	return Term{Qualifier: QualifierComments, Operator: op, Value: strconv.Itoa(n)}
+}
+
+// Query is the search query for ListPostsInput.Q.
+// Clauses are combined by AND, and terms in a clause are combined by OR.
+//
+//	q := post.NewQuery(post.User("michimani"), post.Wip(false)).
+//		Or(post.Tag("go"), post.Tag("golang")).
+//		And(post.In("archive").Not())
+//	q.String() // user:michimani wip:false (tag:go OR tag:golang) -in:archive
+type Query struct {
+	clauses [][]Term
+}
+
+// NewQuery returns the query that combines the terms by AND.
+func NewQuery(terms ...Term) *Query {
+	return (&Query{}).And(terms...)
+}
+
+// And adds the terms combined by AND.
+func (q *Query) And(terms ...Term) *Query {
+	for _, t := range terms {
+		q.clauses = append(q.clauses, []Term{t})
+	}
+	return q
+}
+
+// Or adds the clause that combines the terms by OR.
+func (q *Query) Or(terms ...Term) *Query {
+	if len(terms) > 0 {
+		q.clauses = append(q.clauses, append([]Term{}, terms...))
+	}
+	return q
+}
+
+// Clauses returns the clauses of the query. Terms in a clause are combined by OR.
+func (q *Query) Clauses() [][]Term {
+	if q == nil {
+		return nil
+	}
+
+	cs := make([][]Term, 0, len(q.clauses))
+	for _, c := range q.clauses {
+		cs = append(cs, append([]Term{}, c...))
+	}
+	return cs
+}
+
+// String renders the query. OR clauses are enclosed in parentheses if the query has other clauses.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(q.clauses))
+	for _, c := range q.clauses {
+		terms := make([]string, 0, len(c))
+		for _, t := range c {
+			terms = append(terms, t.String())
+		}
+
+		part := strings.Join(terms, " OR ")
+		if len(c) > 1 && len(q.clauses) > 1 {
+			part = "(" + part + ")"
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// quote encloses the value in double quotes if it contains characters with special meaning.
+func quote(s string) string {
+	if s != "" && s != "OR" && !strings.ContainsAny(s, " \t\r\n\"()\\") && !strings.HasPrefix(s, "-") && !strings.HasPrefix(s, "#") {
+		return s
+	}
+
+	return forceQuote(s)
+}
+
+func forceQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// ParseQuery parses the search query rendered by Query.String or written by hand.
+// A token with an unknown qualifier such as "foo:bar" is regarded as a keyword.
+func ParseQuery(s string) (*Query, error) {
+	toks, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	for i := 0; i < len(toks); {
+		var clause []Term
+		if toks[i].text == "(" && !toks[i].quoted {
+			clause, i, err = parseGroup(toks, i+1)
+		} else {
+			clause, i, err = parseOrChain(toks, i)
+		}
+		if err != nil {
+			return nil, err
+		}
+		q.clauses = append(q.clauses, clause)
+	}
+
+	return q, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+	// negated is true if the quoted part follows a leading "-" such as -"user:foo".
+	// text has the "-" in that case.
+	negated bool
+}
+
+func (t token) is(s string) bool {
+	return !t.quoted && t.text == s
+}
+
+// parseGroup parses terms combined by OR until the closing parenthesis.
+func parseGroup(toks []token, i int) ([]Term, int, error) {
+	clause, i, err := parseOrChain(toks, i)
+	if err != nil {
+		return nil, 0, err
+	}
+	if i >= len(toks) || !toks[i].is(")") {
+		return nil, 0, errors.New("terms in parentheses must be combined by OR and closed")
+	}
+	return clause, i + 1, nil
+}
+
+// parseOrChain parses terms combined by OR such as "a OR b OR c".
+func parseOrChain(toks []token, i int) ([]Term, int, error) {
+	clause := []Term{}
+	for {
+		if i >= len(toks) || toks[i].is("(") || toks[i].is(")") || toks[i].is("OR") {
+			return nil, 0, fmt.Errorf("term is expected at position %d", i)
+		}
+		t, err := parseTerm(toks[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		clause = append(clause, t)
+		i++
+
+		if i >= len(toks) || !toks[i].is("OR") {
+			return clause, i, nil
+		}
+		i++
+	}
+}
+
+func parseTerm(tok token) (Term, error) {
+	s := tok.text
+	if tok.quoted {
+		// The whole token is quoted such as "foo bar" or -"foo bar".
+		if tok.negated {
+			return Keyword(s[1:]).Not(), nil
+		}
+		return Keyword(s), nil
+	}
+
+	t := Term{}
+	if strings.HasPrefix(s, "-") && len(s) > 1 {
+		t.Negate = true
+		s = s[1:]
+	}
+
+	if v, ok := strings.CutPrefix(s, "#"); ok && v != "" {
+		t.Qualifier = QualifierSharp
+		t.Value = v
+		return t, nil
+	}
+
+	key, v, ok := strings.Cut(s, ":")
+	if _, known := qualifiers[Qualifier(key)]; !ok || !known {
+		t.Value = s
+		return t, nil
+	}
+
+	t.Qualifier = Qualifier(key)
+	if t.Qualifier.IsComparable() {
+		for _, op := range operators {
+			if rest, ok := strings.CutPrefix(v, string(op)); ok {
+				t.Operator = op
+				v = rest
+				break
+			}
+		}
+	}
+	t.Value = v
+
+	switch t.Qualifier {
+	case QualifierStarred, QualifierWatched, QualifierWip:
+		if _, err := strconv.ParseBool(v); err != nil {
+			return Term{}, fmt.Errorf("invalid value of %s: %q", key, v)
+		}
+	case QualifierStars, QualifierWatches, QualifierComments:
+		if _, err := strconv.Atoi(v); err != nil {
+			return Term{}, fmt.Errorf("invalid value of %s: %q", key, v)
+		}
+	}
+
+	return t, nil
+}
+
+// tokenize splits the query into tokens.
+// Quoted parts are unquoted and joined to the adjacent characters, such as title:"foo bar".
+func tokenize(s string) ([]token, error) {
+	toks := []token{}
+	cur := new(strings.Builder)
+	inToken := false
+	// wholeQuoted is true while the current token consists only of a quoted part,
+	// optionally after a leading "-".
+	wholeQuoted := false
+	negated := false
+
+	flush := func() {
+		if inToken {
+			toks = append(toks, token{text: cur.String(), quoted: wholeQuoted, negated: wholeQuoted && negated})
+		}
+		cur.Reset()
+		inToken = false
+		wholeQuoted = false
+		negated = false
+	}
+
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '"':
+			negated = inToken && cur.String() == "-" && !wholeQuoted
+			wholeQuoted = !inToken || negated
+			inToken = true
+			closed := false
+			for i++; i < len(rs); i++ {
+				if rs[i] == '\\' && i+1 < len(rs) {
+					i++
+					cur.WriteRune(rs[i])
+					continue
+				}
+				if rs[i] == '"' {
+					closed = true
+					break
+				}
+				cur.WriteRune(rs[i])
+			}
+			if !closed {
+				return nil, errors.New("double quote is not closed")
+			}
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			flush()
+		case (r == '(' || r == ')') && !inToken:
+			toks = append(toks, token{text: string(r)})
+		case r == ')':
+			flush()
+			toks = append(toks, token{text: string(r)})
+		default:
+			wholeQuoted = false
+			inToken = true
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return toks, nil
+}
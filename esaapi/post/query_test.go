@@ -0,0 +1,257 @@
+package post_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Term_String(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		t      post.Term
+		expect string
+	}{
+		{name: "keyword", t: post.Keyword("esa"), expect: "esa"},
+		{name: "keyword with space", t: post.Keyword("hello world"), expect: `"hello world"`},
+		{name: "keyword with quote", t: post.Keyword(`say "hi"`), expect: `"say \"hi\""`},
+		{name: "keyword with colon", t: post.Keyword("user:foo"), expect: `"user:foo"`},
+		{name: "keyword starts with hyphen", t: post.Keyword("-foo"), expect: `"-foo"`},
+		{name: "keyword OR", t: post.Keyword("OR"), expect: `"OR"`},
+		{name: "empty keyword", t: post.Keyword(""), expect: `""`},
+		{name: "title", t: post.Title("release note"), expect: `title:"release note"`},
+		{name: "body", t: post.Body("TODO"), expect: "body:TODO"},
+		{name: "category", t: post.Category("dev/go"), expect: "category:dev/go"},
+		{name: "in", t: post.In("日報"), expect: "in:日報"},
+		{name: "on", t: post.On("dev"), expect: "on:dev"},
+		{name: "tag", t: post.Tag("go"), expect: "tag:go"},
+		{name: "sharp", t: post.Sharp("go"), expect: "#go"},
+		{name: "user", t: post.User("michimani"), expect: "user:michimani"},
+		{name: "updated_by", t: post.UpdatedBy("michimani"), expect: "updated_by:michimani"},
+		{name: "comment", t: post.Comment("LGTM"), expect: "comment:LGTM"},
+		{name: "starred", t: post.Starred(true), expect: "starred:true"},
+		{name: "watched", t: post.Watched(false), expect: "watched:false"},
+		{name: "wip", t: post.Wip(true), expect: "wip:true"},
+		{name: "kind", t: post.Kind(post.KindFlow), expect: "kind:flow"},
+		{name: "created", t: post.Created(post.OperatorGreater, date), expect: "created:>2024-01-02"},
+		{name: "updated", t: post.Updated(post.OperatorLessOrEqual, date), expect: "updated:<=2024-01-02"},
+		{name: "stars", t: post.Stars(post.OperatorGreaterOrEqual, 3), expect: "stars:>=3"},
+		{name: "watches", t: post.Watches(post.OperatorEqual, 1), expect: "watches:1"},
+		{name: "comments", t: post.Comments(post.OperatorLess, 2), expect: "comments:<2"},
+		{name: "negated", t: post.Tag("wip").Not(), expect: "-tag:wip"},
+		{name: "negated twice", t: post.Tag("wip").Not().Not(), expect: "tag:wip"},
+		{name: "negated keyword with space", t: post.Keyword("a b").Not(), expect: `-"a b"`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.t.String())
+		})
+	}
+}
+
+func Test_Query_String(t *testing.T) {
+	cases := []struct {
+		name   string
+		q      *post.Query
+		expect string
+	}{
+		{
+			name:   "nil",
+			q:      nil,
+			expect: "",
+		},
+		{
+			name:   "empty",
+			q:      post.NewQuery(),
+			expect: "",
+		},
+		{
+			name:   "and",
+			q:      post.NewQuery(post.User("michimani"), post.Wip(false)),
+			expect: "user:michimani wip:false",
+		},
+		{
+			name:   "only or",
+			q:      post.NewQuery().Or(post.Tag("go"), post.Tag("golang")),
+			expect: "tag:go OR tag:golang",
+		},
+		{
+			name: "and with or",
+			q: post.NewQuery(post.User("michimani"), post.Wip(false)).
+				Or(post.Tag("go"), post.Tag("golang")).
+				And(post.In("archive").Not()),
+			expect: "user:michimani wip:false (tag:go OR tag:golang) -in:archive",
+		},
+		{
+			name:   "or with a term",
+			q:      post.NewQuery().Or(post.Tag("go")),
+			expect: "tag:go",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.q.String())
+		})
+	}
+}
+
+func Test_ParseQuery(t *testing.T) {
+	cases := []struct {
+		name    string
+		s       string
+		expect  [][]post.Term
+		wantErr bool
+	}{
+		{
+			name:   "ok: empty",
+			s:      "  ",
+			expect: [][]post.Term{},
+		},
+		{
+			name: "ok: keywords",
+			s:    `esa "hello world"`,
+			expect: [][]post.Term{
+				{post.Keyword("esa")},
+				{post.Keyword("hello world")},
+			},
+		},
+		{
+			name: "ok: qualifiers",
+			s:    `user:michimani title:"release note" #go -tag:wip in:dev/go`,
+			expect: [][]post.Term{
+				{post.User("michimani")},
+				{post.Title("release note")},
+				{post.Sharp("go")},
+				{post.Tag("wip").Not()},
+				{post.In("dev/go")},
+			},
+		},
+		{
+			name: "ok: comparison",
+			s:    "created:>=2024-01-02 stars:>3 watches:1 comments:<2",
+			expect: [][]post.Term{
+				{{Qualifier: post.QualifierCreated, Operator: post.OperatorGreaterOrEqual, Value: "2024-01-02"}},
+				{post.Stars(post.OperatorGreater, 3)},
+				{post.Watches(post.OperatorEqual, 1)},
+				{post.Comments(post.OperatorLess, 2)},
+			},
+		},
+		{
+			name: "ok: operator is not parsed for non comparable qualifiers",
+			s:    "title:>foo",
+			expect: [][]post.Term{
+				{post.Title(">foo")},
+			},
+		},
+		{
+			name: "ok: or",
+			s:    "tag:go OR tag:golang wip:false",
+			expect: [][]post.Term{
+				{post.Tag("go"), post.Tag("golang")},
+				{post.Wip(false)},
+			},
+		},
+		{
+			name: "ok: or in parentheses",
+			s:    "wip:false (tag:go OR #golang) -in:archive",
+			expect: [][]post.Term{
+				{post.Wip(false)},
+				{post.Tag("go"), post.Sharp("golang")},
+				{post.In("archive").Not()},
+			},
+		},
+		{
+			name: "ok: unknown qualifier is keyword",
+			s:    "foo:bar",
+			expect: [][]post.Term{
+				{post.Keyword("foo:bar")},
+			},
+		},
+		{
+			name: "ok: quoted special characters",
+			s:    `"user:foo" "-bar" "OR" "say \"hi\"" "(x)"`,
+			expect: [][]post.Term{
+				{post.Keyword("user:foo")},
+				{post.Keyword("-bar")},
+				{post.Keyword("OR")},
+				{post.Keyword(`say "hi"`)},
+				{post.Keyword("(x)")},
+			},
+		},
+		{
+			name:    "ng: unclosed quote",
+			s:       `title:"foo`,
+			wantErr: true,
+		},
+		{
+			name:    "ng: unclosed parenthesis",
+			s:       "(tag:go OR tag:golang",
+			wantErr: true,
+		},
+		{
+			name:    "ng: and in parentheses",
+			s:       "(tag:go tag:golang)",
+			wantErr: true,
+		},
+		{
+			name:    "ng: dangling or",
+			s:       "tag:go OR",
+			wantErr: true,
+		},
+		{
+			name:    "ng: invalid bool",
+			s:       "wip:maybe",
+			wantErr: true,
+		},
+		{
+			name:    "ng: invalid count",
+			s:       "stars:>many",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			q, err := post.ParseQuery(c.s)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(q)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, q.Clauses())
+		})
+	}
+}
+
+func Test_ParseQuery_RoundTrip(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	queries := []*post.Query{
+		post.NewQuery(post.Keyword("hello world"), post.Keyword("user:foo"), post.Keyword(`a\b"c`)),
+		post.NewQuery(post.Keyword("user:foo").Not(), post.Keyword("hello world").Not(), post.Keyword("-x").Not()),
+		post.NewQuery(post.Title("(draft) note"), post.Category("a b/c"), post.Sharp("-x")),
+		post.NewQuery(post.Created(post.OperatorGreater, date), post.Stars(post.OperatorGreaterOrEqual, 2)).
+			Or(post.User("alice"), post.UpdatedBy("bob").Not()).
+			And(post.Comment("OR"), post.Kind(post.KindStock)),
+	}
+
+	for _, q := range queries {
+		t.Run(q.String(), func(tt *testing.T) {
+			asst := assert.New(tt)
+			parsed, err := post.ParseQuery(q.String())
+			asst.NoError(err)
+			asst.Equal(q.Clauses(), parsed.Clauses())
+			asst.Equal(q.String(), parsed.String())
+		})
+	}
+}
@@ -2,8 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -72,14 +70,18 @@ func (p *ListPostsInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListPostsInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListPostsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListPostsInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("ListPostsInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -118,12 +120,12 @@ type GetPostInput struct {
 
 func (p *GetPostInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "GetPostInput.TeamName, GetPostInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("GetPostInput.TeamName, GetPostInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -170,17 +172,17 @@ type createPostPayloadPost struct {
 
 func (p *CreatePostInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreatePostInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("CreatePostInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
 	if p.Name == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreatePostInput.Name")
+		return nil, internal.NewRequiredParameterEmptyError("CreatePostInput.Name")
 	}
 
 	payload := &createPostPayload{
@@ -215,7 +217,7 @@ type UpdatePostInput struct {
 	// Payload
 	Name             string // required
 	BodyMD           *string
-	Tags             []*string
+	Tags             []*string // nil keeps the tags, empty removes all
 	Category         *string
 	Wip              *bool
 	Message          *string
@@ -237,7 +239,7 @@ type updatePostPayload struct {
 type updatePostPayloadPost struct {
 	Name             string            `json:"name,omitempty"`
 	BodyMD           *string           `json:"body_md,omitempty"`
-	Tags             []*string         `json:"tags,omitempty"`
+	Tags             *[]*string        `json:"tags,omitempty"`
 	Category         *string           `json:"category,omitempty"`
 	Wip              *bool             `json:"wip,omitempty"`
 	Message          *string           `json:"message,omitempty"`
@@ -248,12 +250,12 @@ type updatePostPayloadPost struct {
 
 func (p *UpdatePostInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "UpdatePostInput.TeamName, UpdatePostInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("UpdatePostInput.TeamName, UpdatePostInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -262,7 +264,6 @@ func (p *UpdatePostInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 		Post: updatePostPayloadPost{
 			Name:             p.Name,
 			BodyMD:           p.BodyMD,
-			Tags:             p.Tags,
 			Category:         p.Category,
 			Wip:              p.Wip,
 			Message:          p.Message,
@@ -271,6 +272,9 @@ func (p *UpdatePostInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 			OriginalRevision: p.OriginalRevision,
 		},
 	}
+	if p.Tags != nil {
+		payload.Post.Tags = &p.Tags
+	}
 
 	json, err := json.Marshal(payload)
 	if err != nil {
@@ -292,12 +296,12 @@ type DeletePostInput struct {
 
 func (p *DeletePostInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeletePostInput.TeamName, DeletePostInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("DeletePostInput.TeamName, DeletePostInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
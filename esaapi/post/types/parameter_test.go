@@ -95,6 +95,27 @@ func Test_ListPostsInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListPostsInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListPostsInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListPostsInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListPostsInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListPostsInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListPostsInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -432,6 +453,38 @@ func Test_UpdatePostInput_EsaAPIParameter(t *testing.T) {
 				Body:  strings.NewReader(`{"post":{"body_md":"body"}}`),
 			},
 		},
+		{
+			name: "ok: tags",
+			p: &types.UpdatePostInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+				Tags:       []*string{gesa.String("a")},
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+				},
+				Query: internal.QueryParameterList{},
+				Body:  strings.NewReader(`{"post":{"tags":["a"]}}`),
+			},
+		},
+		{
+			name: "ok: empty tags remove all tags",
+			p: &types.UpdatePostInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+				Tags:       []*string{},
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+				},
+				Query: internal.QueryParameterList{},
+				Body:  strings.NewReader(`{"post":{"tags":[]}}`),
+			},
+		},
 		{
 			name: "ng: not has required parameter: post_number is empty",
 			p: &types.UpdatePostInput{
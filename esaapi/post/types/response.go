@@ -28,6 +28,14 @@ func (r *ListPostsOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListPostsOutput) PageItems() []models.Post {
+	return r.Posts
+}
+
+func (r *ListPostsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 // GetPostOutput is struct for the response of
 // GET /v1/teams/:team_name/posts/:post_number
 type GetPostOutput struct {
@@ -61,6 +69,10 @@ func (r *CreatePostOutput) SetRateLimitInfo(h http.Header) {
 type UpdatePostOutput struct {
 	models.Post
 
+	// Overlapped is true if the post was updated by someone else since OriginalRevision
+	// and esa merged the bodies. The merged body may contain conflict markers.
+	Overlapped bool `json:"overlapped"`
+
 	RateLimitInfo *gesa.RateLimitInformation `json:"-"`
 }
 
@@ -1,9 +1,11 @@
 package types_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/esaapi/post/types"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
@@ -578,3 +580,60 @@ func Test_DeletePostOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListPostsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListPostsOutput
+		expect []models.Post
+	}{
+		{"ok", &types.ListPostsOutput{Posts: []models.Post{{Number: 1}, {Number: 2}}}, []models.Post{{Number: 1}, {Number: 2}}},
+		{"ok: empty", &types.ListPostsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListPostsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListPostsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListPostsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListPostsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
+
+func Test_UpdatePostOutput_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		expect types.UpdatePostOutput
+	}{
+		{"ok: overlapped", `{"number":1,"body_md":"merged","overlapped":true}`, types.UpdatePostOutput{Post: models.Post{Number: 1, BodyMD: "merged"}, Overlapped: true}},
+		{"ok: not overlapped", `{"number":1,"overlapped":false}`, types.UpdatePostOutput{Post: models.Post{Number: 1}}},
+		{"ok: no overlapped field", `{"number":1}`, types.UpdatePostOutput{Post: models.Post{Number: 1}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			out := types.UpdatePostOutput{}
+			asst.NoError(json.Unmarshal([]byte(c.body), &out))
+			asst.Equal(c.expect, out)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package revision
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/michimani/go-esa/esaapi/revision/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+	"github.com/michimani/go-esa/internal/linediff"
+)
+
+const (
+	listRevisionsEndpoint = "https://api.esa.io/:esa_api_version/teams/:team_name/posts/:post_number/revisions"
+	getRevisionEndpoint   = "https://api.esa.io/:esa_api_version/teams/:team_name/posts/:post_number/revisions/:revision_number"
+
+	defaultDiffContext = 3
+)
+
+// ListRevisions calls getting revisions of a post API.
+// GET /:esa_api_version/teams/:team_name/posts/:post_number/revisions
+func ListRevisions(ctx context.Context, c gesa.IAPIClient, p *types.ListRevisionsInput) (*types.ListRevisionsOutput, error) {
+	res := &types.ListRevisionsOutput{}
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "revision.ListRevisions"), listRevisionsEndpoint, "GET", p, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetRevision calls getting a revision of a post API.
+// GET /:esa_api_version/teams/:team_name/posts/:post_number/revisions/:revision_number
+func GetRevision(ctx context.Context, c gesa.IAPIClient, p *types.GetRevisionInput) (*types.GetRevisionOutput, error) {
+	res := &types.GetRevisionOutput{}
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "revision.GetRevision"), getRevisionEndpoint, "GET", p, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DiffRevisions fetches two revisions of a post and returns the unified diff of their bodies.
+func DiffRevisions(ctx context.Context, c gesa.IAPIClient, p *types.DiffRevisionsInput) (*types.DiffRevisionsOutput, error) {
+	if p == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+	if p.From == 0 || p.To == 0 {
+		return nil, internal.NewRequiredParameterEmptyError("DiffRevisionsInput.From, DiffRevisionsInput.To")
+	}
+	if p.Context != nil && *p.Context < 0 {
+		return nil, &internal.ParameterError{
+			Message: "DiffRevisionsInput.Context must not be negative.",
+			Fields:  []string{"DiffRevisionsInput.Context"},
+		}
+	}
+
+	from, err := GetRevision(ctx, c, &types.GetRevisionInput{TeamName: p.TeamName, PostNumber: p.PostNumber, RevisionNumber: p.From})
+	if err != nil {
+		return nil, err
+	}
+	to, err := GetRevision(ctx, c, &types.GetRevisionInput{TeamName: p.TeamName, PostNumber: p.PostNumber, RevisionNumber: p.To})
+	if err != nil {
+		return nil, err
+	}
+
+	n := defaultDiffContext
+	if p.Context != nil {
+		n = *p.Context
+	}
+
+	return &types.DiffRevisionsOutput{
+		From: from.Revision,
+		To:   to.Revision,
+		Diff: UnifiedDiff("revision "+strconv.Itoa(p.From), "revision "+strconv.Itoa(p.To), from.BodyMD, to.BodyMD, n),
+	}, nil
+}
+
+// UnifiedDiff returns the unified diff of the bodies with n lines of context.
+// It returns an empty string if the bodies are the same.
+func UnifiedDiff(fromName, toName, from, to string, n int) string {
+	return linediff.Unified(fromName, toName, from, to, n)
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"strconv"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+)
+
+// ListRevisionsInput is struct for the parameter for
+// GET /v1/teams/:team_name/posts/:post_number/revisions
+type ListRevisionsInput struct {
+	TeamName   string
+	PostNumber int
+
+	Page    *gesa.PageNumber
+	PerPage *gesa.PageNumber
+}
+
+func (p *ListRevisionsInput) PageValue() (int, bool) {
+	if p.Page.IsNull() {
+		return 0, false
+	}
+	return p.Page.SafeInt(), true
+}
+
+func (p *ListRevisionsInput) PerPageValue() (int, bool) {
+	if p.PerPage.IsNull() {
+		return 0, false
+	}
+	return p.PerPage.SafeInt(), true
+}
+
+func (p *ListRevisionsInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
+func (p *ListRevisionsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	if p == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+
+	pp := internal.PathParameterList{}
+	if p.TeamName == "" || p.PostNumber == 0 {
+		return nil, internal.NewRequiredParameterEmptyError("ListRevisionsInput.TeamName, ListRevisionsInput.PostNumber")
+	}
+	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
+	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
+
+	qp := internal.QueryParameterList{}
+	pagination := internal.GeneratePaginationParameter(p)
+	qp = append(qp, pagination...)
+
+	return &internal.EsaAPIParameter{
+		Path:  pp,
+		Query: qp,
+		Body:  nil,
+	}, nil
+}
+
+// GetRevisionInput is struct for the parameter for
+// GET /v1/teams/:team_name/posts/:post_number/revisions/:revision_number
+type GetRevisionInput struct {
+	TeamName       string
+	PostNumber     int
+	RevisionNumber int
+}
+
+func (p *GetRevisionInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	if p == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+
+	pp := internal.PathParameterList{}
+	if p.TeamName == "" || p.PostNumber == 0 || p.RevisionNumber == 0 {
+		return nil, internal.NewRequiredParameterEmptyError("GetRevisionInput.TeamName, GetRevisionInput.PostNumber, GetRevisionInput.RevisionNumber")
+	}
+	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
+	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
+	pp = append(pp, internal.PathParameter{Key: ":revision_number", Value: strconv.Itoa(p.RevisionNumber)})
+
+	return &internal.EsaAPIParameter{
+		Path:  pp,
+		Query: internal.QueryParameterList{},
+		Body:  nil,
+	}, nil
+}
+
+// DiffRevisionsInput is struct for the parameter of revision.DiffRevisions.
+type DiffRevisionsInput struct {
+	TeamName   string
+	PostNumber int
+
+	// From and To are the revision numbers to compare.
+	From int
+	To   int
+
+	// Context is the number of the context lines (default: 3).
+	Context *int
+}
@@ -0,0 +1,252 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/revision/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListRevisionsInput_PageValue(t *testing.T) {
+	cases := []struct {
+		name       string
+		p          *types.ListRevisionsInput
+		expectInt  int
+		expectBool bool
+	}{
+		{"true", &types.ListRevisionsInput{Page: gesa.NewPageNumber(1)}, 1, true},
+		{"false", &types.ListRevisionsInput{}, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			i, b := c.p.PageValue()
+			asst.Equal(c.expectInt, i)
+			asst.Equal(c.expectBool, b)
+		})
+	}
+}
+
+func Test_ListRevisionsInput_PerPageValue(t *testing.T) {
+	cases := []struct {
+		name       string
+		p          *types.ListRevisionsInput
+		expectInt  int
+		expectBool bool
+	}{
+		{"true", &types.ListRevisionsInput{PerPage: gesa.NewPageNumber(1)}, 1, true},
+		{"false", &types.ListRevisionsInput{}, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			i, b := c.p.PerPageValue()
+			asst.Equal(c.expectInt, i)
+			asst.Equal(c.expectBool, b)
+		})
+	}
+}
+
+func Test_ListRevisionsInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListRevisionsInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListRevisionsInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListRevisionsInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListRevisionsInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
+func Test_ListRevisionsInput_EsaAPIParameter(t *testing.T) {
+	cases := []struct {
+		name    string
+		p       *types.ListRevisionsInput
+		expect  *internal.EsaAPIParameter
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			p: &types.ListRevisionsInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+				},
+				Query: internal.QueryParameterList{},
+			},
+		},
+		{
+			name: "with page",
+			p: &types.ListRevisionsInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+				Page:       gesa.NewPageNumber(1),
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+				},
+				Query: internal.QueryParameterList{
+					{Key: "page", Value: "1"},
+				},
+			},
+		},
+		{
+			name: "with per_page",
+			p: &types.ListRevisionsInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+				PerPage:    gesa.NewPageNumber(2),
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+				},
+				Query: internal.QueryParameterList{
+					{Key: "per_page", Value: "2"},
+				},
+			},
+		},
+		{
+			name: "with all",
+			p: &types.ListRevisionsInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+				Page:       gesa.NewPageNumber(1),
+				PerPage:    gesa.NewPageNumber(2),
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+				},
+				Query: internal.QueryParameterList{
+					{Key: "page", Value: "1"},
+					{Key: "per_page", Value: "2"},
+				},
+			},
+		},
+		{
+			name: "ng: not has required parameter: has only TeamName",
+			p: &types.ListRevisionsInput{
+				TeamName: "test-team",
+				Page:     gesa.NewPageNumber(1),
+				PerPage:  gesa.NewPageNumber(2),
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name: "ng: not has required parameter: has only PostNumber",
+			p: &types.ListRevisionsInput{
+				PostNumber: 1,
+				Page:       gesa.NewPageNumber(1),
+				PerPage:    gesa.NewPageNumber(2),
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil",
+			p:       nil,
+			expect:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ep, err := c.p.EsaAPIParameter()
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(ep)
+				return
+			}
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_GetRevisionInput_EsaAPIParameter(t *testing.T) {
+	cases := []struct {
+		name    string
+		p       *types.GetRevisionInput
+		expect  *internal.EsaAPIParameter
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			p: &types.GetRevisionInput{
+				TeamName:       "test-team",
+				PostNumber:     1,
+				RevisionNumber: 2,
+			},
+			expect: &internal.EsaAPIParameter{
+				Path: internal.PathParameterList{
+					{Key: ":team_name", Value: "test-team"},
+					{Key: ":post_number", Value: "1"},
+					{Key: ":revision_number", Value: "2"},
+				},
+				Query: internal.QueryParameterList{},
+			},
+		},
+		{
+			name: "ng: not has required parameter: has no RevisionNumber",
+			p: &types.GetRevisionInput{
+				TeamName:   "test-team",
+				PostNumber: 1,
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name: "ng: not has required parameter: has no TeamName",
+			p: &types.GetRevisionInput{
+				PostNumber:     1,
+				RevisionNumber: 2,
+			},
+			expect:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil",
+			p:       nil,
+			expect:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ep, err := c.p.EsaAPIParameter()
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(ep)
+				return
+			}
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/gesa"
+)
+
+// ListRevisionsOutput is struct for the response of
+// GET /v1/teams/:team_name/posts/:post_number/revisions
+type ListRevisionsOutput struct {
+	Revisions []models.Revision `json:"revisions"`
+
+	PrevPage   *gesa.PageNumber `json:"prev_page,omitempty"`
+	NextPage   *gesa.PageNumber `json:"next_page,omitempty"`
+	TotalCount int              `json:"total_count"`
+	Page       int              `json:"page"`
+	PerPage    int              `json:"per_page"`
+	MaxPerPage int              `json:"max_per_page"`
+
+	RateLimitInfo *gesa.RateLimitInformation `json:"-"`
+}
+
+func (r *ListRevisionsOutput) SetRateLimitInfo(h http.Header) {
+	if rri, err := gesa.GetRateLimitInformation(h); err == nil {
+		r.RateLimitInfo = rri
+	}
+}
+
+func (r *ListRevisionsOutput) PageItems() []models.Revision {
+	return r.Revisions
+}
+
+func (r *ListRevisionsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
+// GetRevisionOutput is struct for the response of
+// GET /v1/teams/:team_name/posts/:post_number/revisions/:revision_number
+type GetRevisionOutput struct {
+	models.Revision
+
+	RateLimitInfo *gesa.RateLimitInformation `json:"-"`
+}
+
+func (r *GetRevisionOutput) SetRateLimitInfo(h http.Header) {
+	if rri, err := gesa.GetRateLimitInformation(h); err == nil {
+		r.RateLimitInfo = rri
+	}
+}
+
+// DiffRevisionsOutput is struct for the result of revision.DiffRevisions.
+type DiffRevisionsOutput struct {
+	From models.Revision
+	To   models.Revision
+
+	// Diff is the unified diff of the bodies. It is empty if the bodies are the same.
+	Diff string
+}
@@ -0,0 +1,275 @@
+package types_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/revision/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListRevisionsOutput_SetRateLimitInfo(t *testing.T) {
+	resetTimestamp := gesa.Timestamp(100000000)
+
+	cases := []struct {
+		name string
+		h    http.Header
+		want *types.ListRevisionsOutput
+	}{
+		{
+			name: "normal",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: limit is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     0,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: remaining is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 0,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: reset is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     nil,
+				},
+			},
+		},
+		{
+			name: "error: invalid rate limit limit value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"a"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit remaining value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"a"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit reset value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"a"},
+			},
+			want: &types.ListRevisionsOutput{
+				RateLimitInfo: nil,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			res := &types.ListRevisionsOutput{}
+			res.SetRateLimitInfo(c.h)
+
+			asst.Equal(c.want.RateLimitInfo, res.RateLimitInfo)
+		})
+	}
+}
+
+func Test_GetRevisionOutput_SetRateLimitInfo(t *testing.T) {
+	resetTimestamp := gesa.Timestamp(100000000)
+
+	cases := []struct {
+		name string
+		h    http.Header
+		want *types.GetRevisionOutput
+	}{
+		{
+			name: "normal",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: limit is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     0,
+					Remaining: 100,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: remaining is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 0,
+					Reset:     &resetTimestamp,
+				},
+			},
+		},
+		{
+			name: "normal: reset is empty",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: &gesa.RateLimitInformation{
+					Limit:     1,
+					Remaining: 100,
+					Reset:     nil,
+				},
+			},
+		},
+		{
+			name: "error: invalid rate limit limit value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"a"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit remaining value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"a"},
+				"X-RateLimit-Reset":     []string{"100000000"},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: nil,
+			},
+		},
+		{
+			name: "error: invalid rate limit reset value",
+			h: http.Header{
+				"X-RateLimit-Limit":     []string{"1"},
+				"X-RateLimit-Remaining": []string{"100"},
+				"X-RateLimit-Reset":     []string{"a"},
+			},
+			want: &types.GetRevisionOutput{
+				RateLimitInfo: nil,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			res := &types.GetRevisionOutput{}
+			res.SetRateLimitInfo(c.h)
+
+			asst.Equal(c.want.RateLimitInfo, res.RateLimitInfo)
+		})
+	}
+}
+
+func Test_ListRevisionsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListRevisionsOutput
+		expect []models.Revision
+	}{
+		{"ok", &types.ListRevisionsOutput{Revisions: []models.Revision{{Number: 1}, {Number: 2}}}, []models.Revision{{Number: 1}, {Number: 2}}},
+		{"ok: empty", &types.ListRevisionsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListRevisionsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListRevisionsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListRevisionsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListRevisionsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
@@ -18,9 +18,9 @@ const (
 
 // ListPostStargazers calls getting all stargazers in a post API.
 // GET /v1/teams/:team_name/posts/:post_number/stargazers
-func ListPostStargazers(ctx context.Context, c *gesa.Client, p *types.ListPostStargazersInput) (*types.ListPostStargazersOutput, error) {
+func ListPostStargazers(ctx context.Context, c gesa.IAPIClient, p *types.ListPostStargazersInput) (*types.ListPostStargazersOutput, error) {
 	res := &types.ListPostStargazersOutput{}
-	if err := c.CallAPI(ctx, listPostStargazersEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "star.ListPostStargazers"), listPostStargazersEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -29,9 +29,9 @@ func ListPostStargazers(ctx context.Context, c *gesa.Client, p *types.ListPostSt
 
 // CreatePostStar calls getting all stargazers in a post API.
 // POST /v1/teams/:team_name/posts/:post_number/star
-func CreatePostStar(ctx context.Context, c *gesa.Client, p *types.CreatePostStarInput) (*types.CreatePostStarOutput, error) {
+func CreatePostStar(ctx context.Context, c gesa.IAPIClient, p *types.CreatePostStarInput) (*types.CreatePostStarOutput, error) {
 	res := &types.CreatePostStarOutput{}
-	if err := c.CallAPI(ctx, createPostStarEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "star.CreatePostStar"), createPostStarEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -40,9 +40,9 @@ func CreatePostStar(ctx context.Context, c *gesa.Client, p *types.CreatePostStar
 
 // DeletePostStar calls getting all stargazers in a post API.
 // DELETE /v1/teams/:team_name/posts/:post_number/star
-func DeletePostStar(ctx context.Context, c *gesa.Client, p *types.DeletePostStarInput) (*types.DeletePostStarOutput, error) {
+func DeletePostStar(ctx context.Context, c gesa.IAPIClient, p *types.DeletePostStarInput) (*types.DeletePostStarOutput, error) {
 	res := &types.DeletePostStarOutput{}
-	if err := c.CallAPI(ctx, deletePostStarEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "star.DeletePostStar"), deletePostStarEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
@@ -51,9 +51,9 @@ func DeletePostStar(ctx context.Context, c *gesa.Client, p *types.DeletePostStar
 
 // ListCommentStargazers calls getting all stargazers in a comment API.
 // GET /v1/teams/:team_name/comments/:comment_id/stargazers
-func ListCommentStargazers(ctx context.Context, c *gesa.Client, p *types.ListCommentStargazersInput) (*types.ListCommentStargazersOutput, error) {
+func ListCommentStargazers(ctx context.Context, c gesa.IAPIClient, p *types.ListCommentStargazersInput) (*types.ListCommentStargazersOutput, error) {
 	res := &types.ListCommentStargazersOutput{}
-	if err := c.CallAPI(ctx, listCommentStargazersEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "star.ListCommentStargazers"), listCommentStargazersEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -62,9 +62,9 @@ func ListCommentStargazers(ctx context.Context, c *gesa.Client, p *types.ListCom
 
 // CreateCommentStar calls getting all stargazers in a comment API.
 // POST /v1/teams/:team_name/comments/:comment_id/star
-func CreateCommentStar(ctx context.Context, c *gesa.Client, p *types.CreateCommentStarInput) (*types.CreateCommentStarOutput, error) {
+func CreateCommentStar(ctx context.Context, c gesa.IAPIClient, p *types.CreateCommentStarInput) (*types.CreateCommentStarOutput, error) {
 	res := &types.CreateCommentStarOutput{}
-	if err := c.CallAPI(ctx, createCommentStarEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "star.CreateCommentStar"), createCommentStarEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -73,9 +73,9 @@ func CreateCommentStar(ctx context.Context, c *gesa.Client, p *types.CreateComme
 
 // DeleteCommentStar calls getting all stargazers in a comment API.
 // DELETE /v1/teams/:team_name/comments/:comment_id/star
-func DeleteCommentStar(ctx context.Context, c *gesa.Client, p *types.DeleteCommentStarInput) (*types.DeleteCommentStarOutput, error) {
+func DeleteCommentStar(ctx context.Context, c gesa.IAPIClient, p *types.DeleteCommentStarInput) (*types.DeleteCommentStarOutput, error) {
 	res := &types.DeleteCommentStarOutput{}
-	if err := c.CallAPI(ctx, deleteCommentStarEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "star.DeleteCommentStar"), deleteCommentStarEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
@@ -2,8 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -35,14 +33,18 @@ func (p *ListPostStargazersInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListPostStargazersInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListPostStargazersInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListPostStargazersInput.TeamName, ListPostStargazersInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("ListPostStargazersInput.TeamName, ListPostStargazersInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -71,18 +73,18 @@ type CreatePostStarInput struct {
 
 func (p *CreatePostStarInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreatePostStarInput.TeamName, CreatePostStarInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("CreatePostStarInput.TeamName, CreatePostStarInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
 
 	if p.Body == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreatePostStarInput.Body")
+		return nil, internal.NewRequiredParameterEmptyError("CreatePostStarInput.Body")
 	}
 
 	json, err := json.Marshal(p)
@@ -106,12 +108,12 @@ type DeletePostStarInput struct {
 
 func (p *DeletePostStarInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeletePostStarInput.TeamName, ListPostStargazersInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("DeletePostStarInput.TeamName, ListPostStargazersInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -147,14 +149,18 @@ func (p *ListCommentStargazersInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListCommentStargazersInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListCommentStargazersInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.CommentID == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListCommentStargazersInput.TeamName, ListCommentStargazersInput.CommentID")
+		return nil, internal.NewRequiredParameterEmptyError("ListCommentStargazersInput.TeamName, ListCommentStargazersInput.CommentID")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":comment_id", Value: strconv.Itoa(p.CommentID)})
@@ -183,18 +189,18 @@ type CreateCommentStarInput struct {
 
 func (p *CreateCommentStarInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.CommentID == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateCommentStarInput.TeamName, CreateCommentStarInput.CommentID")
+		return nil, internal.NewRequiredParameterEmptyError("CreateCommentStarInput.TeamName, CreateCommentStarInput.CommentID")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":comment_id", Value: strconv.Itoa(p.CommentID)})
 
 	if p.Body == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateCommentStarInput.Body")
+		return nil, internal.NewRequiredParameterEmptyError("CreateCommentStarInput.Body")
 	}
 
 	json, err := json.Marshal(p)
@@ -218,12 +224,12 @@ type DeleteCommentStarInput struct {
 
 func (p *DeleteCommentStarInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.CommentID == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeleteCommentStarInput.TeamName, ListCommentStargazersInput.CommentID")
+		return nil, internal.NewRequiredParameterEmptyError("DeleteCommentStarInput.TeamName, ListCommentStargazersInput.CommentID")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":comment_id", Value: strconv.Itoa(p.CommentID)})
@@ -52,6 +52,27 @@ func Test_ListPostStargazersInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListPostStargazersInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListPostStargazersInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListPostStargazersInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListPostStargazersInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListPostStargazersInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListPostStargazersInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -345,6 +366,27 @@ func Test_ListCommentStargazersInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListCommentStargazersInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListCommentStargazersInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListCommentStargazersInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListCommentStargazersInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListCommentStargazersInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListCommentStargazersInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -28,6 +28,14 @@ func (r *ListPostStargazersOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListPostStargazersOutput) PageItems() []models.Stargazer {
+	return r.Stargazers
+}
+
+func (r *ListPostStargazersOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 // CreatePostStarOutput is struct for the response of
 // POST /v1/teams/:team_name/posts/:post_number/star
 type CreatePostStarOutput struct {
@@ -73,6 +81,14 @@ func (r *ListCommentStargazersOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListCommentStargazersOutput) PageItems() []models.Stargazer {
+	return r.Stargazers
+}
+
+func (r *ListCommentStargazersOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 // CreateCommentStarOutput is struct for the response of
 // POST /v1/teams/:team_name/comments/:comment_id/star
 type CreateCommentStarOutput struct {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/esaapi/star/types"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
@@ -692,3 +693,75 @@ func Test_DeleteCommentStarOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListPostStargazersOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListPostStargazersOutput
+		expect []models.Stargazer
+	}{
+		{"ok", &types.ListPostStargazersOutput{Stargazers: []models.Stargazer{{Body: "body1"}, {Body: "body2"}}}, []models.Stargazer{{Body: "body1"}, {Body: "body2"}}},
+		{"ok: empty", &types.ListPostStargazersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListPostStargazersOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListPostStargazersOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListPostStargazersOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListPostStargazersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
+
+func Test_ListCommentStargazersOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListCommentStargazersOutput
+		expect []models.Stargazer
+	}{
+		{"ok", &types.ListCommentStargazersOutput{Stargazers: []models.Stargazer{{Body: "body1"}, {Body: "body2"}}}, []models.Stargazer{{Body: "body1"}, {Body: "body2"}}},
+		{"ok: empty", &types.ListCommentStargazersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListCommentStargazersOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListCommentStargazersOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListCommentStargazersOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListCommentStargazersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
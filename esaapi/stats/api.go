@@ -13,9 +13,9 @@ const (
 
 // GetStats calls getting stats API.
 // GET /:esa_api_version/teams/:team_name/stats
-func GetStats(ctx context.Context, c *gesa.Client, p *types.GetStatsInput) (*types.GetStatsOutput, error) {
+func GetStats(ctx context.Context, c gesa.IAPIClient, p *types.GetStatsInput) (*types.GetStatsOutput, error) {
 	res := &types.GetStatsOutput{}
-	if err := c.CallAPI(ctx, getStatsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "stats.GetStats"), getStatsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
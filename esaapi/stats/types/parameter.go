@@ -1,11 +1,6 @@
 package types
 
-import (
-	"errors"
-	"fmt"
-
-	"github.com/michimani/go-esa/internal"
-)
+import "github.com/michimani/go-esa/internal"
 
 type GetStatsInput struct {
 	TeamName string
@@ -13,12 +8,12 @@ type GetStatsInput struct {
 
 func (p *GetStatsInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "GetStatsInput.TeamName")
+		return nil, internal.NewRequiredParameterEmptyError("GetStatsInput.TeamName")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 
@@ -13,9 +13,9 @@ const (
 
 // ListTags calls getting all tags API.
 // GET v1/teams/:team_name/tags
-func ListTags(ctx context.Context, c *gesa.Client, p *types.ListTagsInput) (*types.ListTagsOutput, error) {
+func ListTags(ctx context.Context, c gesa.IAPIClient, p *types.ListTagsInput) (*types.ListTagsOutput, error) {
 	res := &types.ListTagsOutput{}
-	if err := c.CallAPI(ctx, listTagsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "tag.ListTags"), listTagsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
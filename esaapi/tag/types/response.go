@@ -25,3 +25,11 @@ func (r *ListTagsOutput) SetRateLimitInfo(h http.Header) {
 		r.RateLimitInfo = rri
 	}
 }
+
+func (r *ListTagsOutput) PageItems() []models.Tag {
+	return r.Tags
+}
+
+func (r *ListTagsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
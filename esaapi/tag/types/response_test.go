@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/esaapi/tag/types"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
@@ -122,3 +123,39 @@ func Test_ListTagsOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListTagsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListTagsOutput
+		expect []models.Tag
+	}{
+		{"ok", &types.ListTagsOutput{Tags: []models.Tag{{Name: "tag1"}, {Name: "tag2"}}}, []models.Tag{{Name: "tag1"}, {Name: "tag2"}}},
+		{"ok: empty", &types.ListTagsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListTagsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListTagsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListTagsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListTagsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
@@ -14,9 +14,9 @@ const (
 
 // ListTeams calls getting teams API.
 // GET /:esa_api_version/teams
-func ListTeams(ctx context.Context, c *gesa.Client, p *types.ListTeamsInput) (*types.ListTeamsOutput, error) {
+func ListTeams(ctx context.Context, c gesa.IAPIClient, p *types.ListTeamsInput) (*types.ListTeamsOutput, error) {
 	res := &types.ListTeamsOutput{}
-	if err := c.CallAPI(ctx, listTeamsEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "team.ListTeams"), listTeamsEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -25,9 +25,9 @@ func ListTeams(ctx context.Context, c *gesa.Client, p *types.ListTeamsInput) (*t
 
 // GetTeam calls getting a team API.
 // GET /:esa_api_version/teams/:team_name
-func GetTeam(ctx context.Context, c *gesa.Client, p *types.GetTeamInput) (*types.GetTeamOutput, error) {
+func GetTeam(ctx context.Context, c gesa.IAPIClient, p *types.GetTeamInput) (*types.GetTeamOutput, error) {
 	res := &types.GetTeamOutput{}
-	if err := c.CallAPI(ctx, getTeamEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "team.GetTeam"), getTeamEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -51,6 +51,27 @@ func Test_ListTeamsInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListTeamsInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListTeamsInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListTeamsInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListTeamsInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListTeamsInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListTeamsInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
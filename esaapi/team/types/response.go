@@ -25,6 +25,14 @@ func (r *ListTeamsOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListTeamsOutput) PageItems() []models.Team {
+	return r.Teams
+}
+
+func (r *ListTeamsOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 type GetTeamOutput struct {
 	models.Team
 
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/esaapi/team/types"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
@@ -236,3 +237,39 @@ func Test_GetTeamOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListTeamsOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListTeamsOutput
+		expect []models.Team
+	}{
+		{"ok", &types.ListTeamsOutput{Teams: []models.Team{{Name: "team1"}, {Name: "team2"}}}, []models.Team{{Name: "team1"}, {Name: "team2"}}},
+		{"ok: empty", &types.ListTeamsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListTeamsOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListTeamsOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListTeamsOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListTeamsOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
@@ -13,9 +13,9 @@ const (
 
 // GetMe calls getting user using API.
 // GET /v1/user
-func GetMe(ctx context.Context, c *gesa.Client, p *types.GetMeInput) (*types.GetMeOutput, error) {
+func GetMe(ctx context.Context, c gesa.IAPIClient, p *types.GetMeInput) (*types.GetMeOutput, error) {
 	res := &types.GetMeOutput{}
-	if err := c.CallAPI(ctx, getMeEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "user.GetMe"), getMeEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -1,10 +1,6 @@
 package types
 
-import (
-	"errors"
-
-	"github.com/michimani/go-esa/internal"
-)
+import "github.com/michimani/go-esa/internal"
 
 type GetMeInput struct {
 	Include string
@@ -12,7 +8,7 @@ type GetMeInput struct {
 
 func (p *GetMeInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	qp := internal.QueryParameterList{}
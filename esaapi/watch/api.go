@@ -15,9 +15,9 @@ const (
 
 // ListWatchers calls getting all watchers in a post API.
 // GET /v1/teams/:team_name/posts/:post_number/watchers
-func ListWatchers(ctx context.Context, c *gesa.Client, p *types.ListWatchersInput) (*types.ListWatchersOutput, error) {
+func ListWatchers(ctx context.Context, c gesa.IAPIClient, p *types.ListWatchersInput) (*types.ListWatchersOutput, error) {
 	res := &types.ListWatchersOutput{}
-	if err := c.CallAPI(ctx, listWatchersEndpoint, "GET", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "watch.ListWatchers"), listWatchersEndpoint, "GET", p, res); err != nil {
 		return nil, err
 	}
 
@@ -26,9 +26,9 @@ func ListWatchers(ctx context.Context, c *gesa.Client, p *types.ListWatchersInpu
 
 // CreateWatch calls creating watch for a post API.
 // POST /v1/teams/:team_name/posts/:post_number/watch
-func CreateWatch(ctx context.Context, c *gesa.Client, p *types.CreateWatchInput) (*types.CreateWatchOutput, error) {
+func CreateWatch(ctx context.Context, c gesa.IAPIClient, p *types.CreateWatchInput) (*types.CreateWatchOutput, error) {
 	res := &types.CreateWatchOutput{}
-	if err := c.CallAPI(ctx, createWatchEndpoint, "POST", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "watch.CreateWatch"), createWatchEndpoint, "POST", p, res); err != nil {
 		return nil, err
 	}
 
@@ -37,9 +37,9 @@ func CreateWatch(ctx context.Context, c *gesa.Client, p *types.CreateWatchInput)
 
 // DeleteWatch calls deleting watch for a post API.
 // POST /v1/teams/:team_name/posts/:post_number/watch
-func DeleteWatch(ctx context.Context, c *gesa.Client, p *types.DeleteWatchInput) (*types.DeleteWatchOutput, error) {
+func DeleteWatch(ctx context.Context, c gesa.IAPIClient, p *types.DeleteWatchInput) (*types.DeleteWatchOutput, error) {
 	res := &types.DeleteWatchOutput{}
-	if err := c.CallAPI(ctx, deleteWatchEndpoint, "DELETE", p, res); err != nil {
+	if err := c.CallAPI(gesa.WithOperationName(ctx, "watch.DeleteWatch"), deleteWatchEndpoint, "DELETE", p, res); err != nil {
 		return nil, err
 	}
 
@@ -1,8 +1,6 @@
 package types
 
 import (
-	"errors"
-	"fmt"
 	"strconv"
 
 	"github.com/michimani/go-esa/gesa"
@@ -33,14 +31,18 @@ func (p *ListWatchersInput) PerPageValue() (int, bool) {
 	return p.PerPage.SafeInt(), true
 }
 
+func (p *ListWatchersInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
 func (p *ListWatchersInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "ListWatchersInput.TeamName, ListWatchersInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("ListWatchersInput.TeamName, ListWatchersInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -65,12 +67,12 @@ type CreateWatchInput struct {
 
 func (p *CreateWatchInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "CreateWatchInput.TeamName, CreateWatchInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("CreateWatchInput.TeamName, CreateWatchInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -91,12 +93,12 @@ type DeleteWatchInput struct {
 
 func (p *DeleteWatchInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
 	if p == nil {
-		return nil, errors.New(internal.ErrorParameterIsNil)
+		return nil, internal.NewParameterIsNilError()
 	}
 
 	pp := internal.PathParameterList{}
 	if p.TeamName == "" || p.PostNumber == 0 {
-		return nil, fmt.Errorf(internal.ErrorRequiredParameterEmpty, "DeleteWatchInput.TeamName, DeleteWatchInput.PostNumber")
+		return nil, internal.NewRequiredParameterEmptyError("DeleteWatchInput.TeamName, DeleteWatchInput.PostNumber")
 	}
 	pp = append(pp, internal.PathParameter{Key: ":team_name", Value: p.TeamName})
 	pp = append(pp, internal.PathParameter{Key: ":post_number", Value: strconv.Itoa(p.PostNumber)})
@@ -51,6 +51,27 @@ func Test_ListWatchersInput_PerPageValue(t *testing.T) {
 	}
 }
 
+func Test_ListWatchersInput_SetPage(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *types.ListWatchersInput
+		page   *gesa.PageNumber
+		expect *gesa.PageNumber
+	}{
+		{"set", &types.ListWatchersInput{}, gesa.NewPageNumber(2), gesa.NewPageNumber(2)},
+		{"overwrite", &types.ListWatchersInput{Page: gesa.NewPageNumber(1)}, gesa.NewPageNumber(3), gesa.NewPageNumber(3)},
+		{"nil", &types.ListWatchersInput{Page: gesa.NewPageNumber(1)}, nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			c.p.SetPage(c.page)
+			asst.Equal(c.expect, c.p.Page)
+		})
+	}
+}
+
 func Test_ListWatchersInput_EsaAPIParameter(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -28,6 +28,14 @@ func (r *ListWatchersOutput) SetRateLimitInfo(h http.Header) {
 	}
 }
 
+func (r *ListWatchersOutput) PageItems() []models.Watcher {
+	return r.Watchers
+}
+
+func (r *ListWatchersOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
 // CreateWatchOutput is struct for the response of
 // POST /v1/teams/:team_name/posts/:post_number/watch
 type CreateWatchOutput struct {
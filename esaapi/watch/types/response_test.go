@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/michimani/go-esa/esaapi/models"
 	"github.com/michimani/go-esa/esaapi/watch/types"
 	"github.com/michimani/go-esa/gesa"
 	"github.com/stretchr/testify/assert"
@@ -350,3 +351,39 @@ func Test_DeleteWatchOutput_SetRateLimitInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListWatchersOutput_PageItems(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListWatchersOutput
+		expect []models.Watcher
+	}{
+		{"ok", &types.ListWatchersOutput{Watchers: []models.Watcher{{User: models.User{ScreenName: "user1"}}, {User: models.User{ScreenName: "user2"}}}}, []models.Watcher{{User: models.User{ScreenName: "user1"}}, {User: models.User{ScreenName: "user2"}}}},
+		{"ok: empty", &types.ListWatchersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.PageItems())
+		})
+	}
+}
+
+func Test_ListWatchersOutput_NextPageNumber(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      *types.ListWatchersOutput
+		expect *gesa.PageNumber
+	}{
+		{"ok", &types.ListWatchersOutput{NextPage: gesa.NewPageNumber(2)}, gesa.NewPageNumber(2)},
+		{"ok: nil", &types.ListWatchersOutput{}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, c.r.NextPageNumber())
+		})
+	}
+}
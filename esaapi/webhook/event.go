@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+// Kind is the kind of the webhook event.
+type Kind string
+
+const (
+	KindPostCreate    Kind = "post_create"
+	KindPostUpdate    Kind = "post_update"
+	KindPostArchive   Kind = "post_archive"
+	KindPostDelete    Kind = "post_delete"
+	KindPostRestore   Kind = "post_restore"
+	KindCommentCreate Kind = "comment_create"
+	KindCommentUpdate Kind = "comment_update"
+	KindCommentDelete Kind = "comment_delete"
+	KindMemberJoin    Kind = "member_join"
+	KindMemberDelete  Kind = "member_delete"
+)
+
+// Team is the team where the event occurred.
+type Team struct {
+	Name string `json:"name"`
+}
+
+// Event is the payload of the webhook sent by esa.
+// Post, Comment and User are nil if they are not included in the payload.
+type Event struct {
+	Kind    Kind            `json:"kind"`
+	Team    Team            `json:"team"`
+	Post    *models.Post    `json:"post,omitempty"`
+	Comment *models.Comment `json:"comment,omitempty"`
+	User    *models.User    `json:"user,omitempty"`
+
+	// Raw is the raw payload.
+	Raw json.RawMessage `json:"-"`
+}
+
+type eventPayload struct {
+	Kind    Kind            `json:"kind"`
+	Team    Team            `json:"team"`
+	Post    *models.Post    `json:"post,omitempty"`
+	Comment *models.Comment `json:"comment,omitempty"`
+	User    *userPayload    `json:"user,omitempty"`
+}
+
+// userPayload is the user in the webhook payload.
+// Its icon is an object that has some sizes of URLs, unlike the esa API.
+type userPayload struct {
+	Name       string          `json:"name"`
+	ScreenName string          `json:"screen_name"`
+	Icon       json.RawMessage `json:"icon"`
+}
+
+type iconPayload struct {
+	URL string `json:"url"`
+}
+
+func (u *userPayload) user() *models.User {
+	if u == nil {
+		return nil
+	}
+
+	mu := &models.User{
+		Name:       u.Name,
+		ScreenName: u.ScreenName,
+	}
+
+	var s string
+	if err := json.Unmarshal(u.Icon, &s); err == nil {
+		mu.Icon = s
+		return mu
+	}
+
+	var ip iconPayload
+	if err := json.Unmarshal(u.Icon, &ip); err == nil {
+		mu.Icon = ip.URL
+	}
+
+	return mu
+}
+
+// ParseEvent decodes the payload of the webhook.
+func ParseEvent(body []byte) (*Event, error) {
+	p := eventPayload{}
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+
+	raw := make(json.RawMessage, len(body))
+	copy(raw, body)
+
+	return &Event{
+		Kind:    p.Kind,
+		Team:    p.Team,
+		Post:    p.Post,
+		Comment: p.Comment,
+		User:    p.User.user(),
+		Raw:     raw,
+	}, nil
+}
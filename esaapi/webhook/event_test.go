@@ -0,0 +1,93 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseEvent(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		expect  *webhook.Event
+		wantErr bool
+	}{
+		{
+			name: "ok: post_create",
+			body: `{"kind":"post_create","team":{"name":"docs"},"post":{"name":"hi!","body_md":"# Getting Started\n","wip":false,"number":1253,"url":"https://docs.esa.io/posts/1253"},"user":{"icon":{"url":"https://example.com/icon.png","thumb_s":{"url":"https://example.com/thumb_s.png"}},"name":"Atsuo Fukaya","screen_name":"fukayatsu"}}`,
+			expect: &webhook.Event{
+				Kind: webhook.KindPostCreate,
+				Team: webhook.Team{Name: "docs"},
+				Post: &models.Post{
+					Name:   "hi!",
+					BodyMD: "# Getting Started\n",
+					Number: 1253,
+					URL:    "https://docs.esa.io/posts/1253",
+				},
+				User: &models.User{
+					Name:       "Atsuo Fukaya",
+					ScreenName: "fukayatsu",
+					Icon:       "https://example.com/icon.png",
+				},
+			},
+		},
+		{
+			name: "ok: comment_create, icon is string",
+			body: `{"kind":"comment_create","team":{"name":"docs"},"post":{"name":"hi!","number":1253},"comment":{"body_md":"LGTM!","url":"https://docs.esa.io/posts/1253#comment-1"},"user":{"icon":"https://example.com/icon.png","name":"Atsuo Fukaya","screen_name":"fukayatsu"}}`,
+			expect: &webhook.Event{
+				Kind: webhook.KindCommentCreate,
+				Team: webhook.Team{Name: "docs"},
+				Post: &models.Post{
+					Name:   "hi!",
+					Number: 1253,
+				},
+				Comment: &models.Comment{
+					BodyMD: "LGTM!",
+					URL:    "https://docs.esa.io/posts/1253#comment-1",
+				},
+				User: &models.User{
+					Name:       "Atsuo Fukaya",
+					ScreenName: "fukayatsu",
+					Icon:       "https://example.com/icon.png",
+				},
+			},
+		},
+		{
+			name: "ok: member_join, no icon",
+			body: `{"kind":"member_join","team":{"name":"docs"},"user":{"name":"Atsuo Fukaya","screen_name":"fukayatsu"}}`,
+			expect: &webhook.Event{
+				Kind: webhook.KindMemberJoin,
+				Team: webhook.Team{Name: "docs"},
+				User: &models.User{
+					Name:       "Atsuo Fukaya",
+					ScreenName: "fukayatsu",
+				},
+			},
+		},
+		{
+			name:    "ng: invalid json",
+			body:    `///`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			e, err := webhook.ParseEvent([]byte(c.body))
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(e)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.body, string(e.Raw))
+			e.Raw = nil
+			asst.Equal(c.expect, e)
+		})
+	}
+}
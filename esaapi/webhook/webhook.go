@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	SignatureHeaderKey = "X-Esa-Signature"
+
+	signaturePrefix = "sha256="
+
+	// maxPayloadBytes is the upper limit of the size of the payload.
+	maxPayloadBytes int64 = 10 << 20
+)
+
+var (
+	ErrSignatureMissing = errors.New("webhook signature is missing")
+	ErrSignatureInvalid = errors.New("webhook signature is invalid")
+)
+
+// HandlerFunc is the callback for the webhook event.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// Handler is http.Handler that receives webhooks from esa.
+// It verifies the signature, decodes the payload and dispatches it to the callback for its kind.
+type Handler struct {
+	secret []byte
+
+	mu       sync.RWMutex
+	handlers map[Kind]HandlerFunc
+	fallback HandlerFunc
+}
+
+// NewHandler generates *Handler.
+// If the secret is empty, the signature is not verified.
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		secret:   []byte(secret),
+		handlers: map[Kind]HandlerFunc{},
+	}
+}
+
+// On registers the callback for the kind of event.
+func (h *Handler) On(kind Kind, fn HandlerFunc) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[kind] = fn
+	return h
+}
+
+// OnUnhandled registers the callback for events whose kind has no callback.
+func (h *Handler) OnUnhandled(fn HandlerFunc) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fallback = fn
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadBytes+1))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > maxPayloadBytes {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if len(h.secret) > 0 {
+		if err := verifySignature(h.secret, body, r.Header.Get(SignatureHeaderKey)); err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	e, err := ParseEvent(body)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if fn := h.handler(e.Kind); fn != nil {
+		if err := fn(r.Context(), e); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) handler(kind Kind) HandlerFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if fn, ok := h.handlers[kind]; ok {
+		return fn
+	}
+	return h.fallback
+}
+
+// VerifySignature verifies the value of the X-Esa-Signature header,
+// that is the HMAC-SHA256 of the payload with the secret.
+func VerifySignature(secret string, body []byte, signature string) error {
+	return verifySignature([]byte(secret), body, signature)
+}
+
+// Sign returns the value of the X-Esa-Signature header for the payload.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifySignature(secret, body []byte, signature string) error {
+	if signature == "" {
+		return ErrSignatureMissing
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrSignatureInvalid
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrSignatureInvalid
+	}
+
+	return nil
+}
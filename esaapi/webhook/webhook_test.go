@@ -0,0 +1,181 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifySignature(t *testing.T) {
+	body := []byte(`{"kind":"post_create"}`)
+
+	cases := []struct {
+		name      string
+		signature string
+		expectErr error
+	}{
+		{
+			name:      "ok",
+			signature: webhook.Sign("test-secret", body),
+		},
+		{
+			name:      "ng: missing",
+			signature: "",
+			expectErr: webhook.ErrSignatureMissing,
+		},
+		{
+			name:      "ng: no prefix",
+			signature: strings.TrimPrefix(webhook.Sign("test-secret", body), "sha256="),
+			expectErr: webhook.ErrSignatureInvalid,
+		},
+		{
+			name:      "ng: not hex",
+			signature: "sha256=zzz",
+			expectErr: webhook.ErrSignatureInvalid,
+		},
+		{
+			name:      "ng: other secret",
+			signature: webhook.Sign("other-secret", body),
+			expectErr: webhook.ErrSignatureInvalid,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			err := webhook.VerifySignature("test-secret", body, c.signature)
+			if c.expectErr != nil {
+				asst.ErrorIs(err, c.expectErr)
+				return
+			}
+			asst.NoError(err)
+		})
+	}
+}
+
+func Test_Handler_ServeHTTP(t *testing.T) {
+	const secret = "test-secret"
+	postCreate := `{"kind":"post_create","team":{"name":"docs"},"post":{"number":1}}`
+	memberJoin := `{"kind":"member_join","team":{"name":"docs"},"user":{"screen_name":"fukayatsu"}}`
+
+	cases := []struct {
+		name         string
+		secret       string
+		method       string
+		body         string
+		signature    string
+		fallback     bool
+		handlerErr   error
+		expectStatus int
+		expectCalled []string
+	}{
+		{
+			name:         "ok: dispatched",
+			secret:       secret,
+			method:       http.MethodPost,
+			body:         postCreate,
+			signature:    webhook.Sign(secret, []byte(postCreate)),
+			expectStatus: http.StatusOK,
+			expectCalled: []string{"post_create:1"},
+		},
+		{
+			name:         "ok: no callback",
+			secret:       secret,
+			method:       http.MethodPost,
+			body:         memberJoin,
+			signature:    webhook.Sign(secret, []byte(memberJoin)),
+			expectStatus: http.StatusOK,
+			expectCalled: []string{},
+		},
+		{
+			name:         "ok: fallback",
+			secret:       secret,
+			method:       http.MethodPost,
+			body:         memberJoin,
+			signature:    webhook.Sign(secret, []byte(memberJoin)),
+			fallback:     true,
+			expectStatus: http.StatusOK,
+			expectCalled: []string{"fallback:member_join"},
+		},
+		{
+			name:         "ok: no secret",
+			secret:       "",
+			method:       http.MethodPost,
+			body:         postCreate,
+			expectStatus: http.StatusOK,
+			expectCalled: []string{"post_create:1"},
+		},
+		{
+			name:         "ng: invalid signature",
+			secret:       secret,
+			method:       http.MethodPost,
+			body:         postCreate,
+			signature:    webhook.Sign("other-secret", []byte(postCreate)),
+			expectStatus: http.StatusUnauthorized,
+			expectCalled: []string{},
+		},
+		{
+			name:         "ng: method not allowed",
+			secret:       secret,
+			method:       http.MethodGet,
+			expectStatus: http.StatusMethodNotAllowed,
+			expectCalled: []string{},
+		},
+		{
+			name:         "ng: invalid payload",
+			secret:       secret,
+			method:       http.MethodPost,
+			body:         `///`,
+			signature:    webhook.Sign(secret, []byte(`///`)),
+			expectStatus: http.StatusBadRequest,
+			expectCalled: []string{},
+		},
+		{
+			name:         "ng: callback error",
+			secret:       secret,
+			method:       http.MethodPost,
+			body:         postCreate,
+			signature:    webhook.Sign(secret, []byte(postCreate)),
+			handlerErr:   errors.New("callback error"),
+			expectStatus: http.StatusInternalServerError,
+			expectCalled: []string{"post_create:1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			called := []string{}
+			h := webhook.NewHandler(c.secret).
+				On(webhook.KindPostCreate, func(ctx context.Context, e *webhook.Event) error {
+					called = append(called, "post_create:"+strconv.Itoa(e.Post.Number))
+					return c.handlerErr
+				})
+			if c.fallback {
+				h.OnUnhandled(func(ctx context.Context, e *webhook.Event) error {
+					called = append(called, "fallback:"+string(e.Kind))
+					return nil
+				})
+			}
+
+			req := httptest.NewRequest(c.method, "/webhook", strings.NewReader(c.body))
+			if c.signature != "" {
+				req.Header.Set(webhook.SignatureHeaderKey, c.signature)
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			asst.Equal(c.expectStatus, rec.Code)
+			asst.Equal(c.expectCalled, called)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Package esaexport exports esa posts to a directory tree of Markdown files.
+//
+// Each post is written to "<category>/<name>.md" with the YAML front matter.
+//
+//	out, err := esaexport.Export(ctx, c, &esaexport.Input{
+//		TeamName:    "docs",
+//		Q:           "in:dev",
+//		Dir:         "backup",
+//		Incremental: true,
+//	})
+package esaexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+)
+
+// StateFileName is the name of the file in the export directory that records
+// the exported posts for incremental exports.
+const StateFileName = ".esaexport.json"
+
+const perPage = 100
+
+type Input struct {
+	TeamName string
+
+	// Q filters the posts with the search query of post.ListPosts.
+	Q string
+
+	// Dir is the directory the posts are written to. It is created if it does not exist.
+	Dir string
+
+	// IncludeComments writes the comments of the posts to the front matter.
+	IncludeComments bool
+
+	// Incremental skips the posts that are not updated since the previous export.
+	// If IncludeComments is false, only the posts updated since the previous export are listed.
+	Incremental bool
+}
+
+type Output struct {
+	// Written is the paths of the written files, relative to Dir.
+	Written []string
+
+	// Skipped is the number of the posts that are not updated since the previous export.
+	Skipped int
+
+	// Removed is the paths of the files removed because the posts were moved or renamed.
+	Removed []string
+}
+
+type state struct {
+	Posts map[int]*stateEntry `json:"posts"`
+}
+
+type stateEntry struct {
+	Path         string     `json:"path"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	CommentCount int        `json:"comment_count"`
+}
+
+// Export writes the posts of the team to the directory.
+// The state of the export is saved even if it fails halfway, so the next incremental
+// export resumes from the posts that are not written yet.
+func Export(ctx context.Context, c gesa.IAPIClient, in *Input) (*Output, error) {
+	if in == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+	if in.TeamName == "" || in.Dir == "" {
+		return nil, internal.NewRequiredParameterEmptyError("Input.TeamName, Input.Dir")
+	}
+
+	st, err := loadState(in.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	lin := &types.ListPostsInput{
+		TeamName: in.TeamName,
+		Q:        in.Q,
+		Sort:     types.ListPostsSortUpdated,
+		Order:    types.ListPostsOrderAsc,
+		PerPage:  gesa.NewPageNumber(perPage),
+	}
+	if in.IncludeComments {
+		lin.Include = "comments"
+	}
+	if in.Incremental && !in.IncludeComments {
+		if since, ok := st.lastUpdatedAt(); ok {
+			// The date of the query is in the time zone of the team, so the posts of the
+			// previous day are also listed and skipped by the recorded UpdatedAt.
+			updated := post.Updated(post.OperatorGreaterOrEqual, since.Add(-24*time.Hour)).String()
+			lin.Q = strings.TrimSpace(in.Q + " " + updated)
+		}
+	}
+
+	out := &Output{}
+	fetch := func(ctx context.Context, in *types.ListPostsInput) (*types.ListPostsOutput, error) {
+		return post.ListPosts(ctx, c, in)
+	}
+	for p, err := range gesa.Paginate(ctx, lin, fetch) {
+		if err != nil {
+			return out, errors.Join(err, st.save(in.Dir))
+		}
+
+		if in.Incremental && st.isUpToDate(p, in.Dir) {
+			out.Skipped++
+			continue
+		}
+
+		if err := st.write(in.Dir, p, in.IncludeComments, out); err != nil {
+			return out, errors.Join(err, st.save(in.Dir))
+		}
+	}
+
+	if err := st.save(in.Dir); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+func loadState(dir string) (*state, error) {
+	st := &state{Posts: map[int]*stateEntry{}}
+
+	b, err := os.ReadFile(filepath.Join(dir, StateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, st); err != nil {
+		return nil, fmt.Errorf("invalid state file: %w", err)
+	}
+	if st.Posts == nil {
+		st.Posts = map[int]*stateEntry{}
+	}
+	return st, nil
+}
+
+func (st *state) save(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, StateFileName), b, 0o644)
+}
+
+func (st *state) lastUpdatedAt() (time.Time, bool) {
+	var last time.Time
+	for _, e := range st.Posts {
+		if e.UpdatedAt != nil && e.UpdatedAt.After(last) {
+			last = *e.UpdatedAt
+		}
+	}
+	return last, !last.IsZero()
+}
+
+func (st *state) isUpToDate(p models.Post, dir string) bool {
+	e, ok := st.Posts[p.Number]
+	if !ok || e.UpdatedAt == nil || p.UpdatedAt == nil {
+		return false
+	}
+	if !e.UpdatedAt.Equal(*p.UpdatedAt) || e.CommentCount != p.CommentCount {
+		return false
+	}
+
+	_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(e.Path)))
+	return err == nil
+}
+
+func (st *state) write(dir string, p models.Post, withComments bool, out *Output) error {
+	rel := st.pathOf(p)
+
+	fm := &FrontMatter{
+		Number:    p.Number,
+		Name:      p.Name,
+		Category:  p.Category,
+		Tags:      p.Tags,
+		Wip:       gesa.Bool(p.Wip),
+		URL:       p.URL,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+		CreatedBy: p.CreatedBy.ScreenName,
+		UpdatedBy: p.UpdatedBy.ScreenName,
+	}
+	if fm.Tags == nil {
+		fm.Tags = []string{}
+	}
+	if withComments {
+		for _, cm := range p.Comments {
+			fm.Comments = append(fm.Comments, Comment{
+				ID:        cm.ID,
+				CreatedBy: cm.CreatedBy.ScreenName,
+				CreatedAt: cm.CreatedAt,
+				BodyMD:    cm.BodyMD,
+			})
+		}
+	}
+
+	b, err := MarshalDocument(fm, p.BodyMD)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		return err
+	}
+	out.Written = append(out.Written, rel)
+
+	if prev, ok := st.Posts[p.Number]; ok && prev.Path != rel {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(prev.Path))); err == nil {
+			out.Removed = append(out.Removed, prev.Path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	st.Posts[p.Number] = &stateEntry{Path: rel, UpdatedAt: p.UpdatedAt, CommentCount: p.CommentCount}
+	return nil
+}
+
+// pathOf returns the slash separated path of the post relative to the export directory.
+// If the path is used by another post, the number of the post is appended to the name.
+func (st *state) pathOf(p models.Post) string {
+	segments := []string{}
+	for _, s := range strings.Split(p.Category, "/") {
+		if s != "" {
+			segments = append(segments, sanitize(s))
+		}
+	}
+
+	name := sanitize(p.Name)
+	rel := path.Join(append(segments, name+".md")...)
+	for number, e := range st.Posts {
+		if number != p.Number && e.Path == rel {
+			return path.Join(append(segments, name+"_"+strconv.Itoa(p.Number)+".md")...)
+		}
+	}
+	return rel
+}
+
+// sanitize replaces the characters that cannot be used in a path segment.
+func sanitize(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', 0:
+			return '_'
+		}
+		return r
+	}, s)
+
+	if s == "" || s == "." || s == ".." {
+		return "_" + s
+	}
+	return s
+}
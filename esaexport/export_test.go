@@ -0,0 +1,169 @@
+package esaexport_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaexport"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func readDocument(t *testing.T, name string) (*esaexport.FrontMatter, string) {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fm, body, err := esaexport.ParseDocument(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fm, body
+}
+
+func Test_Export(t *testing.T) {
+	ctx := context.Background()
+	asst := assert.New(t)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := esatest.NewServer(esatest.WithClock(func() time.Time { return now }))
+	defer s.Close()
+	s.AddToken("alice-token", "alice")
+
+	hello := s.AddPost("docs", models.Post{Name: "hello", Category: "dev/go", BodyMD: "# hello\n", Tags: []string{"go"}})
+	draft := s.AddPost("docs", models.Post{Name: "draft", Wip: true, CreatedBy: models.User{ScreenName: "bob"}})
+	s.AddPost("docs", models.Post{Name: "hello", Category: "dev/go", BodyMD: "duplicated"})
+	s.AddComment("docs", hello.Number, models.Comment{BodyMD: "LGTM", CreatedBy: models.User{ScreenName: "bob"}})
+
+	c, err := s.NewClient("alice-token")
+	asst.NoError(err)
+	dir := t.TempDir()
+
+	// full export
+	out, err := esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Dir: dir, IncludeComments: true, Incremental: true})
+	asst.NoError(err)
+	asst.ElementsMatch([]string{"dev/go/hello.md", "draft.md", "dev/go/hello_3.md"}, out.Written)
+	asst.Equal(0, out.Skipped)
+
+	fm, body := readDocument(t, filepath.Join(dir, "dev", "go", "hello.md"))
+	asst.Equal(1, fm.Number)
+	asst.Equal("hello", fm.Name)
+	asst.Equal("dev/go", fm.Category)
+	asst.Equal([]string{"go"}, fm.Tags)
+	asst.Equal(gesa.Bool(false), fm.Wip)
+	asst.True(now.Equal(*fm.CreatedAt))
+	asst.True(now.Equal(*fm.UpdatedAt))
+	asst.Equal("esatest", fm.CreatedBy)
+	if asst.Len(fm.Comments, 1) {
+		asst.Equal("LGTM", fm.Comments[0].BodyMD)
+		asst.Equal("bob", fm.Comments[0].CreatedBy)
+	}
+	asst.Equal("# hello\n", body)
+
+	fm, _ = readDocument(t, filepath.Join(dir, "draft.md"))
+	asst.Equal(draft.Number, fm.Number)
+	asst.Equal(gesa.Bool(true), fm.Wip)
+	asst.Equal("bob", fm.CreatedBy)
+	asst.Equal([]string{}, fm.Tags)
+	asst.FileExists(filepath.Join(dir, esaexport.StateFileName))
+
+	// incremental export after an update and a move
+	now = now.Add(time.Hour)
+	_, err = post.UpdatePost(ctx, c, &types.UpdatePostInput{TeamName: "docs", PostNumber: hello.Number, BodyMD: gesa.String("# updated\n")})
+	asst.NoError(err)
+	_, err = post.UpdatePost(ctx, c, &types.UpdatePostInput{TeamName: "docs", PostNumber: draft.Number, Category: gesa.String("archive")})
+	asst.NoError(err)
+
+	requests := len(s.Requests())
+	out, err = esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Dir: dir, IncludeComments: true, Incremental: true})
+	asst.NoError(err)
+	asst.ElementsMatch([]string{"dev/go/hello.md", "archive/draft.md"}, out.Written)
+	asst.Equal([]string{"draft.md"}, out.Removed)
+	asst.Equal(1, out.Skipped)
+	asst.Len(s.Requests(), requests+1)
+	asst.NoFileExists(filepath.Join(dir, "draft.md"))
+
+	_, body = readDocument(t, filepath.Join(dir, "dev", "go", "hello.md"))
+	asst.Equal("# updated\n", body)
+
+	// a new comment is exported even if the post is not updated
+	s.AddComment("docs", draft.Number, models.Comment{BodyMD: "old?"})
+	out, err = esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Dir: dir, IncludeComments: true, Incremental: true})
+	asst.NoError(err)
+	asst.Equal([]string{"archive/draft.md"}, out.Written)
+	asst.Equal(2, out.Skipped)
+
+	// a deleted file is written again
+	asst.NoError(os.Remove(filepath.Join(dir, "dev", "go", "hello_3.md")))
+	out, err = esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Dir: dir, Incremental: true})
+	asst.NoError(err)
+	asst.Equal([]string{"dev/go/hello_3.md"}, out.Written)
+
+	// without Incremental, all posts are written
+	out, err = esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Dir: dir})
+	asst.NoError(err)
+	asst.Len(out.Written, 3)
+}
+
+func Test_Export_Incremental_Query(t *testing.T) {
+	ctx := context.Background()
+	asst := assert.New(t)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := esatest.NewServer(esatest.WithClock(func() time.Time { return now }))
+	defer s.Close()
+	s.AddPost("docs", models.Post{Name: "a", Category: "dev"})
+	s.AddPost("docs", models.Post{Name: "b", Category: "misc"})
+
+	c, err := s.NewClient("token")
+	asst.NoError(err)
+	dir := t.TempDir()
+
+	out, err := esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Q: "in:dev", Dir: dir, Incremental: true})
+	asst.NoError(err)
+	asst.Equal([]string{"dev/a.md"}, out.Written)
+
+	out, err = esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Q: "in:dev", Dir: dir, Incremental: true})
+	asst.NoError(err)
+	asst.Empty(out.Written)
+	asst.Equal(1, out.Skipped)
+
+	reqs := s.Requests()
+	q, err := url.ParseQuery(reqs[len(reqs)-1].Query)
+	asst.NoError(err)
+	asst.Equal("in:dev updated:>=2024-01-01", q.Get("q"))
+}
+
+func Test_Export_Error(t *testing.T) {
+	ctx := context.Background()
+	s := esatest.NewServer()
+	defer s.Close()
+	client, _ := s.NewClient("token")
+
+	cases := []struct {
+		name string
+		in   *esaexport.Input
+	}{
+		{name: "ng: nil", in: nil},
+		{name: "ng: no team", in: &esaexport.Input{Dir: t.TempDir()}},
+		{name: "ng: no dir", in: &esaexport.Input{TeamName: "docs"}},
+		{name: "ng: api error", in: &esaexport.Input{TeamName: "unknown", Dir: t.TempDir()}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			_, err := esaexport.Export(ctx, client, c.in)
+			asst.Error(err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package esaexport
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// FrontMatter is the YAML front matter of an exported post.
+type FrontMatter struct {
+	Number   int      `yaml:"number,omitempty"`
+	Name     string   `yaml:"name"`
+	Category string   `yaml:"category,omitempty"`
+	Tags     []string `yaml:"tags"`
+	// Wip is nil if the front matter has no "wip".
+	Wip       *bool      `yaml:"wip,omitempty"`
+	URL       string     `yaml:"url,omitempty"`
+	CreatedAt *time.Time `yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `yaml:"updated_at,omitempty"`
+	CreatedBy string     `yaml:"created_by,omitempty"`
+	UpdatedBy string     `yaml:"updated_by,omitempty"`
+	Comments  []Comment  `yaml:"comments,omitempty"`
+}
+
+// Comment is a comment of an exported post.
+type Comment struct {
+	ID        int        `yaml:"id"`
+	CreatedBy string     `yaml:"created_by"`
+	CreatedAt *time.Time `yaml:"created_at,omitempty"`
+	BodyMD    string     `yaml:"body_md"`
+}
+
+// MarshalDocument returns the Markdown document of the front matter and the body.
+func MarshalDocument(fm *FrontMatter, body string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString(frontMatterDelimiter + "\n")
+
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(frontMatterDelimiter + "\n")
+	buf.WriteString(body)
+	return buf.Bytes(), nil
+}
+
+// ParseDocument splits the Markdown document into the front matter and the body.
+// If the document has no front matter, the zero FrontMatter and the whole document are returned.
+func ParseDocument(b []byte) (*FrontMatter, string, error) {
+	fm := &FrontMatter{}
+
+	rest, ok := cutDelimiterLine(b)
+	if !ok {
+		return fm, string(b), nil
+	}
+
+	var header []byte
+	for len(rest) > 0 {
+		line := rest
+		next := []byte(nil)
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, next = rest[:i+1], rest[i+1:]
+		}
+
+		if _, ok := cutDelimiterLine(line); ok {
+			if err := yaml.Unmarshal(header, fm); err != nil {
+				return nil, "", err
+			}
+			return fm, string(next), nil
+		}
+
+		header = append(header, line...)
+		rest = next
+	}
+
+	return nil, "", errors.New("front matter is not closed")
+}
+
+// cutDelimiterLine returns the bytes after the first line if it is the front matter delimiter.
+func cutDelimiterLine(b []byte) ([]byte, bool) {
+	line, rest, _ := bytes.Cut(b, []byte("\n"))
+	if string(bytes.TrimRight(line, "\r")) != frontMatterDelimiter {
+		return nil, false
+	}
+	return rest, true
+}
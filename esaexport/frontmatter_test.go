@@ -0,0 +1,127 @@
+package esaexport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaexport"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MarshalDocument(t *testing.T) {
+	asst := assert.New(t)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	b, err := esaexport.MarshalDocument(&esaexport.FrontMatter{
+		Number:    1,
+		Name:      "hello: world",
+		Category:  "dev/go",
+		Tags:      []string{"a", "b"},
+		Wip:       gesa.Bool(false),
+		CreatedAt: &created,
+		CreatedBy: "alice",
+	}, "# body\n")
+	asst.NoError(err)
+	asst.Equal(`---
+number: 1
+name: 'hello: world'
+category: dev/go
+tags:
+  - a
+  - b
+wip: false
+created_at: 2024-01-02T03:04:05Z
+created_by: alice
+---
+# body
+`, string(b))
+}
+
+func Test_ParseDocument(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		doc        string
+		expect     *esaexport.FrontMatter
+		expectBody string
+		wantErr    bool
+	}{
+		{
+			name: "ok",
+			doc:  "---\nnumber: 1\nname: hello\ntags: [a]\nwip: true\ncreated_at: 2024-01-02T03:04:05Z\n---\n# body\n---\n",
+			expect: &esaexport.FrontMatter{
+				Number:    1,
+				Name:      "hello",
+				Tags:      []string{"a"},
+				Wip:       gesa.Bool(true),
+				CreatedAt: &created,
+			},
+			expectBody: "# body\n---\n",
+		},
+		{
+			name:       "ok: crlf",
+			doc:        "---\r\nname: hello\r\n---\r\nbody",
+			expect:     &esaexport.FrontMatter{Name: "hello"},
+			expectBody: "body",
+		},
+		{
+			name:       "ok: empty body",
+			doc:        "---\nname: hello\n---",
+			expect:     &esaexport.FrontMatter{Name: "hello"},
+			expectBody: "",
+		},
+		{
+			name:       "ok: no front matter",
+			doc:        "# title\n---\n",
+			expect:     &esaexport.FrontMatter{},
+			expectBody: "# title\n---\n",
+		},
+		{
+			name:    "ng: not closed",
+			doc:     "---\nname: hello\n",
+			wantErr: true,
+		},
+		{
+			name:    "ng: invalid yaml",
+			doc:     "---\nname: [\n---\n",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			fm, body, err := esaexport.ParseDocument([]byte(c.doc))
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(fm)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, fm)
+			asst.Equal(c.expectBody, body)
+		})
+	}
+}
+
+func Test_Document_RoundTrip(t *testing.T) {
+	asst := assert.New(t)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fm := &esaexport.FrontMatter{
+		Number:   3,
+		Name:     "---",
+		Tags:     []string{},
+		Comments: []esaexport.Comment{{ID: 1, CreatedBy: "bob", CreatedAt: &created, BodyMD: "LGTM\n---\n"}},
+	}
+
+	b, err := esaexport.MarshalDocument(fm, "---\nbody")
+	asst.NoError(err)
+
+	parsed, body, err := esaexport.ParseDocument(b)
+	asst.NoError(err)
+	asst.Equal(fm, parsed)
+	asst.Equal("---\nbody", body)
+}
@@ -0,0 +1,287 @@
+// Package esaimport imports a directory tree of Markdown files into esa posts.
+// It is the reverse of the esaexport package.
+//
+// The directory of a file is used as the category of the post, and the YAML front matter
+// (see esaexport.FrontMatter) gives the name, the tags and wip. The numbers of the imported
+// posts are recorded in the mapping file in the directory, so the next import updates them
+// instead of creating new posts. Files without the mapping are imported into the posts of
+// the numbers in their front matter, so a tree exported from the team updates the posts.
+//
+//	out, err := esaimport.Import(ctx, c, &esaimport.Input{
+//		TeamName: "docs",
+//		Dir:      "backup",
+//		Message:  "Imported from backup",
+//	})
+package esaimport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaexport"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+)
+
+// MappingFileName is the name of the file in the import directory that records
+// the numbers of the imported posts.
+const MappingFileName = ".esaimport.json"
+
+type Input struct {
+	TeamName string
+
+	// Dir is the directory of the Markdown files. Files and directories whose names
+	// start with "." are ignored.
+	Dir string
+
+	// Message is the message of the revisions created by the import.
+	Message string
+
+	// DryRun reports the actions without calling the API or updating the mapping file.
+	DryRun bool
+}
+
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+)
+
+type Result struct {
+	// Path is the slash separated path of the file relative to Dir.
+	Path   string
+	Action Action
+	// Number is the number of the post. It is 0 for ActionCreate in the dry-run mode.
+	Number int
+}
+
+type Output struct {
+	Results []Result
+}
+
+type mapping struct {
+	Posts map[string]*mappingEntry `json:"posts"`
+}
+
+type mappingEntry struct {
+	Number int    `json:"number"`
+	Digest string `json:"digest"`
+}
+
+// Import creates or updates a post for each Markdown file in the directory.
+// Files that are not changed since the previous import are not sent.
+// If the mapped post has been deleted, the post is created again.
+// The mapping is saved even if the import fails halfway, so the next import resumes.
+func Import(ctx context.Context, c gesa.IAPIClient, in *Input) (*Output, error) {
+	if in == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+	if in.TeamName == "" || in.Dir == "" {
+		return nil, internal.NewRequiredParameterEmptyError("Input.TeamName, Input.Dir")
+	}
+
+	m, err := loadMapping(in.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := markdownFiles(in.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Output{Results: []Result{}}
+	for _, rel := range files {
+		r, err := m.importFile(ctx, c, in, rel)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", rel, err)
+			if !in.DryRun {
+				err = errors.Join(err, m.save(in.Dir))
+			}
+			return out, err
+		}
+		out.Results = append(out.Results, *r)
+	}
+
+	if in.DryRun {
+		return out, nil
+	}
+	if err := m.save(in.Dir); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// markdownFiles returns the slash separated paths of the Markdown files in lexical order.
+func markdownFiles(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || filepath.Ext(p) != ".md" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (m *mapping) importFile(ctx context.Context, c gesa.IAPIClient, in *Input, rel string) (*Result, error) {
+	b, err := os.ReadFile(filepath.Join(in.Dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(b)
+	digest := hex.EncodeToString(sum[:])
+	number := 0
+	if e, ok := m.Posts[rel]; ok {
+		if e.Digest == digest {
+			return &Result{Path: rel, Action: ActionUnchanged, Number: e.Number}, nil
+		}
+		number = e.Number
+	}
+
+	fm, body, err := esaexport.ParseDocument(b)
+	if err != nil {
+		return nil, err
+	}
+	if number == 0 {
+		number = frontMatterNumber(fm, in.TeamName)
+	}
+
+	name := fm.Name
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(rel), ".md")
+	}
+	category := path.Dir(rel)
+	if category == "." {
+		category = ""
+	}
+	tags := make([]*string, 0, len(fm.Tags))
+	for _, t := range fm.Tags {
+		tags = append(tags, gesa.String(t))
+	}
+	var message *string
+	if in.Message != "" {
+		message = gesa.String(in.Message)
+	}
+
+	if number != 0 {
+		if in.DryRun {
+			return &Result{Path: rel, Action: ActionUpdate, Number: number}, nil
+		}
+
+		res, err := post.UpdatePost(ctx, c, &types.UpdatePostInput{
+			TeamName:   in.TeamName,
+			PostNumber: number,
+			Name:       name,
+			BodyMD:     gesa.String(body),
+			Tags:       tags,
+			Category:   gesa.String(category),
+			Wip:        fm.Wip,
+			Message:    message,
+		})
+		if err == nil {
+			m.Posts[rel] = &mappingEntry{Number: res.Number, Digest: digest}
+			return &Result{Path: rel, Action: ActionUpdate, Number: res.Number}, nil
+		}
+		if !errors.Is(err, gesa.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	if in.DryRun {
+		return &Result{Path: rel, Action: ActionCreate}, nil
+	}
+
+	res, err := post.CreatePost(ctx, c, &types.CreatePostInput{
+		TeamName: in.TeamName,
+		Name:     name,
+		BodyMD:   gesa.String(body),
+		Tags:     tags,
+		Category: gesa.String(category),
+		Wip:      fm.Wip,
+		Message:  message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.Posts[rel] = &mappingEntry{Number: res.Number, Digest: digest}
+	return &Result{Path: rel, Action: ActionCreate, Number: res.Number}, nil
+}
+
+// frontMatterNumber returns the number in the front matter written by esaexport, or 0.
+// The number is ignored if the URL in the front matter is of another team, so that
+// a tree exported from another team does not overwrite posts.
+func frontMatterNumber(fm *esaexport.FrontMatter, teamName string) int {
+	if fm.Number == 0 {
+		return 0
+	}
+	if fm.URL != "" {
+		u, err := url.Parse(fm.URL)
+		if err != nil || !strings.HasPrefix(u.Host, teamName+".") {
+			return 0
+		}
+	}
+	return fm.Number
+}
+
+func loadMapping(dir string) (*mapping, error) {
+	m := &mapping{Posts: map[string]*mappingEntry{}}
+
+	b, err := os.ReadFile(filepath.Join(dir, MappingFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("invalid mapping file: %w", err)
+	}
+	if m.Posts == nil {
+		m.Posts = map[string]*mappingEntry{}
+	}
+	return m, nil
+}
+
+func (m *mapping) save(dir string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, MappingFileName), b, 0o644)
+}
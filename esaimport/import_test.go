@@ -0,0 +1,189 @@
+package esaimport_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaexport"
+	"github.com/michimani/go-esa/esaimport"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	name := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Import(t *testing.T) {
+	ctx := context.Background()
+	asst := assert.New(t)
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddTeam(models.Team{Name: "docs"})
+	c, err := s.NewClient("token")
+	asst.NoError(err)
+
+	dir := t.TempDir()
+	writeFile(t, dir, "dev/go/hello.md", "---\nname: Hello, world\ntags: [go, api]\nwip: false\n---\n# hello\n")
+	writeFile(t, dir, "memo.md", "no front matter\n")
+	writeFile(t, dir, "dev/image.png", "not markdown")
+	writeFile(t, dir, ".git/HEAD.md", "hidden")
+
+	// dry run
+	out, err := esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir, DryRun: true})
+	asst.NoError(err)
+	asst.Equal([]esaimport.Result{
+		{Path: "dev/go/hello.md", Action: esaimport.ActionCreate},
+		{Path: "memo.md", Action: esaimport.ActionCreate},
+	}, out.Results)
+	asst.Empty(s.Requests())
+	asst.NoFileExists(filepath.Join(dir, esaimport.MappingFileName))
+
+	// create
+	out, err = esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir, Message: "import"})
+	asst.NoError(err)
+	asst.Equal([]esaimport.Result{
+		{Path: "dev/go/hello.md", Action: esaimport.ActionCreate, Number: 1},
+		{Path: "memo.md", Action: esaimport.ActionCreate, Number: 2},
+	}, out.Results)
+
+	hello, _ := s.Post("docs", 1)
+	asst.Equal("Hello, world", hello.Name)
+	asst.Equal("dev/go", hello.Category)
+	asst.Equal([]string{"go", "api"}, hello.Tags)
+	asst.False(hello.Wip)
+	asst.Equal("# hello\n", hello.BodyMD)
+	asst.Equal("import", hello.Message)
+
+	memo, _ := s.Post("docs", 2)
+	asst.Equal("memo", memo.Name)
+	asst.Equal("", memo.Category)
+	asst.Equal("no front matter\n", memo.BodyMD)
+
+	// unchanged files are not sent
+	requests := len(s.Requests())
+	out, err = esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir})
+	asst.NoError(err)
+	asst.Equal(esaimport.ActionUnchanged, out.Results[0].Action)
+	asst.Equal(esaimport.ActionUnchanged, out.Results[1].Action)
+	asst.Len(s.Requests(), requests)
+
+	// update, and create again the deleted post
+	writeFile(t, dir, "dev/go/hello.md", "---\nname: Hello, world\ntags: [docs]\nwip: true\n---\n# updated\n")
+	writeFile(t, dir, "memo.md", "recreated\n")
+	_, err = post.DeletePost(ctx, c, &types.DeletePostInput{TeamName: "docs", PostNumber: 2})
+	asst.NoError(err)
+
+	out, err = esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir, DryRun: true})
+	asst.NoError(err)
+	asst.Equal(esaimport.ActionUpdate, out.Results[0].Action)
+	asst.Equal(esaimport.ActionUpdate, out.Results[1].Action)
+
+	out, err = esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir, Message: "sync"})
+	asst.NoError(err)
+	asst.Equal([]esaimport.Result{
+		{Path: "dev/go/hello.md", Action: esaimport.ActionUpdate, Number: 1},
+		{Path: "memo.md", Action: esaimport.ActionCreate, Number: 3},
+	}, out.Results)
+
+	hello, _ = s.Post("docs", 1)
+	asst.Equal("# updated\n", hello.BodyMD)
+	asst.Equal([]string{"docs"}, hello.Tags)
+	asst.True(hello.Wip)
+	asst.Equal("sync", hello.Message)
+	asst.Len(s.Revisions("docs", 1), 2)
+}
+
+func Test_Import_Error(t *testing.T) {
+	ctx := context.Background()
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddTeam(models.Team{Name: "docs"})
+	client, _ := s.NewClient("token")
+
+	invalid := t.TempDir()
+	writeFile(t, invalid, "a.md", "---\nname: a\n---\n")
+	writeFile(t, invalid, "b.md", "---\nname: [\n---\n")
+
+	cases := []struct {
+		name          string
+		in            *esaimport.Input
+		expectResults int
+	}{
+		{name: "ng: nil", in: nil},
+		{name: "ng: no team", in: &esaimport.Input{Dir: t.TempDir()}},
+		{name: "ng: no dir", in: &esaimport.Input{TeamName: "docs"}},
+		{name: "ng: dir does not exist", in: &esaimport.Input{TeamName: "docs", Dir: filepath.Join(t.TempDir(), "none")}},
+		{name: "ng: invalid front matter", in: &esaimport.Input{TeamName: "docs", Dir: invalid}, expectResults: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			out, err := esaimport.Import(ctx, client, c.in)
+			asst.Error(err)
+			if c.expectResults > 0 {
+				asst.Len(out.Results, c.expectResults)
+			}
+		})
+	}
+
+	// the mapping of the imported file is saved
+	asst := assert.New(t)
+	asst.FileExists(filepath.Join(invalid, esaimport.MappingFileName))
+	out, err := esaimport.Import(ctx, client, &esaimport.Input{TeamName: "docs", Dir: invalid, DryRun: true})
+	asst.Error(err)
+	asst.Equal(esaimport.ActionUnchanged, out.Results[0].Action)
+}
+
+func Test_Import_ExportedTree(t *testing.T) {
+	ctx := context.Background()
+	asst := assert.New(t)
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddPost("docs", models.Post{Name: "hello", Category: "dev", BodyMD: "# hello\n", Wip: true})
+	s.AddPost("docs", models.Post{Name: "memo", BodyMD: "memo\n"})
+	c, err := s.NewClient("token")
+	asst.NoError(err)
+
+	dir := t.TempDir()
+	_, err = esaexport.Export(ctx, c, &esaexport.Input{TeamName: "docs", Dir: dir})
+	asst.NoError(err)
+	writeFile(t, dir, "other.md", "---\nnumber: 1\nname: other\nurl: https://other.esa.io/posts/1\n---\nother\n")
+
+	// the numbers in the front matter are used without the mapping file,
+	// except for the post of another team
+	out, err := esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir})
+	asst.NoError(err)
+	asst.Equal([]esaimport.Result{
+		{Path: "dev/hello.md", Action: esaimport.ActionUpdate, Number: 1},
+		{Path: "memo.md", Action: esaimport.ActionUpdate, Number: 2},
+		{Path: "other.md", Action: esaimport.ActionCreate, Number: 3},
+	}, out.Results)
+	_, ok := s.Post("docs", 4)
+	asst.False(ok)
+
+	// wip is kept if the front matter has no wip
+	writeFile(t, dir, "dev/hello.md", "---\nnumber: 1\nname: hello\n---\n# edited\n")
+	out, err = esaimport.Import(ctx, c, &esaimport.Input{TeamName: "docs", Dir: dir})
+	asst.NoError(err)
+	asst.Equal(esaimport.Result{Path: "dev/hello.md", Action: esaimport.ActionUpdate, Number: 1}, out.Results[0])
+
+	hello, _ := s.Post("docs", 1)
+	asst.Equal("# edited\n", hello.BodyMD)
+	asst.True(hello.Wip)
+}
@@ -0,0 +1,467 @@
+// Package esamd parses the Markdown body of esa posts into a tree of sections with task
+// list items, and edits it while preserving the untouched text byte for byte.
+//
+//	doc := esamd.Parse(p.BodyMD)
+//	if err := doc.ReplaceSection([]string{"Release", "Notes"}, "- fixed a bug\n"); err != nil {
+//		return err
+//	}
+//	for _, t := range doc.Tasks() {
+//		if t.Text == "deploy" {
+//			t.SetChecked(true)
+//		}
+//	}
+//	body := doc.String()
+//
+// Headings are ATX headings ("# Title"). Headings and tasks in fenced code blocks are ignored.
+package esamd
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrSectionNotFound is returned when no section matches the heading path.
+	ErrSectionNotFound = errors.New("section not found")
+
+	// ErrStale is returned when a section or a task is used after the document is re-parsed by an edit.
+	ErrStale = errors.New("section or task of an old version of the document")
+)
+
+// Document is a parsed Markdown body.
+type Document struct {
+	src []byte
+	// gen is incremented every time the document is re-parsed.
+	gen   int
+	root  *Section
+	tasks []*Task
+}
+
+// Section is a heading and its content up to the next heading of the same or a higher level.
+// The root section has level 0 and no heading, and covers the whole document.
+type Section struct {
+	Level    int
+	Title    string
+	Parent   *Section
+	Children []*Section
+
+	// Tasks is the task list items in the section, excluding the ones in the subsections.
+	Tasks []*Task
+
+	doc *Document
+	gen int
+	// start is the offset of the heading, body is the offset of the line after the heading,
+	// and end is the offset of the next section.
+	start, body, end int
+}
+
+// Task is a task list item such as "- [ ] do something @alice due:2024-01-31".
+type Task struct {
+	Checked bool
+	// Text is the text after the check box.
+	Text string
+	// Line is the 1-based line number.
+	Line int
+	// Indent is the width of the whitespace before the list marker. A tab is counted as 4.
+	Indent  int
+	Section *Section
+
+	// Parent is the task that the task is nested in, and Children is the tasks nested in the task.
+	Parent   *Task
+	Children []*Task
+
+	// Mentions is the screen names mentioned in the text such as "@alice", without "@".
+	Mentions []string
+	// Due is the date written as "due:2006-01-02" or "due:2006/01/02" in the text.
+	Due *time.Time
+
+	doc *Document
+	gen int
+	// mark is the offset of the character in the check box.
+	mark int
+}
+
+// Parse parses the Markdown body.
+func Parse(src string) *Document {
+	d := &Document{src: []byte(src)}
+	d.parse()
+	return d
+}
+
+// String returns the body including the edits.
+func (d *Document) String() string {
+	return string(d.src)
+}
+
+// Root returns the root section.
+func (d *Document) Root() *Section {
+	return d.root
+}
+
+// Tasks returns all task list items in the document order.
+func (d *Document) Tasks() []*Task {
+	return d.tasks
+}
+
+// Section returns the section of the heading path. For example, ("Usage", "Install") is
+// the first "Install" section in the first "Usage" section. The levels of the headings
+// do not need to be consecutive. An empty path returns the root section.
+func (d *Document) Section(path ...string) (*Section, bool) {
+	s := d.root
+	for _, title := range path {
+		found := false
+		for _, c := range s.Children {
+			if c.Title == title {
+				s, found = c, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return s, true
+}
+
+// ReplaceSection replaces the body of the section of the heading path, including its subsections.
+// The heading is kept. A line break is added to the body if the section is followed by another one.
+// The sections and tasks obtained before are invalidated.
+func (d *Document) ReplaceSection(path []string, body string) error {
+	s, ok := d.Section(path...)
+	if !ok {
+		return ErrSectionNotFound
+	}
+	return s.Replace(body)
+}
+
+// Heading returns the heading line of the section, including the line break.
+func (s *Section) Heading() string {
+	return string(s.doc.src[s.start:s.body])
+}
+
+// Body returns the content of the section after the heading line, including its subsections.
+func (s *Section) Body() string {
+	return string(s.doc.src[s.body:s.end])
+}
+
+// Text returns the heading and the body of the section.
+func (s *Section) Text() string {
+	return string(s.doc.src[s.start:s.end])
+}
+
+// Replace replaces the body of the section. See Document.ReplaceSection.
+func (s *Section) Replace(body string) error {
+	d := s.doc
+	if s.gen != d.gen {
+		return ErrStale
+	}
+
+	prefix := d.src[:s.body]
+	if len(prefix) > 0 && prefix[len(prefix)-1] != '\n' && body != "" {
+		body = "\n" + body
+	}
+	if s.end < len(d.src) && body != "" && !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	src := make([]byte, 0, len(prefix)+len(body)+len(d.src)-s.end)
+	src = append(src, prefix...)
+	src = append(src, body...)
+	src = append(src, d.src[s.end:]...)
+	d.src = src
+	d.parse()
+	return nil
+}
+
+// SetChecked checks or unchecks the task. It does not invalidate other sections and tasks.
+func (t *Task) SetChecked(checked bool) error {
+	if t.gen != t.doc.gen {
+		return ErrStale
+	}
+
+	if checked && !t.Checked {
+		t.doc.src[t.mark] = 'x'
+	} else if !checked && t.Checked {
+		t.doc.src[t.mark] = ' '
+	}
+	t.Checked = checked
+	return nil
+}
+
+func (d *Document) parse() {
+	d.gen++
+	d.root = &Section{doc: d, gen: d.gen, end: len(d.src)}
+	d.tasks = nil
+
+	stack := []*Section{d.root}
+	// items is the list items that the following items can be nested in.
+	items := []listItem{}
+	var fence string
+	lineNumber := 0
+	for start := 0; start < len(d.src); {
+		lineNumber++
+		end, next := lineEnd(d.src, start)
+		line := string(d.src[start:end])
+		top := stack[len(stack)-1]
+
+		switch {
+		case fence != "":
+			if isClosingFence(line, fence) {
+				fence = ""
+			}
+
+		case openingFence(line) != "":
+			fence = openingFence(line)
+
+		default:
+			if level, title, ok := atxHeading(line); ok {
+				for len(stack) > 1 && stack[len(stack)-1].Level >= level {
+					stack[len(stack)-1].end = start
+					stack = stack[:len(stack)-1]
+				}
+				items = items[:0]
+				parent := stack[len(stack)-1]
+				s := &Section{Level: level, Title: title, Parent: parent, doc: d, gen: d.gen, start: start, body: next, end: len(d.src)}
+				parent.Children = append(parent.Children, s)
+				stack = append(stack, s)
+			} else if t, ok := taskItem(line); ok {
+				t.Line = lineNumber
+				t.Section = top
+				t.doc = d
+				t.gen = d.gen
+				t.mark += start
+				top.Tasks = append(top.Tasks, t)
+				d.tasks = append(d.tasks, t)
+
+				items = popItems(items, t.Indent)
+				if len(items) > 0 && items[len(items)-1].task != nil {
+					t.Parent = items[len(items)-1].task
+					t.Parent.Children = append(t.Parent.Children, t)
+				}
+				items = append(items, listItem{indent: t.Indent, task: t})
+			} else if indent, ok := listMarker(line); ok {
+				items = append(popItems(items, indent), listItem{indent: indent})
+			} else if width, _ := indentWidth(line); strings.TrimSpace(line) != "" && width == 0 {
+				items = items[:0]
+			}
+		}
+
+		start = next
+	}
+}
+
+// listItem is a list item that the following items can be nested in.
+// task is nil if the item is not a task.
+type listItem struct {
+	indent int
+	task   *Task
+}
+
+// popItems removes the items that an item of the indent cannot be nested in.
+func popItems(items []listItem, indent int) []listItem {
+	for len(items) > 0 && items[len(items)-1].indent >= indent {
+		items = items[:len(items)-1]
+	}
+	return items
+}
+
+// lineEnd returns the end of the line excluding the line break, and the start of the next line.
+func lineEnd(src []byte, start int) (int, int) {
+	i := start
+	for i < len(src) && src[i] != '\n' {
+		i++
+	}
+	if i == len(src) {
+		return i, i
+	}
+	end := i
+	if end > start && src[end-1] == '\r' {
+		end--
+	}
+	return end, i + 1
+}
+
+// indentWidth returns the width of the leading whitespace and its length in bytes.
+func indentWidth(line string) (int, int) {
+	width := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width, i
+		}
+	}
+	return width, len(line)
+}
+
+// atxHeading parses "# Title" headings with up to 3 spaces of indentation.
+func atxHeading(line string) (int, string, bool) {
+	width, n := indentWidth(line)
+	if width > 3 {
+		return 0, "", false
+	}
+	rest := line[n:]
+
+	level := 0
+	for level < len(rest) && rest[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	rest = rest[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+
+	title := strings.TrimSpace(rest)
+	// the closing sequence of "#" must be preceded by a space
+	if trimmed := strings.TrimRight(title, "#"); trimmed == "" || strings.HasSuffix(trimmed, " ") || strings.HasSuffix(trimmed, "\t") {
+		title = strings.TrimSpace(trimmed)
+	}
+	return level, title, true
+}
+
+// openingFence returns the fence of the code block such as "```" or "~~~~", or an empty string.
+func openingFence(line string) string {
+	width, n := indentWidth(line)
+	if width > 3 {
+		return ""
+	}
+	rest := line[n:]
+	if rest == "" || (rest[0] != '`' && rest[0] != '~') {
+		return ""
+	}
+
+	l := 0
+	for l < len(rest) && rest[l] == rest[0] {
+		l++
+	}
+	if l < 3 {
+		return ""
+	}
+	// the info string of a backtick fence cannot contain backticks
+	if rest[0] == '`' && strings.Contains(rest[l:], "`") {
+		return ""
+	}
+	return rest[:l]
+}
+
+func isClosingFence(line, fence string) bool {
+	width, n := indentWidth(line)
+	if width > 3 {
+		return false
+	}
+	rest := strings.TrimRight(line[n:], " \t")
+	return len(rest) >= len(fence) && strings.Trim(rest, fence[:1]) == ""
+}
+
+// taskItem parses a task list item such as "- [ ] text" or "1. [x] text".
+// The offset of the check mark is relative to the line.
+func taskItem(line string) (*Task, bool) {
+	width, n := indentWidth(line)
+	marker, ok := markerLength(line[n:])
+	if !ok {
+		return nil, false
+	}
+
+	body := line[n+marker:]
+	spaces := len(body) - len(strings.TrimLeft(body, " "))
+	body = body[spaces:]
+
+	if len(body) < 3 || body[0] != '[' || body[2] != ']' {
+		return nil, false
+	}
+	if len(body) > 3 && body[3] != ' ' && body[3] != '\t' {
+		return nil, false
+	}
+
+	var checked bool
+	switch body[1] {
+	case ' ':
+		checked = false
+	case 'x', 'X':
+		checked = true
+	default:
+		return nil, false
+	}
+
+	text := strings.TrimSpace(body[3:])
+	return &Task{
+		Checked:  checked,
+		Text:     text,
+		Indent:   width,
+		Mentions: mentions(text),
+		Due:      dueDate(text),
+		mark:     n + marker + spaces + 1,
+	}, true
+}
+
+// listMarker reports whether the line is a list item, and returns its indent.
+func listMarker(line string) (int, bool) {
+	width, n := indentWidth(line)
+	_, ok := markerLength(line[n:])
+	return width, ok
+}
+
+// markerLength returns the length of the list marker such as "-" or "1." followed by 1 to 4 spaces.
+func markerLength(s string) (int, bool) {
+	marker := 0
+	switch {
+	case strings.HasPrefix(s, "-"), strings.HasPrefix(s, "*"), strings.HasPrefix(s, "+"):
+		marker = 1
+	default:
+		for marker < len(s) && marker < 9 && s[marker] >= '0' && s[marker] <= '9' {
+			marker++
+		}
+		if marker == 0 || marker >= len(s) || (s[marker] != '.' && s[marker] != ')') {
+			return 0, false
+		}
+		marker++
+	}
+
+	rest := s[marker:]
+	spaces := len(rest) - len(strings.TrimLeft(rest, " "))
+	if spaces == 0 || spaces > 4 {
+		return 0, false
+	}
+	return marker, true
+}
+
+var (
+	mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_-]+)`)
+	duePattern     = regexp.MustCompile(`(?i)(?:^|[^\w])due:\s*(\d{4})[-/](\d{1,2})[-/](\d{1,2})(?:$|[^\d])`)
+)
+
+// mentions returns the screen names mentioned in the text in order, without duplicates.
+func mentions(text string) []string {
+	names := []string{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(names, m[1]) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// dueDate returns the first valid due date in the text as a date in UTC.
+func dueDate(text string) *time.Time {
+	for _, m := range duePattern.FindAllStringSubmatch(text, -1) {
+		y, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[2])
+		d, _ := strconv.Atoi(m[3])
+		t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, time.UTC)
+		// reject dates normalized by time.Date such as 2024-02-30
+		if t.Month() == time.Month(mo) && t.Day() == d {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package esamd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esamd"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBody = `intro
+- [ ] root task
+
+# Release ##
+## Notes
+- [x] written
+  - [ ] reviewed
+## Checklist
+1. [ ] deploy
+2. [X] announce
+* [] not a task
+- [ ]not a task
+` + "```md" + `
+# not a heading
+- [ ] not a task
+` + "```" + `
+# C#
+####### not a heading
+#not a heading
+    # indented code
+`
+
+func Test_Parse(t *testing.T) {
+	asst := assert.New(t)
+	doc := esamd.Parse(testBody)
+
+	root := doc.Root()
+	asst.Equal(0, root.Level)
+	asst.Equal(testBody, root.Text())
+	if asst.Len(root.Children, 2) {
+		asst.Equal("Release", root.Children[0].Title)
+		asst.Equal("C#", root.Children[1].Title)
+	}
+
+	release, ok := doc.Section("Release")
+	asst.True(ok)
+	asst.Equal(1, release.Level)
+	asst.Equal("# Release ##\n", release.Heading())
+	if asst.Len(release.Children, 2) {
+		asst.Equal("Notes", release.Children[0].Title)
+		asst.Equal(release, release.Children[0].Parent)
+	}
+
+	checklist, ok := doc.Section("Release", "Checklist")
+	asst.True(ok)
+	asst.Equal(2, checklist.Level)
+	asst.Equal("1. [ ] deploy\n2. [X] announce\n* [] not a task\n- [ ]not a task\n```md\n# not a heading\n- [ ] not a task\n```\n", checklist.Body())
+
+	_, ok = doc.Section("Release", "Unknown")
+	asst.False(ok)
+	_, ok = doc.Section("Notes")
+	asst.False(ok)
+
+	type task struct {
+		checked bool
+		text    string
+		line    int
+		indent  int
+		section string
+	}
+	tasks := []task{}
+	for _, t := range doc.Tasks() {
+		tasks = append(tasks, task{t.Checked, t.Text, t.Line, t.Indent, t.Section.Title})
+	}
+	asst.Equal([]task{
+		{false, "root task", 2, 0, ""},
+		{true, "written", 6, 0, "Notes"},
+		{false, "reviewed", 7, 2, "Notes"},
+		{false, "deploy", 9, 0, "Checklist"},
+		{true, "announce", 10, 0, "Checklist"},
+	}, tasks)
+	asst.Len(checklist.Tasks, 2)
+}
+
+func Test_Task_SetChecked(t *testing.T) {
+	asst := assert.New(t)
+	doc := esamd.Parse("- [ ] a\r\n- [X] b\r\n")
+
+	tasks := doc.Tasks()
+	asst.NoError(tasks[0].SetChecked(true))
+	asst.NoError(tasks[1].SetChecked(false))
+	asst.Equal("- [x] a\r\n- [ ] b\r\n", doc.String())
+	asst.True(tasks[0].Checked)
+	asst.False(tasks[1].Checked)
+
+	// no changes if the state is the same
+	asst.NoError(tasks[0].SetChecked(true))
+	asst.Equal("- [x] a\r\n- [ ] b\r\n", doc.String())
+}
+
+func Test_Document_ReplaceSection(t *testing.T) {
+	cases := []struct {
+		name    string
+		src     string
+		path    []string
+		body    string
+		expect  string
+		wantErr error
+	}{
+		{
+			name:   "ok",
+			src:    "# A\nold\n## A-1\nold\n# B\nkeep\n",
+			path:   []string{"A"},
+			body:   "new\n",
+			expect: "# A\nnew\n# B\nkeep\n",
+		},
+		{
+			name:   "ok: subsection",
+			src:    "# A\nkeep\n## A-1\nold\n\n## A-2\nkeep\n",
+			path:   []string{"A", "A-1"},
+			body:   "new\n\n",
+			expect: "# A\nkeep\n## A-1\nnew\n\n## A-2\nkeep\n",
+		},
+		{
+			name:   "ok: line break is added before the next section",
+			src:    "# A\nold\n# B\n",
+			path:   []string{"A"},
+			body:   "new",
+			expect: "# A\nnew\n# B\n",
+		},
+		{
+			name:   "ok: last section",
+			src:    "# A\nkeep\n# B\nold",
+			path:   []string{"B"},
+			body:   "new",
+			expect: "# A\nkeep\n# B\nnew",
+		},
+		{
+			name:   "ok: heading without line break",
+			src:    "# A",
+			path:   []string{"A"},
+			body:   "new\n",
+			expect: "# A\nnew\n",
+		},
+		{
+			name:   "ok: empty body",
+			src:    "# A\nold\n# B\n",
+			path:   []string{"A"},
+			body:   "",
+			expect: "# A\n# B\n",
+		},
+		{
+			name:   "ok: root",
+			src:    "# A\nold\n",
+			path:   []string{},
+			body:   "replaced\n",
+			expect: "replaced\n",
+		},
+		{
+			name:    "ng: not found",
+			src:     "# A\n",
+			path:    []string{"B"},
+			wantErr: esamd.ErrSectionNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			doc := esamd.Parse(c.src)
+			err := doc.ReplaceSection(c.path, c.body)
+			if c.wantErr != nil {
+				asst.ErrorIs(err, c.wantErr)
+				asst.Equal(c.src, doc.String())
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, doc.String())
+		})
+	}
+}
+
+func Test_Document_Stale(t *testing.T) {
+	asst := assert.New(t)
+	doc := esamd.Parse("# A\n- [ ] a\n# B\n- [ ] b\n")
+
+	a, _ := doc.Section("A")
+	task := doc.Tasks()[1]
+	asst.NoError(doc.ReplaceSection([]string{"B"}, "- [ ] b\n- [ ] c\n"))
+
+	asst.ErrorIs(a.Replace("x\n"), esamd.ErrStale)
+	asst.ErrorIs(task.SetChecked(true), esamd.ErrStale)
+
+	asst.Len(doc.Tasks(), 3)
+	asst.NoError(doc.Tasks()[2].SetChecked(true))
+	asst.Equal("# A\n- [ ] a\n# B\n- [ ] b\n- [x] c\n", doc.String())
+}
+
+func Test_Task_Nesting(t *testing.T) {
+	asst := assert.New(t)
+	doc := esamd.Parse(`- [ ] a
+  - [ ] a-1
+    - [x] a-1-1
+  - [ ] a-2
+- plain
+  - [ ] b
+    * [ ] b-1
+	- [ ] b-2
+
+paragraph
+  - [ ] c
+# heading
+  - [ ] d
+`)
+
+	parents := map[string]string{}
+	children := map[string][]string{}
+	for _, t := range doc.Tasks() {
+		if t.Parent != nil {
+			parents[t.Text] = t.Parent.Text
+		}
+		for _, c := range t.Children {
+			children[t.Text] = append(children[t.Text], c.Text)
+		}
+	}
+	asst.Equal(map[string]string{
+		"a-1":   "a",
+		"a-1-1": "a-1",
+		"a-2":   "a",
+		"b-1":   "b",
+		"b-2":   "b",
+	}, parents)
+	asst.Equal(map[string][]string{
+		"a":   {"a-1", "a-2"},
+		"a-1": {"a-1-1"},
+		"b":   {"b-1", "b-2"},
+	}, children)
+}
+
+func Test_Task_MentionsAndDue(t *testing.T) {
+	date := func(y int, m time.Month, d int) *time.Time {
+		t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	cases := []struct {
+		name           string
+		line           string
+		expectMentions []string
+		expectDue      *time.Time
+	}{
+		{"none", "- [ ] task", []string{}, nil},
+		{"mentions", "- [ ] @alice review with @bob-2 and @alice", []string{"alice", "bob-2"}, nil},
+		{"email is not a mention", "- [ ] mail to alice@example.com", []string{}, nil},
+		{"due with hyphens", "- [ ] release due:2024-01-31", []string{}, date(2024, 1, 31)},
+		{"due with slashes and space", "- [ ] release (DUE: 2024/2/5)", []string{}, date(2024, 2, 5)},
+		{"invalid due", "- [ ] release due:2024-02-30", []string{}, nil},
+		{"not a due", "- [ ] overdue:2024-01-31", []string{}, nil},
+		{"both", "- [x] @carol due:2024-12-01", []string{"carol"}, date(2024, 12, 1)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			tasks := esamd.Parse(c.line).Tasks()
+			if asst.Len(tasks, 1) {
+				asst.Equal(c.expectMentions, tasks[0].Mentions)
+				asst.Equal(c.expectDue, tasks[0].Due)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Package esamerge performs a line-based three-way merge of Markdown bodies.
+//
+// It is used to resolve conflicting updates of a post: the base is the body of the
+// original revision, the remote is the body updated by someone else, and the local is
+// the body to send.
+//
+//	r := esamerge.Merge(base, remote, local)
+//	if !r.HasConflicts() {
+//		body = r.String()
+//	} else {
+//		body, err = r.Resolve(esamerge.PreferLocal)
+//	}
+package esamerge
+
+import (
+	"strings"
+
+	"github.com/michimani/go-esa/internal/linediff"
+)
+
+const (
+	MarkerRemote = "<<<<<<< remote"
+	MarkerBase   = "||||||| base"
+	MarkerSep    = "======="
+	MarkerLocal  = ">>>>>>> local"
+)
+
+// Hunk is a part of the merged text.
+type Hunk struct {
+	// Conflict reports whether the remote and the local changed the part differently.
+	Conflict bool
+
+	// Merged is the merged text of the part. It is empty for conflicting hunks.
+	Merged string
+
+	// Base, Remote and Local are the texts of the part in each version. They are set only for conflicting hunks.
+	Base   string
+	Remote string
+	Local  string
+
+	// BaseLine is the 1-based line number of the part in the base.
+	BaseLine int
+}
+
+// Result is the result of Merge.
+type Result struct {
+	Hunks []Hunk
+}
+
+// Resolver returns the text of the conflicting hunk.
+type Resolver func(h *Hunk) (string, error)
+
+var (
+	// PreferLocal resolves conflicts with the local text.
+	PreferLocal Resolver = func(h *Hunk) (string, error) { return h.Local, nil }
+
+	// PreferRemote resolves conflicts with the remote text.
+	PreferRemote Resolver = func(h *Hunk) (string, error) { return h.Remote, nil }
+
+	// Union resolves conflicts with the remote text followed by the local text.
+	Union Resolver = func(h *Hunk) (string, error) { return h.Remote + h.Local, nil }
+)
+
+// Merge merges the changes from the base to the remote and to the local.
+func Merge(base, remote, local string) *Result {
+	o, a, b := linediff.Split(base), linediff.Split(remote), linediff.Split(local)
+	ma, mb := linediff.Match(o, a), linediff.Match(o, b)
+
+	r := &Result{Hunks: []Hunk{}}
+	i, j, k := 0, 0, 0
+	for {
+		// the lines unchanged in both versions
+		n := 0
+		for i+n < len(o) && ma[i+n] == j+n && mb[i+n] == k+n {
+			n++
+		}
+		if n > 0 {
+			r.add(Hunk{Merged: join(o[i : i+n]), BaseLine: i + 1})
+			i, j, k = i+n, j+n, k+n
+			continue
+		}
+
+		if i == len(o) && j == len(a) && k == len(b) {
+			return r
+		}
+
+		// the changed lines until the next line unchanged in both versions
+		ni := i
+		for ni < len(o) && (ma[ni] < 0 || mb[ni] < 0) {
+			ni++
+		}
+		nj, nk := len(a), len(b)
+		if ni < len(o) {
+			nj, nk = ma[ni], mb[ni]
+		}
+
+		r.add(chunk(o[i:ni], a[j:nj], b[k:nk], i+1))
+		i, j, k = ni, nj, nk
+	}
+}
+
+func chunk(o, a, b []string, line int) Hunk {
+	base, remote, local := join(o), join(a), join(b)
+	switch {
+	case remote == base:
+		return Hunk{Merged: local, BaseLine: line}
+	case local == base, local == remote:
+		return Hunk{Merged: remote, BaseLine: line}
+	}
+	return Hunk{Conflict: true, Base: base, Remote: remote, Local: local, BaseLine: line}
+}
+
+// add appends the hunk, joining it with the previous one if both are resolved.
+func (r *Result) add(h Hunk) {
+	if n := len(r.Hunks); n > 0 && !h.Conflict && !r.Hunks[n-1].Conflict {
+		r.Hunks[n-1].Merged += h.Merged
+		return
+	}
+	r.Hunks = append(r.Hunks, h)
+}
+
+// HasConflicts reports whether the result has conflicting hunks.
+func (r *Result) HasConflicts() bool {
+	return len(r.Conflicts()) > 0
+}
+
+// Conflicts returns the conflicting hunks.
+func (r *Result) Conflicts() []*Hunk {
+	cs := []*Hunk{}
+	for i := range r.Hunks {
+		if r.Hunks[i].Conflict {
+			cs = append(cs, &r.Hunks[i])
+		}
+	}
+	return cs
+}
+
+// String returns the merged text. Conflicting hunks are written with the conflict markers
+// in the diff3 style.
+func (r *Result) String() string {
+	sb := new(strings.Builder)
+	for _, h := range r.Hunks {
+		if !h.Conflict {
+			sb.WriteString(h.Merged)
+			continue
+		}
+
+		sb.WriteString(MarkerRemote + "\n")
+		writeLines(sb, h.Remote)
+		sb.WriteString(MarkerBase + "\n")
+		writeLines(sb, h.Base)
+		sb.WriteString(MarkerSep + "\n")
+		writeLines(sb, h.Local)
+		sb.WriteString(MarkerLocal + "\n")
+	}
+	return sb.String()
+}
+
+// Resolve returns the merged text, resolving each conflicting hunk with the resolver.
+func (r *Result) Resolve(resolve Resolver) (string, error) {
+	sb := new(strings.Builder)
+	for i := range r.Hunks {
+		h := &r.Hunks[i]
+		if !h.Conflict {
+			sb.WriteString(h.Merged)
+			continue
+		}
+
+		s, err := resolve(h)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s)
+	}
+	return sb.String(), nil
+}
+
+// writeLines writes the text, terminating the last line so that a marker follows it.
+func writeLines(sb *strings.Builder, s string) {
+	sb.WriteString(s)
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		sb.WriteString("\n")
+	}
+}
+
+func join(lines []string) string {
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,213 @@
+package esamerge_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/esamerge"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Merge(t *testing.T) {
+	cases := []struct {
+		name            string
+		base            string
+		remote          string
+		local           string
+		expect          string
+		expectConflicts []esamerge.Hunk
+	}{
+		{
+			name:   "ok: no changes",
+			base:   "a\nb\n",
+			remote: "a\nb\n",
+			local:  "a\nb\n",
+			expect: "a\nb\n",
+		},
+		{
+			name:   "ok: only remote",
+			base:   "a\nb\nc\n",
+			remote: "a\nB\nc\n",
+			local:  "a\nb\nc\n",
+			expect: "a\nB\nc\n",
+		},
+		{
+			name:   "ok: only local",
+			base:   "a\nb\nc\n",
+			remote: "a\nb\nc\n",
+			local:  "a\nb\nc\nd\n",
+			expect: "a\nb\nc\nd\n",
+		},
+		{
+			name:   "ok: different lines",
+			base:   "# title\n\na\nb\nc\nd\n",
+			remote: "# Title\n\na\nb\nc\nd\n",
+			local:  "# title\n\na\nb\nc\nd\ne\n",
+			expect: "# Title\n\na\nb\nc\nd\ne\n",
+		},
+		{
+			name:   "ok: same change",
+			base:   "a\nb\nc\n",
+			remote: "a\nx\nc\n",
+			local:  "a\nx\nc\n",
+			expect: "a\nx\nc\n",
+		},
+		{
+			name:   "ok: deletion and insertion",
+			base:   "a\nb\nc\nd\ne\n",
+			remote: "a\nc\nd\ne\n",
+			local:  "a\nb\nc\nd\nX\ne\n",
+			expect: "a\nc\nd\nX\ne\n",
+		},
+		{
+			name:   "ok: empty base",
+			base:   "",
+			remote: "",
+			local:  "new\n",
+			expect: "new\n",
+		},
+		{
+			name:   "ng: conflict",
+			base:   "a\nb\nc\n",
+			remote: "a\nremote\nc\n",
+			local:  "a\nlocal\nc\n",
+			expect: "a\n<<<<<<< remote\nremote\n||||||| base\nb\n=======\nlocal\n>>>>>>> local\nc\n",
+			expectConflicts: []esamerge.Hunk{
+				{Conflict: true, Base: "b\n", Remote: "remote\n", Local: "local\n", BaseLine: 2},
+			},
+		},
+		{
+			name:   "ng: conflict at the end without line break",
+			base:   "a\nb",
+			remote: "a\nremote",
+			local:  "a\nlocal",
+			expect: "a\n<<<<<<< remote\nremote\n||||||| base\nb\n=======\nlocal\n>>>>>>> local\n",
+			expectConflicts: []esamerge.Hunk{
+				{Conflict: true, Base: "b", Remote: "remote", Local: "local", BaseLine: 2},
+			},
+		},
+		{
+			name:   "ng: both append",
+			base:   "a\n",
+			remote: "a\nr\n",
+			local:  "a\nl\n",
+			expect: "a\n<<<<<<< remote\nr\n||||||| base\n=======\nl\n>>>>>>> local\n",
+			expectConflicts: []esamerge.Hunk{
+				{Conflict: true, Remote: "r\n", Local: "l\n", BaseLine: 2},
+			},
+		},
+		{
+			name:   "ng: two conflicts",
+			base:   "a\nb\nc\nd\ne\n",
+			remote: "A1\nb\nc\nd\nE1\n",
+			local:  "A2\nb\nc\nd\nE2\n",
+			expect: "<<<<<<< remote\nA1\n||||||| base\na\n=======\nA2\n>>>>>>> local\nb\nc\nd\n<<<<<<< remote\nE1\n||||||| base\ne\n=======\nE2\n>>>>>>> local\n",
+			expectConflicts: []esamerge.Hunk{
+				{Conflict: true, Base: "a\n", Remote: "A1\n", Local: "A2\n", BaseLine: 1},
+				{Conflict: true, Base: "e\n", Remote: "E1\n", Local: "E2\n", BaseLine: 5},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			r := esamerge.Merge(c.base, c.remote, c.local)
+			asst.Equal(c.expect, r.String())
+			asst.Equal(len(c.expectConflicts) > 0, r.HasConflicts())
+
+			conflicts := []esamerge.Hunk{}
+			for _, h := range r.Conflicts() {
+				conflicts = append(conflicts, *h)
+			}
+			if c.expectConflicts == nil {
+				c.expectConflicts = []esamerge.Hunk{}
+			}
+			asst.Equal(c.expectConflicts, conflicts)
+		})
+	}
+}
+
+func Test_Result_Resolve(t *testing.T) {
+	errResolve := errors.New("resolve error")
+	r := esamerge.Merge("a\nb\nc\n", "a\nremote\nc\n", "a\nlocal\nc\n")
+
+	cases := []struct {
+		name     string
+		resolver esamerge.Resolver
+		expect   string
+		wantErr  bool
+	}{
+		{name: "ok: prefer local", resolver: esamerge.PreferLocal, expect: "a\nlocal\nc\n"},
+		{name: "ok: prefer remote", resolver: esamerge.PreferRemote, expect: "a\nremote\nc\n"},
+		{name: "ok: union", resolver: esamerge.Union, expect: "a\nremote\nlocal\nc\n"},
+		{
+			name: "ok: custom",
+			resolver: func(h *esamerge.Hunk) (string, error) {
+				return fmt.Sprintf("line %d: %s", h.BaseLine, strings.ToUpper(h.Local)), nil
+			},
+			expect: "a\nline 2: LOCAL\nc\n",
+		},
+		{
+			name:     "ng: error",
+			resolver: func(h *esamerge.Hunk) (string, error) { return "", errResolve },
+			wantErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			s, err := r.Resolve(c.resolver)
+			if c.wantErr {
+				asst.ErrorIs(err, errResolve)
+				return
+			}
+			asst.NoError(err)
+			asst.Equal(c.expect, s)
+		})
+	}
+}
+
+func Test_Merge_Large(t *testing.T) {
+	asst := assert.New(t)
+
+	lines := make([]string, 3000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d\n", i)
+	}
+	base := strings.Join(lines, "")
+
+	remote := strings.Replace(base, "line 10\n", "remote 10\n", 1)
+	local := strings.Replace(base, "line 2990\n", "local 2990\n", 1)
+	asst.Equal(strings.Replace(remote, "line 2990\n", "local 2990\n", 1), esamerge.Merge(base, remote, local).String())
+
+	// completely different bodies are a single conflict
+	other := strings.ReplaceAll(base, "line", "other")
+	r := esamerge.Merge(base, other, base+"appended\n")
+	if asst.Len(r.Conflicts(), 1) {
+		asst.Equal(other, r.Conflicts()[0].Remote)
+	}
+}
+
+func Test_Merge_OneSided(t *testing.T) {
+	asst := assert.New(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	randomText := func() string {
+		sb := new(strings.Builder)
+		for range rnd.IntN(20) {
+			fmt.Fprintf(sb, "%c\n", 'a'+rune(rnd.IntN(4)))
+		}
+		return sb.String()
+	}
+
+	for range 500 {
+		base, changed := randomText(), randomText()
+		asst.Equal(changed, esamerge.Merge(base, changed, base).String(), "base=%q changed=%q", base, changed)
+		asst.Equal(changed, esamerge.Merge(base, base, changed).String(), "base=%q changed=%q", base, changed)
+	}
+}
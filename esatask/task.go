@@ -0,0 +1,181 @@
+// Package esatask extracts task list items from esa posts and checks or unchecks them.
+//
+// A task is a list item with a check box. Assignees and due dates are written inline,
+// such as "- [ ] write the release note @alice due:2024-01-31".
+//
+//	items, err := esatask.Collect(ctx, c, &esatask.CollectInput{
+//		TeamName: "docs",
+//		Q:        "in:projects",
+//		Filter:   esatask.All(esatask.Unchecked, esatask.AssignedTo("alice")),
+//	})
+package esatask
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esamd"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+)
+
+// ErrNoTasks is returned by SetChecked when no task of the post matches.
+var ErrNoTasks = errors.New("no task matches")
+
+const perPage = 100
+
+// Item is a task of a post.
+type Item struct {
+	PostNumber   int
+	PostFullName string
+	PostURL      string
+	Task         *esamd.Task
+}
+
+// Filter reports whether the item is collected.
+type Filter func(*Item) bool
+
+// Unchecked is the filter of the tasks that are not done.
+func Unchecked(i *Item) bool {
+	return !i.Task.Checked
+}
+
+// AssignedTo returns the filter of the tasks mentioning the screen name.
+func AssignedTo(screenName string) Filter {
+	return func(i *Item) bool {
+		for _, m := range i.Task.Mentions {
+			if m == screenName {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// DueBy returns the filter of the tasks due on or before the date.
+func DueBy(t time.Time) Filter {
+	return func(i *Item) bool {
+		return i.Task.Due != nil && !i.Task.Due.After(t)
+	}
+}
+
+// All returns the filter of the items that match all the filters.
+func All(filters ...Filter) Filter {
+	return func(i *Item) bool {
+		for _, f := range filters {
+			if !f(i) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Extract returns the tasks in the body of the post.
+func Extract(p *models.Post) []*Item {
+	if p == nil {
+		return nil
+	}
+
+	tasks := esamd.Parse(p.BodyMD).Tasks()
+	items := make([]*Item, 0, len(tasks))
+	for _, t := range tasks {
+		items = append(items, &Item{
+			PostNumber:   p.Number,
+			PostFullName: p.FullName,
+			PostURL:      p.URL,
+			Task:         t,
+		})
+	}
+	return items
+}
+
+// Count returns the number of the done tasks and all tasks in the body.
+// models.Post has only DoneTasksCount, so this is used to get the total.
+func Count(body string) (done, total int) {
+	for _, t := range esamd.Parse(body).Tasks() {
+		if t.Checked {
+			done++
+		}
+		total++
+	}
+	return done, total
+}
+
+type CollectInput struct {
+	TeamName string
+
+	// Q filters the posts with the search query of post.ListPosts.
+	Q string
+
+	// Filter filters the tasks. All tasks are collected if it is nil.
+	Filter Filter
+}
+
+// Collect returns the tasks of the posts of the team, in the order of the post numbers and the lines.
+func Collect(ctx context.Context, c gesa.IAPIClient, in *CollectInput) ([]*Item, error) {
+	if in == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+	if in.TeamName == "" {
+		return nil, internal.NewRequiredParameterEmptyError("CollectInput.TeamName")
+	}
+
+	lin := &types.ListPostsInput{
+		TeamName: in.TeamName,
+		Q:        in.Q,
+		Sort:     types.ListPostsSortNumber,
+		Order:    types.ListPostsOrderAsc,
+		PerPage:  gesa.NewPageNumber(perPage),
+	}
+	fetch := func(ctx context.Context, in *types.ListPostsInput) (*types.ListPostsOutput, error) {
+		return post.ListPosts(ctx, c, in)
+	}
+
+	items := []*Item{}
+	for p, err := range gesa.Paginate(ctx, lin, fetch) {
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range Extract(&p) {
+			if in.Filter == nil || in.Filter(i) {
+				items = append(items, i)
+			}
+		}
+	}
+	return items, nil
+}
+
+// SetChecked checks or unchecks the tasks of the post that match the function, and updates the post
+// with post.Modify. Only the check boxes are changed, so the edit is merged cleanly with concurrent
+// edits of other lines. ErrNoTasks is returned if no task matches.
+func SetChecked(ctx context.Context, c gesa.IAPIClient, teamName string, postNumber int, match func(*esamd.Task) bool, checked bool, opts ...post.ModifyOption) (*types.UpdatePostOutput, error) {
+	if match == nil {
+		return nil, internal.NewParameterIsNilError()
+	}
+
+	return post.Modify(ctx, c, teamName, postNumber, func(p *models.Post) error {
+		doc := esamd.Parse(p.BodyMD)
+		matched := false
+		for _, t := range doc.Tasks() {
+			if !match(t) {
+				continue
+			}
+			matched = true
+			if err := t.SetChecked(checked); err != nil {
+				return err
+			}
+		}
+		if !matched {
+			return ErrNoTasks
+		}
+
+		p.BodyMD = doc.String()
+		return nil
+	}, opts...)
+}
@@ -0,0 +1,211 @@
+package esatask_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esamd"
+	"github.com/michimani/go-esa/esatask"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Extract(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.Nil(esatask.Extract(nil))
+
+	items := esatask.Extract(&models.Post{
+		Number:   3,
+		FullName: "projects/release",
+		URL:      "https://docs.esa.io/posts/3",
+		BodyMD:   "# todo\n- [x] tag @alice\n- [ ] publish due:2024-02-01\n",
+	})
+	if asst.Len(items, 2) {
+		asst.Equal(3, items[0].PostNumber)
+		asst.Equal("projects/release", items[0].PostFullName)
+		asst.Equal("https://docs.esa.io/posts/3", items[0].PostURL)
+		asst.Equal("tag @alice", items[0].Task.Text)
+		asst.Equal("publish due:2024-02-01", items[1].Task.Text)
+	}
+}
+
+func Test_Count(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		expectDone  int
+		expectTotal int
+	}{
+		{"no tasks", "# hello\n- item\n", 0, 0},
+		{"nested", "- [x] a\n  - [ ] a-1\n  - [X] a-2\n* [ ] b\n", 2, 4},
+		{"in code block", "```\n- [ ] not a task\n```\n- [ ] task\n", 0, 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			done, total := esatask.Count(c.body)
+			asst.Equal(c.expectDone, done)
+			asst.Equal(c.expectTotal, total)
+		})
+	}
+}
+
+func Test_Collect(t *testing.T) {
+	ctx := context.Background()
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddToken("alice-token", "alice")
+
+	s.AddPost("docs", models.Post{Name: "release", Category: "projects", BodyMD: "- [x] tag @alice\n- [ ] publish @alice due:2024-02-01\n- [ ] announce @bob due:2024-01-15\n"})
+	s.AddPost("docs", models.Post{Name: "memo", BodyMD: "- [ ] private @alice\n"})
+	s.AddPost("docs", models.Post{Name: "plan", Category: "projects", BodyMD: "- [ ] design @alice due:2024-03-01\n"})
+
+	client, err := s.NewClient("alice-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texts := func(items []*esatask.Item) []string {
+		l := []string{}
+		for _, i := range items {
+			l = append(l, i.PostFullName+": "+i.Task.Text)
+		}
+		return l
+	}
+
+	cases := []struct {
+		name    string
+		in      *esatask.CollectInput
+		expect  []string
+		wantErr bool
+	}{
+		{
+			name: "ok: all",
+			in:   &esatask.CollectInput{TeamName: "docs"},
+			expect: []string{
+				"projects/release: tag @alice",
+				"projects/release: publish @alice due:2024-02-01",
+				"projects/release: announce @bob due:2024-01-15",
+				"memo: private @alice",
+				"projects/plan: design @alice due:2024-03-01",
+			},
+		},
+		{
+			name: "ok: filtered",
+			in: &esatask.CollectInput{
+				TeamName: "docs",
+				Q:        "in:projects",
+				Filter:   esatask.All(esatask.Unchecked, esatask.AssignedTo("alice"), esatask.DueBy(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))),
+			},
+			expect: []string{"projects/release: publish @alice due:2024-02-01"},
+		},
+		{
+			name:    "ng: nil input",
+			in:      nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: empty team name",
+			in:      &esatask.CollectInput{},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			items, err := esatask.Collect(ctx, client, c.in)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(items)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, texts(items))
+		})
+	}
+}
+
+func Test_SetChecked(t *testing.T) {
+	ctx := context.Background()
+
+	body := "# todo\n- [ ] tag @alice\n  - [x] build\n- [ ] publish\n"
+	byText := func(text string) func(*esamd.Task) bool {
+		return func(t *esamd.Task) bool { return t.Text == text }
+	}
+
+	cases := []struct {
+		name    string
+		match   func(*esamd.Task) bool
+		checked bool
+		expect  string
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "ok: check",
+			match:   byText("tag @alice"),
+			checked: true,
+			expect:  "# todo\n- [x] tag @alice\n  - [x] build\n- [ ] publish\n",
+		},
+		{
+			name:    "ok: uncheck nested",
+			match:   byText("build"),
+			checked: false,
+			expect:  "# todo\n- [ ] tag @alice\n  - [ ] build\n- [ ] publish\n",
+		},
+		{
+			name:    "ok: check all",
+			match:   func(*esamd.Task) bool { return true },
+			checked: true,
+			expect:  "# todo\n- [x] tag @alice\n  - [x] build\n- [x] publish\n",
+		},
+		{
+			name:    "ng: no tasks match",
+			match:   byText("unknown"),
+			checked: true,
+			err:     esatask.ErrNoTasks,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil match",
+			checked: true,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			s := esatest.NewServer()
+			defer s.Close()
+			s.AddToken("alice-token", "alice")
+			p := s.AddPost("docs", models.Post{Name: "release", BodyMD: body})
+
+			client, err := s.NewClient("alice-token")
+			asst.NoError(err)
+
+			res, err := esatask.SetChecked(ctx, client, "docs", p.Number, c.match, c.checked)
+			if c.wantErr {
+				asst.Error(err)
+				if c.err != nil {
+					asst.True(errors.Is(err, c.err))
+				}
+				asst.Nil(res)
+				got, _ := s.Post("docs", p.Number)
+				asst.Equal(body, got.BodyMD)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, res.BodyMD)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package esatest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+type commentPayload struct {
+	Comment struct {
+		BodyMD *string `json:"body_md"`
+		User   *string `json:"user"`
+	} `json:"comment"`
+}
+
+type listCommentsResponse struct {
+	Comments []models.Comment `json:"comments"`
+	pagination
+}
+
+func (s *Server) renderComments(ctx *requestContext, cs []*comment) (int, any) {
+	start, end, pg, ok := paginate(ctx.r, len(cs))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	res := listCommentsResponse{Comments: []models.Comment{}, pagination: pg}
+	for _, c := range cs[start:end] {
+		res.Comments = append(res.Comments, s.renderComment(c, ctx.me.ScreenName, false))
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) listPostComments(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+	return s.renderComments(ctx, ctx.team.postComments(p.Number))
+}
+
+func (s *Server) listTeamComments(ctx *requestContext) (int, any) {
+	cs := make([]*comment, 0, len(ctx.team.comments))
+	for _, c := range ctx.team.comments {
+		cs = append(cs, c)
+	}
+	slices.SortFunc(cs, func(a, b *comment) int { return cmp.Compare(b.ID, a.ID) })
+	return s.renderComments(ctx, cs)
+}
+
+func (s *Server) findComment(ctx *requestContext) (*comment, bool) {
+	id, ok := ctx.intPathValue("id")
+	if !ok {
+		return nil, false
+	}
+	c, ok := ctx.team.comments[id]
+	return c, ok
+}
+
+func (s *Server) getComment(ctx *requestContext) (int, any) {
+	c, ok := s.findComment(ctx)
+	if !ok {
+		return notFound()
+	}
+	return http.StatusOK, s.renderComment(c, ctx.me.ScreenName, false)
+}
+
+func (s *Server) createComment(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	var in commentPayload
+	if !ctx.decode(&in) {
+		return badRequest("Invalid JSON")
+	}
+	if in.Comment.BodyMD == nil || *in.Comment.BodyMD == "" {
+		return badRequest("body_md is required")
+	}
+
+	author := ctx.me.ScreenName
+	if in.Comment.User != nil {
+		if !ctx.team.isOwner(ctx.me.ScreenName) {
+			return forbidden()
+		}
+		author = *in.Comment.User
+	}
+
+	c := s.insertComment(ctx.team, p, models.Comment{
+		BodyMD:    *in.Comment.BodyMD,
+		CreatedBy: models.User{ScreenName: author},
+	})
+	return http.StatusCreated, s.renderComment(c, ctx.me.ScreenName, false)
+}
+
+func (s *Server) updateComment(ctx *requestContext) (int, any) {
+	c, ok := s.findComment(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	var in commentPayload
+	if !ctx.decode(&in) {
+		return badRequest("Invalid JSON")
+	}
+
+	if in.Comment.User != nil {
+		if !ctx.team.isOwner(ctx.me.ScreenName) {
+			return forbidden()
+		}
+		c.CreatedBy = models.User{ScreenName: *in.Comment.User}
+	}
+	if in.Comment.BodyMD != nil {
+		c.BodyMD = *in.Comment.BodyMD
+	}
+	c.UpdatedAt = s.timestamp()
+
+	return http.StatusOK, s.renderComment(c, ctx.me.ScreenName, false)
+}
+
+func (s *Server) deleteComment(ctx *requestContext) (int, any) {
+	c, ok := s.findComment(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	delete(ctx.team.comments, c.ID)
+	return http.StatusNoContent, nil
+}
@@ -0,0 +1,308 @@
+package esatest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+type listTeamsResponse struct {
+	Teams []models.Team `json:"teams"`
+	pagination
+}
+
+type listMembersResponse struct {
+	Members []models.Member `json:"members"`
+	pagination
+}
+
+type listTagsResponse struct {
+	Tags []models.Tag `json:"tags"`
+	pagination
+}
+
+type statsResponse struct {
+	Members            int `json:"members"`
+	Posts              int `json:"posts"`
+	PostsWip           int `json:"posts_wip"`
+	PostsShipped       int `json:"posts_shipped"`
+	Comments           int `json:"comments"`
+	Stars              int `json:"stars"`
+	DailyActiveUsers   int `json:"daily_active_users"`
+	WeeklyActiveUsers  int `json:"weekly_active_users"`
+	MonthlyActiveUsers int `json:"monthly_active_users"`
+}
+
+type tokenInfoResponse struct {
+	ResourceOwnerID int      `json:"resource_owner_id"`
+	Scope           []string `json:"scope"`
+	ExpiresIn       *int64   `json:"expires_in"`
+	Application     *struct {
+		UID string `json:"uid"`
+	} `json:"application"`
+	CreatedAt int64 `json:"created_at"`
+	User      struct {
+		ID int `json:"id"`
+	} `json:"user"`
+}
+
+type batchMovePayload struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type batchMoveResponse struct {
+	Count int    `json:"count"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type emojiPayload struct {
+	Emoji struct {
+		Code       string  `json:"code"`
+		OriginCode *string `json:"origin_code"`
+		Image      *string `json:"image"`
+	} `json:"emoji"`
+}
+
+func (s *Server) getOAuthTokenInfo(ctx *requestContext) (int, any) {
+	res := tokenInfoResponse{
+		ResourceOwnerID: s.userIDs[ctx.me.ScreenName],
+		Scope:           []string{"read", "write"},
+		CreatedAt:       s.now().Unix(),
+	}
+	res.User.ID = res.ResourceOwnerID
+	return http.StatusOK, res
+}
+
+func (s *Server) getMe(ctx *requestContext) (int, any) {
+	me := models.Me{
+		ID:         s.userIDs[ctx.me.ScreenName],
+		Name:       ctx.me.Name,
+		ScreenName: ctx.me.ScreenName,
+		Icon:       ctx.me.Icon,
+		Email:      ctx.me.ScreenName + "@example.com",
+	}
+
+	if slices.Contains(includeValues(ctx.r), "teams") {
+		me.Teams = []models.Team{}
+		for _, name := range s.teamOrder {
+			t := s.teams[name]
+			if t.member(ctx.me.ScreenName) != nil {
+				me.Teams = append(me.Teams, t.Team)
+			}
+		}
+	}
+	return http.StatusOK, me
+}
+
+func (s *Server) listTeams(ctx *requestContext) (int, any) {
+	start, end, pg, ok := paginate(ctx.r, len(s.teamOrder))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	res := listTeamsResponse{Teams: []models.Team{}, pagination: pg}
+	for _, name := range s.teamOrder[start:end] {
+		res.Teams = append(res.Teams, s.teams[name].Team)
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) getTeam(ctx *requestContext) (int, any) {
+	return http.StatusOK, ctx.team.Team
+}
+
+func (s *Server) getStats(ctx *requestContext) (int, any) {
+	res := statsResponse{
+		Members:  len(ctx.team.members),
+		Posts:    len(ctx.team.posts),
+		Comments: len(ctx.team.comments),
+	}
+	for _, p := range ctx.team.posts {
+		if p.Wip {
+			res.PostsWip++
+		} else {
+			res.PostsShipped++
+		}
+		res.Stars += len(p.stars)
+	}
+	for _, c := range ctx.team.comments {
+		res.Stars += len(c.stars)
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) listMembers(ctx *requestContext) (int, any) {
+	start, end, pg, ok := paginate(ctx.r, len(ctx.team.members))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	res := listMembersResponse{Members: []models.Member{}, pagination: pg}
+	for _, m := range ctx.team.members[start:end] {
+		mm := *m
+		mm.Myself = m.ScreenName == ctx.me.ScreenName
+		mm.PostsCount = 0
+		for _, p := range ctx.team.posts {
+			if p.CreatedBy.ScreenName == m.ScreenName {
+				mm.PostsCount++
+			}
+		}
+		res.Members = append(res.Members, mm)
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) deleteMember(ctx *requestContext) (int, any) {
+	if !ctx.team.isOwner(ctx.me.ScreenName) {
+		return forbidden()
+	}
+
+	key := ctx.r.PathValue("screen_name")
+	i := slices.IndexFunc(ctx.team.members, func(m *models.Member) bool {
+		return m.ScreenName == key || (m.Email != "" && m.Email == key)
+	})
+	if i < 0 {
+		return notFound()
+	}
+
+	ctx.team.members = slices.Delete(ctx.team.members, i, i+1)
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) listTags(ctx *requestContext) (int, any) {
+	counts := map[string]int{}
+	for _, p := range ctx.team.posts {
+		for _, tag := range p.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]models.Tag, 0, len(counts))
+	for name, n := range counts {
+		tags = append(tags, models.Tag{Name: name, PostsCount: n})
+	}
+	slices.SortFunc(tags, func(a, b models.Tag) int {
+		if c := cmp.Compare(b.PostsCount, a.PostsCount); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	start, end, pg, ok := paginate(ctx.r, len(tags))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+	return http.StatusOK, listTagsResponse{Tags: tags[start:end], pagination: pg}
+}
+
+func (s *Server) batchMoveCategory(ctx *requestContext) (int, any) {
+	var in batchMovePayload
+	if !ctx.decode(&in) {
+		return badRequest("Invalid JSON")
+	}
+	if in.From == "" || in.To == "" {
+		return badRequest("from and to are required")
+	}
+
+	from := normalizeCategory(in.From)
+	to := normalizeCategory(in.To)
+	count := 0
+	for _, p := range ctx.team.posts {
+		rest, ok := subcategory(p.Category, from)
+		if !ok {
+			continue
+		}
+		p.Category = normalizeCategory(to + "/" + rest)
+		count++
+	}
+
+	return http.StatusOK, batchMoveResponse{Count: count, From: in.From, To: in.To}
+}
+
+// subcategory returns the category relative to the parent category,
+// and reports whether the category is the parent or its descendant.
+func subcategory(category, parent string) (string, bool) {
+	if parent == "" || category == parent {
+		return strings.TrimPrefix(category, parent), true
+	}
+	return strings.CutPrefix(category, parent+"/")
+}
+
+func (s *Server) listEmojis(ctx *requestContext) (int, any) {
+	es := make([]models.Emoji, 0, len(ctx.team.emojis))
+	for _, e := range ctx.team.emojis {
+		me := *e
+		me.Aliases = slices.Clone(e.Aliases)
+		if me.Aliases == nil {
+			me.Aliases = []string{}
+		}
+		es = append(es, me)
+	}
+	return http.StatusOK, struct {
+		Emojis []models.Emoji `json:"emojis"`
+	}{Emojis: es}
+}
+
+func (t *team) emoji(code string) *models.Emoji {
+	for _, e := range t.emojis {
+		if e.Code == code || slices.Contains(e.Aliases, code) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *Server) createEmoji(ctx *requestContext) (int, any) {
+	var in emojiPayload
+	if !ctx.decode(&in) {
+		return badRequest("Invalid JSON")
+	}
+	code := in.Emoji.Code
+	if code == "" {
+		return badRequest("code is required")
+	}
+	if ctx.team.emoji(code) != nil {
+		return badRequest("code has already been taken")
+	}
+
+	if in.Emoji.OriginCode != nil {
+		origin := ctx.team.emoji(*in.Emoji.OriginCode)
+		if origin == nil {
+			return notFound()
+		}
+		origin.Aliases = append(origin.Aliases, code)
+	} else {
+		if in.Emoji.Image == nil || *in.Emoji.Image == "" {
+			return badRequest("image is required")
+		}
+		ctx.team.emojis = append(ctx.team.emojis, &models.Emoji{
+			Code:     code,
+			Category: "Custom",
+			URL:      "https://assets.esa.io/uploads/production/emojis/" + code + ".png",
+		})
+	}
+
+	return http.StatusCreated, struct {
+		Code string `json:"code"`
+	}{Code: code}
+}
+
+func (s *Server) deleteEmoji(ctx *requestContext) (int, any) {
+	code := ctx.r.PathValue("code")
+	for i, e := range ctx.team.emojis {
+		if e.Code == code {
+			ctx.team.emojis = slices.Delete(ctx.team.emojis, i, i+1)
+			return http.StatusNoContent, nil
+		}
+		if j := slices.Index(e.Aliases, code); j >= 0 {
+			e.Aliases = slices.Delete(e.Aliases, j, j+1)
+			return http.StatusNoContent, nil
+		}
+	}
+	return notFound()
+}
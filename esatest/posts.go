@@ -0,0 +1,254 @@
+package esatest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+type postPayload struct {
+	Post struct {
+		Name             *string   `json:"name"`
+		BodyMD           *string   `json:"body_md"`
+		Tags             *[]string `json:"tags"`
+		Category         *string   `json:"category"`
+		Wip              *bool     `json:"wip"`
+		Message          *string   `json:"message"`
+		User             *string   `json:"user"`
+		CreatedBy        *string   `json:"created_by"`
+		UpdatedBy        *string   `json:"updated_by"`
+		OriginalRevision *struct {
+			BodyMD *string `json:"body_md"`
+			Number *int    `json:"number"`
+			User   *string `json:"user"`
+		} `json:"original_revision"`
+	} `json:"post"`
+}
+
+type updatePostResponse struct {
+	models.Post
+
+	Overlapped bool `json:"overlapped"`
+}
+
+type listPostsResponse struct {
+	Posts []models.Post `json:"posts"`
+	pagination
+}
+
+func includeValues(r *http.Request) []string {
+	v := r.URL.Query().Get("include")
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func (s *Server) listPosts(ctx *requestContext) (int, any) {
+	me := ctx.me.ScreenName
+	q, err := parseQuery(ctx.r.URL.Query().Get("q"))
+	if err != nil {
+		return badRequest(err.Error())
+	}
+
+	ps := []*post{}
+	for _, p := range ctx.team.posts {
+		if q.match(ctx.team, p, me) {
+			ps = append(ps, p)
+		}
+	}
+
+	key := func(p *post) int64 {
+		switch ctx.r.URL.Query().Get("sort") {
+		case "created":
+			return p.CreatedAt.UnixNano()
+		case "number":
+			return int64(p.Number)
+		case "stars":
+			return int64(len(p.stars))
+		case "watches":
+			return int64(len(p.watchers))
+		case "comments":
+			return int64(len(ctx.team.postComments(p.Number)))
+		default:
+			return p.UpdatedAt.UnixNano()
+		}
+	}
+	asc := ctx.r.URL.Query().Get("order") == "asc"
+	slices.SortStableFunc(ps, func(a, b *post) int {
+		c := cmp.Compare(key(a), key(b))
+		if c == 0 {
+			c = cmp.Compare(a.Number, b.Number)
+		}
+		if asc {
+			return c
+		}
+		return -c
+	})
+
+	start, end, pg, ok := paginate(ctx.r, len(ps))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	include := includeValues(ctx.r)
+	res := listPostsResponse{Posts: []models.Post{}, pagination: pg}
+	for _, p := range ps[start:end] {
+		res.Posts = append(res.Posts, s.renderPost(ctx.team, p, me, include))
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) findPost(ctx *requestContext) (*post, bool) {
+	n, ok := ctx.intPathValue("number")
+	if !ok {
+		return nil, false
+	}
+	p, ok := ctx.team.posts[n]
+	return p, ok
+}
+
+func (s *Server) getPost(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+	return http.StatusOK, s.renderPost(ctx.team, p, ctx.me.ScreenName, includeValues(ctx.r))
+}
+
+func (s *Server) createPost(ctx *requestContext) (int, any) {
+	var in postPayload
+	if !ctx.decode(&in) {
+		return badRequest("Invalid JSON")
+	}
+	if in.Post.Name == nil || *in.Post.Name == "" {
+		return badRequest("name is required")
+	}
+
+	author := ctx.me.ScreenName
+	if in.Post.User != nil {
+		if !ctx.team.isOwner(ctx.me.ScreenName) {
+			return forbidden()
+		}
+		author = *in.Post.User
+	}
+
+	mp := models.Post{
+		Name:      *in.Post.Name,
+		Wip:       true,
+		CreatedBy: models.User{ScreenName: author},
+		UpdatedBy: models.User{ScreenName: author},
+	}
+	if in.Post.BodyMD != nil {
+		mp.BodyMD = *in.Post.BodyMD
+	}
+	if in.Post.Tags != nil {
+		mp.Tags = *in.Post.Tags
+	}
+	if in.Post.Category != nil {
+		mp.Category = *in.Post.Category
+	}
+	if in.Post.Wip != nil {
+		mp.Wip = *in.Post.Wip
+	}
+	if in.Post.Message != nil {
+		mp.Message = *in.Post.Message
+	}
+
+	p := s.insertPost(ctx.team, mp)
+	return http.StatusCreated, s.renderPost(ctx.team, p, ctx.me.ScreenName, nil)
+}
+
+func (s *Server) updatePost(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	var in postPayload
+	if !ctx.decode(&in) {
+		return badRequest("Invalid JSON")
+	}
+
+	editor := ctx.me.ScreenName
+	if in.Post.CreatedBy != nil || in.Post.UpdatedBy != nil {
+		if !ctx.team.isOwner(ctx.me.ScreenName) {
+			return forbidden()
+		}
+	}
+	if in.Post.UpdatedBy != nil {
+		editor = *in.Post.UpdatedBy
+	}
+
+	if in.Post.Category != nil {
+		p.Category = normalizeCategory(*in.Post.Category)
+	}
+	if in.Post.Name != nil && *in.Post.Name != "" {
+		p.Category, p.Name = splitName(p.Category, *in.Post.Name)
+	}
+	if in.Post.Tags != nil {
+		p.Tags = slices.Clone(*in.Post.Tags)
+	}
+	if in.Post.Wip != nil {
+		p.Wip = *in.Post.Wip
+	}
+	if in.Post.Message != nil {
+		p.Message = *in.Post.Message
+	}
+	if in.Post.CreatedBy != nil {
+		p.CreatedBy = models.User{ScreenName: *in.Post.CreatedBy}
+	}
+
+	overlapped := false
+	if in.Post.BodyMD != nil {
+		body := *in.Post.BodyMD
+		if or := in.Post.OriginalRevision; or != nil && or.Number != nil && *or.Number != p.RevisionNumber {
+			// The body was updated by someone else since the original revision.
+			// Unlike esa, the whole bodies are marked as conflicted instead of merging them.
+			if or.BodyMD == nil || *or.BodyMD != p.BodyMD {
+				if body != p.BodyMD {
+					body = conflictedBody(p.BodyMD, body)
+					overlapped = true
+				}
+			}
+		}
+		if body != p.BodyMD {
+			s.updateBody(p, body, editor)
+		}
+	}
+
+	p.UpdatedBy = models.User{ScreenName: editor}
+	p.UpdatedAt = s.timestamp()
+
+	return http.StatusOK, updatePostResponse{
+		Post:       s.renderPost(ctx.team, p, ctx.me.ScreenName, nil),
+		Overlapped: overlapped,
+	}
+}
+
+// conflictedBody returns the body that contains both the current and the requested body with conflict markers.
+func conflictedBody(current, requested string) string {
+	sb := new(strings.Builder)
+	sb.WriteString("<<<<<<< current\n")
+	sb.WriteString(strings.TrimSuffix(current, "\n") + "\n")
+	sb.WriteString("=======\n")
+	sb.WriteString(strings.TrimSuffix(requested, "\n") + "\n")
+	sb.WriteString(">>>>>>> requested\n")
+	return sb.String()
+}
+
+func (s *Server) deletePost(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	for _, c := range ctx.team.postComments(p.Number) {
+		delete(ctx.team.comments, c.ID)
+	}
+	delete(ctx.team.posts, p.Number)
+	return http.StatusNoContent, nil
+}
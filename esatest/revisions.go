@@ -0,0 +1,60 @@
+package esatest
+
+import (
+	"net/http"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+type listRevisionsResponse struct {
+	Revisions []models.Revision `json:"revisions"`
+	pagination
+}
+
+func (s *Server) renderRevision(r Revision, me string) models.Revision {
+	createdAt := r.CreatedAt
+	return models.Revision{
+		Number:    r.Number,
+		BodyMD:    r.BodyMD,
+		CreatedAt: &createdAt,
+		User:      s.renderUser(r.ScreenName, me),
+	}
+}
+
+// listRevisions returns the revisions of the post in descending order.
+func (s *Server) listRevisions(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	start, end, pg, ok := paginate(ctx.r, len(p.revisions))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	res := listRevisionsResponse{Revisions: []models.Revision{}, pagination: pg}
+	for i := start; i < end; i++ {
+		r := p.revisions[len(p.revisions)-1-i]
+		res.Revisions = append(res.Revisions, s.renderRevision(r, ctx.me.ScreenName))
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) getRevision(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+	n, ok := ctx.intPathValue("revision")
+	if !ok {
+		return notFound()
+	}
+
+	for _, r := range p.revisions {
+		if r.Number == n {
+			return http.StatusOK, s.renderRevision(r, ctx.me.ScreenName)
+		}
+	}
+	return notFound()
+}
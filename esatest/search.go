@@ -0,0 +1,222 @@
+package esatest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// query is the parsed q parameter of the posts API.
+// The supported syntax is a subset of esa's:
+//
+//	keyword "quoted keyword" title:... body:...
+//	user:screen_name @screen_name updated_by:screen_name
+//	category:partial in:prefix on:exact
+//	tag:name #name
+//	wip:true|false starred:true|false watched:true|false kind:stock|flow
+//	number:1 comments:>1 stars:>=1 watches:<2
+//	created:>2006-01-02 updated:<=2006-01 (prefix match without an operator)
+//
+// Each term is combined by AND, and the term prefixed with "-" is negated.
+type query struct {
+	terms []term
+}
+
+type term struct {
+	negate bool
+	key    string
+	value  string
+}
+
+func parseQuery(q string) (*query, error) {
+	qq := &query{}
+	for _, tok := range tokenize(q) {
+		t := term{}
+		if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+			t.negate = true
+			tok = tok[1:]
+		}
+
+		switch {
+		case strings.HasPrefix(tok, "#") && len(tok) > 1:
+			t.key, t.value = "tag", tok[1:]
+		case strings.HasPrefix(tok, "@") && len(tok) > 1:
+			t.key, t.value = "user", tok[1:]
+		default:
+			key, value, ok := strings.Cut(tok, ":")
+			if ok && isSupportedKey(key) {
+				t.key, t.value = key, unquote(value)
+			} else {
+				t.value = unquote(tok)
+			}
+		}
+
+		if err := t.validate(); err != nil {
+			return nil, err
+		}
+		qq.terms = append(qq.terms, t)
+	}
+	return qq, nil
+}
+
+func isSupportedKey(key string) bool {
+	switch key {
+	case "title", "body", "user", "updated_by", "category", "in", "on", "tag",
+		"wip", "starred", "watched", "kind", "number", "comments", "stars", "watches",
+		"created", "updated":
+		return true
+	}
+	return false
+}
+
+func (t term) validate() error {
+	switch t.key {
+	case "wip", "starred", "watched":
+		if _, err := strconv.ParseBool(t.value); err != nil {
+			return fmt.Errorf("invalid value of %s: %q", t.key, t.value)
+		}
+	case "number", "comments", "stars", "watches":
+		_, v := splitOperator(t.value)
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid value of %s: %q", t.key, t.value)
+		}
+	}
+	return nil
+}
+
+// tokenize splits the query by white spaces except in double quotes.
+func tokenize(q string) []string {
+	toks := []string{}
+	cur := new(strings.Builder)
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if cur.Len() > 0 {
+				toks = append(toks, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		toks = append(toks, cur.String())
+	}
+	return toks
+}
+
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+func (q *query) match(tm *team, p *post, me string) bool {
+	for _, t := range q.terms {
+		if t.match(tm, p, me) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func (t term) match(tm *team, p *post, me string) bool {
+	switch t.key {
+	case "":
+		return containsFold(fullName(p.Category, p.Name, p.Tags), t.value) || containsFold(p.BodyMD, t.value)
+	case "title":
+		return containsFold(p.Name, t.value)
+	case "body":
+		return containsFold(p.BodyMD, t.value)
+	case "user":
+		return p.CreatedBy.ScreenName == t.value
+	case "updated_by":
+		return p.UpdatedBy.ScreenName == t.value
+	case "category":
+		return containsFold(p.Category, t.value)
+	case "in":
+		_, ok := subcategory(p.Category, normalizeCategory(t.value))
+		return ok
+	case "on":
+		return p.Category == normalizeCategory(t.value)
+	case "tag":
+		return slices.ContainsFunc(p.Tags, func(tag string) bool { return strings.EqualFold(tag, t.value) })
+	case "wip":
+		return p.Wip == parseBool(t.value)
+	case "starred":
+		return slices.ContainsFunc(p.stars, func(st *star) bool { return st.screenName == me }) == parseBool(t.value)
+	case "watched":
+		return slices.ContainsFunc(p.watchers, func(w *watcher) bool { return w.screenName == me }) == parseBool(t.value)
+	case "kind":
+		return p.Kind == t.value
+	case "number":
+		return compareInt(p.Number, t.value)
+	case "stars":
+		return compareInt(len(p.stars), t.value)
+	case "watches":
+		return compareInt(len(p.watchers), t.value)
+	case "comments":
+		return compareInt(len(tm.postComments(p.Number)), t.value)
+	case "created":
+		return compareDate(p.CreatedAt.Format("2006-01-02"), t.value)
+	case "updated":
+		return compareDate(p.UpdatedAt.Format("2006-01-02"), t.value)
+	}
+	return false
+}
+
+func parseBool(s string) bool {
+	b, _ := strconv.ParseBool(s)
+	return b
+}
+
+func splitOperator(s string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if v, ok := strings.CutPrefix(s, op); ok {
+			return op, v
+		}
+	}
+	return "", s
+}
+
+func compareInt(n int, value string) bool {
+	op, v := splitOperator(value)
+	m, _ := strconv.Atoi(v)
+	switch op {
+	case ">=":
+		return n >= m
+	case "<=":
+		return n <= m
+	case ">":
+		return n > m
+	case "<":
+		return n < m
+	}
+	return n == m
+}
+
+// compareDate compares the date formatted as 2006-01-02 with the value.
+// The value can be a prefix of the date such as 2006 or 2006-01.
+func compareDate(date, value string) bool {
+	op, v := splitOperator(value)
+	d := date[:min(len(v), len(date))]
+	switch op {
+	case ">=":
+		return d >= v
+	case "<=":
+		return d <= v
+	case ">":
+		return d > v
+	case "<":
+		return d < v
+	}
+	return d == v
+}
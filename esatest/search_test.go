@@ -0,0 +1,88 @@
+package esatest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	posttypes "github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaapi/star"
+	startypes "github.com/michimani/go-esa/esaapi/star/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server_ListPostsQuery(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestServer(t)
+
+	jan := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	s.AddPost("docs", models.Post{Name: "Go guide", Category: "dev/go", Tags: []string{"go"}, BodyMD: "hello world", CreatedAt: &jan, CreatedBy: models.User{ScreenName: "alice"}})
+	s.AddPost("docs", models.Post{Name: "API spec", Category: "dev/api", Tags: []string{"api", "go"}, Wip: true, CreatedAt: &feb, CreatedBy: models.User{ScreenName: "bob"}})
+	s.AddPost("docs", models.Post{Name: "daily report", Category: "daily/2024", Kind: "flow", BodyMD: "hello", CreatedAt: &feb, CreatedBy: models.User{ScreenName: "bob"}})
+	s.AddComment("docs", 3, models.Comment{BodyMD: "comment"})
+	if _, err := star.CreatePostStar(ctx, c, &startypes.CreatePostStarInput{TeamName: "docs", PostNumber: 2, Body: "nice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		q       string
+		expect  []int
+		wantErr bool
+	}{
+		{name: "normal: empty", q: "", expect: []int{1, 2, 3}},
+		{name: "normal: keyword", q: "hello", expect: []int{1, 3}},
+		{name: "normal: quoted keyword", q: `"hello world"`, expect: []int{1}},
+		{name: "normal: keywords are combined by AND", q: "hello daily", expect: []int{3}},
+		{name: "normal: title", q: "title:guide", expect: []int{1}},
+		{name: "normal: body", q: "body:world", expect: []int{1}},
+		{name: "normal: user", q: "user:bob", expect: []int{2, 3}},
+		{name: "normal: @user", q: "@alice", expect: []int{1}},
+		{name: "normal: category", q: "category:ap", expect: []int{2}},
+		{name: "normal: in", q: "in:dev", expect: []int{1, 2}},
+		{name: "normal: on", q: "on:dev", expect: []int{}},
+		{name: "normal: tag", q: "tag:go", expect: []int{1, 2}},
+		{name: "normal: #tag", q: "#api", expect: []int{2}},
+		{name: "normal: wip", q: "wip:true", expect: []int{2}},
+		{name: "normal: starred", q: "starred:true", expect: []int{2}},
+		{name: "normal: watched", q: "watched:false", expect: []int{2, 3}},
+		{name: "normal: kind", q: "kind:flow", expect: []int{3}},
+		{name: "normal: comments", q: "comments:>0", expect: []int{3}},
+		{name: "normal: created", q: "created:>=2024-02-01", expect: []int{2, 3}},
+		{name: "normal: created prefix", q: "created:2024-01", expect: []int{1}},
+		{name: "normal: negation", q: "-tag:go", expect: []int{3}},
+		{name: "normal: combined", q: "in:dev -wip:true #go", expect: []int{1}},
+		{name: "ng: invalid bool", q: "wip:maybe", wantErr: true},
+		{name: "ng: invalid number", q: "stars:>many", wantErr: true},
+	}
+
+	for _, cc := range cases {
+		t.Run(cc.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			out, err := post.ListPosts(ctx, c, &posttypes.ListPostsInput{
+				TeamName: "docs",
+				Q:        cc.q,
+				Sort:     posttypes.ListPostsSortNumber,
+				Order:    posttypes.ListPostsOrderAsc,
+				PerPage:  gesa.NewPageNumber(100),
+			})
+			if cc.wantErr {
+				asst.Error(err)
+				asst.Equal(http.StatusBadRequest, apiError(err).StatusCode)
+				return
+			}
+
+			asst.NoError(err)
+			numbers := []int{}
+			for _, p := range out.Posts {
+				numbers = append(numbers, p.Number)
+			}
+			asst.Equal(cc.expect, numbers)
+		})
+	}
+}
@@ -0,0 +1,404 @@
+// Package esatest provides an in-memory esa API server for integration tests.
+//
+// The server implements the subset of the esa API v1 supported by the esaapi packages,
+// including pagination, a subset of the search query of posts and rate limit headers.
+//
+//	s := esatest.NewServer()
+//	defer s.Close()
+//
+//	s.AddMember("docs", models.Member{ScreenName: "alice", Role: "owner"})
+//	s.AddToken("test-token", "alice")
+//
+//	c, _ := s.NewClient("test-token")
+//	out, err := post.CreatePost(ctx, c, &types.CreatePostInput{TeamName: "docs", Name: "hello"})
+package esatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/gesa"
+)
+
+const (
+	DefaultRateLimit       = 75
+	DefaultRateLimitWindow = 15 * time.Minute
+
+	// DefaultScreenName is the screen name of the user for tokens not registered by AddToken.
+	DefaultScreenName = "esatest"
+
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// Option configures Server.
+type Option func(*Server)
+
+// WithRateLimit sets the number of requests allowed in the window.
+// If the limit is 0 or less, requests are never limited.
+func WithRateLimit(limit int, window time.Duration) Option {
+	return func(s *Server) {
+		s.rateLimit = limit
+		s.rateLimitWindow = window
+	}
+}
+
+// WithClock sets the function that returns the current time.
+// It is used for timestamps of resources and rate limiting.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithStrictAuth makes the server reject tokens not registered by AddToken.
+func WithStrictAuth() Option {
+	return func(s *Server) {
+		s.strictAuth = true
+	}
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// Server is an in-memory esa API server.
+type Server struct {
+	// URL is the base URL of the server, used as gesa.NewClientInput.BaseURL.
+	URL string
+
+	srv *httptest.Server
+
+	mu              sync.Mutex
+	now             func() time.Time
+	rateLimit       int
+	rateLimitWindow time.Duration
+	strictAuth      bool
+
+	users         map[string]*models.User
+	userIDs       map[string]int
+	tokens        map[string]string
+	teams         map[string]*team
+	teamOrder     []string
+	nextCommentID int
+	requests      []Request
+	// rate limit state per token
+	rateLimits map[string]*rateLimitState
+}
+
+type rateLimitState struct {
+	remaining int
+	reset     time.Time
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:             time.Now,
+		rateLimit:       DefaultRateLimit,
+		rateLimitWindow: DefaultRateLimitWindow,
+		users:           map[string]*models.User{},
+		userIDs:         map[string]int{},
+		tokens:          map[string]string{},
+		teams:           map[string]*team{},
+		nextCommentID:   1,
+		rateLimits:      map[string]*rateLimitState{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.srv = httptest.NewServer(s.handler())
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// NewClient returns *gesa.Client that sends requests to the server.
+func (s *Server) NewClient(token string) (*gesa.Client, error) {
+	return gesa.NewClient(&gesa.NewClientInput{
+		AccessToken: token,
+		BaseURL:     s.URL,
+		HTTPClient:  s.srv.Client(),
+	})
+}
+
+// Requests returns the requests received by the server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := make([]Request, len(s.requests))
+	copy(r, s.requests)
+	return r
+}
+
+// AddToken registers the access token of the user.
+func (s *Server) AddToken(token, screenName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[token] = screenName
+	s.user(screenName)
+}
+
+// ResetRateLimit restores the remaining number of requests of all tokens.
+func (s *Server) ResetRateLimit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimits = map[string]*rateLimitState{}
+}
+
+// user returns the user of the screen name, creating it if it does not exist.
+func (s *Server) user(screenName string) *models.User {
+	u, ok := s.users[screenName]
+	if !ok {
+		u = &models.User{
+			Name:       screenName,
+			ScreenName: screenName,
+			Icon:       "https://img.esa.io/uploads/production/users/" + screenName + "/icon.png",
+		}
+		s.users[screenName] = u
+		s.userIDs[screenName] = len(s.userIDs) + 1
+	}
+	return u
+}
+
+type apiError struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type handlerFunc func(ctx *requestContext) (int, any)
+
+type requestContext struct {
+	r    *http.Request
+	me   *models.User
+	team *team
+}
+
+func (c *requestContext) intPathValue(name string) (int, bool) {
+	n, err := strconv.Atoi(c.r.PathValue(name))
+	return n, err == nil
+}
+
+func (c *requestContext) decode(v any) bool {
+	return json.NewDecoder(c.r.Body).Decode(v) == nil
+}
+
+func notFound() (int, any) {
+	return http.StatusNotFound, apiError{Error: "not_found", Message: "Not found"}
+}
+
+func badRequest(message string) (int, any) {
+	return http.StatusBadRequest, apiError{Error: "bad_request", Message: message}
+}
+
+func forbidden() (int, any) {
+	return http.StatusForbidden, apiError{Error: "forbidden", Message: "Forbidden"}
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	handle := func(pattern string, needTeam bool, h handlerFunc) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			s.serve(w, r, needTeam, h)
+		})
+	}
+
+	handle("GET /oauth/token/info", false, s.getOAuthTokenInfo)
+	handle("GET /v1/user", false, s.getMe)
+	handle("GET /v1/teams", false, s.listTeams)
+	handle("GET /v1/teams/{team}", true, s.getTeam)
+	handle("GET /v1/teams/{team}/stats", true, s.getStats)
+
+	handle("GET /v1/teams/{team}/members", true, s.listMembers)
+	handle("DELETE /v1/teams/{team}/members/{screen_name}", true, s.deleteMember)
+
+	handle("GET /v1/teams/{team}/posts", true, s.listPosts)
+	handle("GET /v1/teams/{team}/posts/{number}", true, s.getPost)
+	handle("POST /v1/teams/{team}/posts", true, s.createPost)
+	handle("PATCH /v1/teams/{team}/posts/{number}", true, s.updatePost)
+	handle("DELETE /v1/teams/{team}/posts/{number}", true, s.deletePost)
+	handle("GET /v1/teams/{team}/posts/{number}/revisions", true, s.listRevisions)
+	handle("GET /v1/teams/{team}/posts/{number}/revisions/{revision}", true, s.getRevision)
+
+	handle("GET /v1/teams/{team}/posts/{number}/comments", true, s.listPostComments)
+	handle("POST /v1/teams/{team}/posts/{number}/comments", true, s.createComment)
+	handle("GET /v1/teams/{team}/comments", true, s.listTeamComments)
+	handle("GET /v1/teams/{team}/comments/{id}", true, s.getComment)
+	handle("PATCH /v1/teams/{team}/comments/{id}", true, s.updateComment)
+	handle("DELETE /v1/teams/{team}/comments/{id}", true, s.deleteComment)
+
+	handle("GET /v1/teams/{team}/posts/{number}/stargazers", true, s.listPostStargazers)
+	handle("POST /v1/teams/{team}/posts/{number}/star", true, s.createPostStar)
+	handle("DELETE /v1/teams/{team}/posts/{number}/star", true, s.deletePostStar)
+	handle("GET /v1/teams/{team}/comments/{id}/stargazers", true, s.listCommentStargazers)
+	handle("POST /v1/teams/{team}/comments/{id}/star", true, s.createCommentStar)
+	handle("DELETE /v1/teams/{team}/comments/{id}/star", true, s.deleteCommentStar)
+
+	handle("GET /v1/teams/{team}/posts/{number}/watchers", true, s.listWatchers)
+	handle("POST /v1/teams/{team}/posts/{number}/watch", true, s.createWatch)
+	handle("DELETE /v1/teams/{team}/posts/{number}/watch", true, s.deleteWatch)
+
+	handle("GET /v1/teams/{team}/tags", true, s.listTags)
+	handle("POST /v1/teams/{team}/categories/batch_move", true, s.batchMoveCategory)
+
+	handle("GET /v1/teams/{team}/emojis", true, s.listEmojis)
+	handle("POST /v1/teams/{team}/emojis", true, s.createEmoji)
+	handle("DELETE /v1/teams/{team}/emojis/{code}", true, s.deleteEmoji)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "not_found", Message: "Not found"})
+	})
+
+	return mux
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, needTeam bool, h handlerFunc) {
+	body := readAll(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		writeJSON(w, http.StatusUnauthorized, apiError{Error: "unauthorized", Message: "Unauthorized"})
+		return
+	}
+
+	screenName, ok := s.tokens[token]
+	if !ok {
+		if s.strictAuth {
+			writeJSON(w, http.StatusUnauthorized, apiError{Error: "unauthorized", Message: "Unauthorized"})
+			return
+		}
+		screenName = DefaultScreenName
+	}
+
+	if !s.consumeRateLimit(w.Header(), token) {
+		writeJSON(w, http.StatusTooManyRequests, apiError{Error: "too_many_requests", Message: "Rate limit exceeded"})
+		return
+	}
+
+	ctx := &requestContext{r: r, me: s.user(screenName)}
+	if needTeam {
+		t, ok := s.teams[r.PathValue("team")]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, apiError{Error: "not_found", Message: "Not found"})
+			return
+		}
+		ctx.team = t
+	}
+
+	status, v := h(ctx)
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+func (s *Server) consumeRateLimit(h http.Header, token string) bool {
+	if s.rateLimit <= 0 {
+		return true
+	}
+
+	now := s.now()
+	st, ok := s.rateLimits[token]
+	if !ok || !now.Before(st.reset) {
+		st = &rateLimitState{remaining: s.rateLimit, reset: now.Add(s.rateLimitWindow)}
+		s.rateLimits[token] = st
+	}
+
+	allowed := st.remaining > 0
+	if allowed {
+		st.remaining--
+	}
+
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(st.remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(st.reset.Unix(), 10))
+
+	return allowed
+}
+
+func readAll(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	b, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	return string(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// pagination is the pagination part of list responses.
+type pagination struct {
+	PrevPage   *int `json:"prev_page"`
+	NextPage   *int `json:"next_page"`
+	TotalCount int  `json:"total_count"`
+	Page       int  `json:"page"`
+	PerPage    int  `json:"per_page"`
+	MaxPerPage int  `json:"max_per_page"`
+}
+
+// paginate returns the range of the page and the pagination information.
+func paginate(r *http.Request, total int) (int, int, pagination, bool) {
+	page := 1
+	perPage := defaultPerPage
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, 0, pagination{}, false
+		}
+		page = n
+	}
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, 0, pagination{}, false
+		}
+		perPage = min(n, maxPerPage)
+	}
+
+	p := pagination{TotalCount: total, Page: page, PerPage: perPage, MaxPerPage: maxPerPage}
+	if page > 1 {
+		prev := page - 1
+		p.PrevPage = &prev
+	}
+	if page*perPage < total {
+		next := page + 1
+		p.NextPage = &next
+	}
+
+	start := min((page-1)*perPage, total)
+	end := min(start+perPage, total)
+	return start, end, p, true
+}
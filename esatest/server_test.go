@@ -0,0 +1,439 @@
+package esatest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/category"
+	categorytypes "github.com/michimani/go-esa/esaapi/category/types"
+	"github.com/michimani/go-esa/esaapi/comment"
+	commenttypes "github.com/michimani/go-esa/esaapi/comment/types"
+	"github.com/michimani/go-esa/esaapi/emoji"
+	emojitypes "github.com/michimani/go-esa/esaapi/emoji/types"
+	"github.com/michimani/go-esa/esaapi/member"
+	membertypes "github.com/michimani/go-esa/esaapi/member/types"
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	posttypes "github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaapi/revision"
+	revisiontypes "github.com/michimani/go-esa/esaapi/revision/types"
+	"github.com/michimani/go-esa/esaapi/star"
+	startypes "github.com/michimani/go-esa/esaapi/star/types"
+	"github.com/michimani/go-esa/esaapi/tag"
+	tagtypes "github.com/michimani/go-esa/esaapi/tag/types"
+	"github.com/michimani/go-esa/esaapi/user"
+	usertypes "github.com/michimani/go-esa/esaapi/user/types"
+	"github.com/michimani/go-esa/esaapi/watch"
+	watchtypes "github.com/michimani/go-esa/esaapi/watch/types"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, opts ...esatest.Option) (*esatest.Server, *gesa.Client) {
+	t.Helper()
+
+	s := esatest.NewServer(opts...)
+	t.Cleanup(s.Close)
+
+	s.AddTeam(models.Team{Name: "docs", Description: "test team"})
+	s.AddMember("docs", models.Member{ScreenName: "alice", Role: "owner"})
+	s.AddMember("docs", models.Member{ScreenName: "bob"})
+	s.AddToken("alice-token", "alice")
+	s.AddToken("bob-token", "bob")
+
+	c, err := s.NewClient("alice-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, c
+}
+
+func apiError(err error) *gesa.EsaAPIError {
+	var ge *gesa.GesaError
+	if errors.As(err, &ge) && ge.OnAPI {
+		return &ge.EsaAPIError
+	}
+	return nil
+}
+
+func Test_Server_Post(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestServer(t)
+	asst := assert.New(t)
+
+	created, err := post.CreatePost(ctx, c, &posttypes.CreatePostInput{
+		TeamName: "docs",
+		Name:     "dev/guide/hello",
+		BodyMD:   gesa.String("first"),
+		Tags:     []*string{gesa.String("api")},
+		Wip:      gesa.Bool(false),
+	})
+	assert.NoError(t, err)
+	asst.Equal(1, created.Number)
+	asst.Equal("hello", created.Name)
+	asst.Equal("dev/guide", created.Category)
+	asst.Equal("dev/guide/hello #api", created.FullName)
+	asst.Equal(1, created.RevisionNumber)
+	asst.Equal("alice", created.CreatedBy.ScreenName)
+	asst.True(created.CreatedBy.Myself)
+	asst.True(created.Watch)
+	asst.Equal(1, created.WatchersCount)
+	asst.NotNil(created.RateLimitInfo)
+
+	updated, err := post.UpdatePost(ctx, c, &posttypes.UpdatePostInput{
+		TeamName:   "docs",
+		PostNumber: created.Number,
+		BodyMD:     gesa.String("second"),
+		OriginalRevision: &posttypes.OriginalRevision{
+			BodyMD: gesa.String("first"),
+			Number: gesa.Int(1),
+			User:   gesa.String("alice"),
+		},
+	})
+	assert.NoError(t, err)
+	asst.Equal("second", updated.BodyMD)
+	asst.Equal(2, updated.RevisionNumber)
+
+	revs := s.Revisions("docs", created.Number)
+	assert.Len(t, revs, 2)
+	asst.Equal("first", revs[0].BodyMD)
+	asst.Equal("second", revs[1].BodyMD)
+
+	// Updating based on the stale revision makes the body conflicted.
+	conflicted, err := post.UpdatePost(ctx, c, &posttypes.UpdatePostInput{
+		TeamName:   "docs",
+		PostNumber: created.Number,
+		BodyMD:     gesa.String("third"),
+		OriginalRevision: &posttypes.OriginalRevision{
+			BodyMD: gesa.String("first"),
+			Number: gesa.Int(1),
+		},
+	})
+	assert.NoError(t, err)
+	asst.Equal("<<<<<<< current\nsecond\n=======\nthird\n>>>>>>> requested\n", conflicted.BodyMD)
+	asst.Equal(3, conflicted.RevisionNumber)
+
+	got, err := post.GetPost(ctx, c, &posttypes.GetPostInput{TeamName: "docs", PostNumber: created.Number})
+	assert.NoError(t, err)
+	asst.Equal(conflicted.BodyMD, got.BodyMD)
+
+	_, err = post.DeletePost(ctx, c, &posttypes.DeletePostInput{TeamName: "docs", PostNumber: created.Number})
+	assert.NoError(t, err)
+
+	_, err = post.GetPost(ctx, c, &posttypes.GetPostInput{TeamName: "docs", PostNumber: created.Number})
+	eae := apiError(err)
+	assert.NotNil(t, eae)
+	asst.Equal(http.StatusNotFound, eae.StatusCode)
+	asst.Equal("not_found", eae.Error)
+
+	_, ok := s.Post("docs", created.Number)
+	asst.False(ok)
+}
+
+func Test_Server_Revision(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	asst := assert.New(t)
+
+	created, err := post.CreatePost(ctx, c, &posttypes.CreatePostInput{TeamName: "docs", Name: "hello", BodyMD: gesa.String("a\nb\nc\n")})
+	asst.NoError(err)
+	for _, body := range []string{"a\nB\nc\n", "a\nB\nc\nd\n"} {
+		_, err := post.UpdatePost(ctx, c, &posttypes.UpdatePostInput{TeamName: "docs", PostNumber: created.Number, BodyMD: gesa.String(body)})
+		asst.NoError(err)
+	}
+
+	list, err := revision.ListRevisions(ctx, c, &revisiontypes.ListRevisionsInput{TeamName: "docs", PostNumber: created.Number, PerPage: gesa.NewPageNumber(2)})
+	asst.NoError(err)
+	asst.Equal(3, list.TotalCount)
+	asst.Equal(gesa.NewPageNumber(2), list.NextPage)
+	if asst.Len(list.Revisions, 2) {
+		asst.Equal(3, list.Revisions[0].Number)
+		asst.Equal("a\nB\nc\nd\n", list.Revisions[0].BodyMD)
+		asst.Equal("alice", list.Revisions[0].User.ScreenName)
+		asst.Equal(2, list.Revisions[1].Number)
+	}
+
+	numbers := []int{}
+	in := &revisiontypes.ListRevisionsInput{TeamName: "docs", PostNumber: created.Number, PerPage: gesa.NewPageNumber(2)}
+	for r, err := range gesa.Paginate(ctx, in, func(ctx context.Context, in *revisiontypes.ListRevisionsInput) (*revisiontypes.ListRevisionsOutput, error) {
+		return revision.ListRevisions(ctx, c, in)
+	}) {
+		asst.NoError(err)
+		numbers = append(numbers, r.Number)
+	}
+	asst.Equal([]int{3, 2, 1}, numbers)
+
+	got, err := revision.GetRevision(ctx, c, &revisiontypes.GetRevisionInput{TeamName: "docs", PostNumber: created.Number, RevisionNumber: 1})
+	asst.NoError(err)
+	asst.Equal("a\nb\nc\n", got.BodyMD)
+	asst.NotNil(got.CreatedAt)
+
+	diff, err := revision.DiffRevisions(ctx, c, &revisiontypes.DiffRevisionsInput{TeamName: "docs", PostNumber: created.Number, From: 1, To: 3})
+	asst.NoError(err)
+	asst.Equal(1, diff.From.Number)
+	asst.Equal(3, diff.To.Number)
+	asst.Equal("--- revision 1\n+++ revision 3\n@@ -1,3 +1,4 @@\n a\n-b\n+B\n c\n+d\n", diff.Diff)
+
+	diff, err = revision.DiffRevisions(ctx, c, &revisiontypes.DiffRevisionsInput{TeamName: "docs", PostNumber: created.Number, From: 2, To: 3, Context: gesa.Int(0)})
+	asst.NoError(err)
+	asst.Equal("--- revision 2\n+++ revision 3\n@@ -3,0 +4 @@\n+d\n", diff.Diff)
+
+	_, err = revision.GetRevision(ctx, c, &revisiontypes.GetRevisionInput{TeamName: "docs", PostNumber: created.Number, RevisionNumber: 4})
+	asst.Equal(http.StatusNotFound, apiError(err).StatusCode)
+	_, err = revision.DiffRevisions(ctx, c, &revisiontypes.DiffRevisionsInput{TeamName: "docs", PostNumber: created.Number, From: 1})
+	asst.Error(err)
+	_, err = revision.DiffRevisions(ctx, c, &revisiontypes.DiffRevisionsInput{TeamName: "docs", PostNumber: created.Number, From: 1, To: 3, Context: gesa.Int(-1)})
+	asst.ErrorIs(err, gesa.ErrInvalidParameter)
+}
+
+func Test_Server_PostOwnerOnlyParameters(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestServer(t)
+	bc, err := s.NewClient("bob-token")
+	assert.NoError(t, err)
+
+	_, err = post.CreatePost(ctx, bc, &posttypes.CreatePostInput{TeamName: "docs", Name: "n", User: gesa.String("alice")})
+	eae := apiError(err)
+	assert.NotNil(t, eae)
+	assert.Equal(t, http.StatusForbidden, eae.StatusCode)
+}
+
+func Test_Server_ListPostsPagination(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestServer(t)
+	asst := assert.New(t)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		updatedAt := base.Add(time.Duration(i) * time.Hour)
+		s.AddPost("docs", models.Post{Name: "post", UpdatedAt: &updatedAt, CreatedAt: &updatedAt})
+	}
+
+	out, err := post.ListPosts(ctx, c, &posttypes.ListPostsInput{TeamName: "docs", PerPage: gesa.NewPageNumber(2)})
+	assert.NoError(t, err)
+	asst.Equal(5, out.TotalCount)
+	asst.Equal(1, out.Page)
+	asst.Equal(2, out.PerPage)
+	asst.Equal(100, out.MaxPerPage)
+	asst.True(out.PrevPage.IsNull())
+	asst.Equal(2, out.NextPage.SafeInt())
+	assert.Len(t, out.Posts, 2)
+	asst.Equal(5, out.Posts[0].Number)
+	asst.Equal(4, out.Posts[1].Number)
+
+	numbers := []int{}
+	for p, err := range gesa.Paginate(ctx, &posttypes.ListPostsInput{
+		TeamName: "docs",
+		Sort:     posttypes.ListPostsSortNumber,
+		Order:    posttypes.ListPostsOrderAsc,
+		PerPage:  gesa.NewPageNumber(2),
+	}, func(ctx context.Context, in *posttypes.ListPostsInput) (*posttypes.ListPostsOutput, error) {
+		return post.ListPosts(ctx, c, in)
+	}) {
+		assert.NoError(t, err)
+		numbers = append(numbers, p.Number)
+	}
+	asst.Equal([]int{1, 2, 3, 4, 5}, numbers)
+}
+
+func Test_Server_Comment(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestServer(t)
+	asst := assert.New(t)
+
+	p := s.AddPost("docs", models.Post{Name: "post"})
+
+	created, err := comment.CreateComment(ctx, c, &commenttypes.CreateCommentInput{TeamName: "docs", PostNumber: p.Number, BodyMD: "hi"})
+	assert.NoError(t, err)
+	asst.Equal("hi", created.BodyMD)
+	asst.Equal(p.Number, created.PostNumber)
+	asst.Equal("alice", created.CreatedBy.ScreenName)
+
+	_, err = comment.UpdateComment(ctx, c, &commenttypes.UpdateCommentInput{TeamName: "docs", CommentID: created.ID, BodyMD: gesa.String("hello")})
+	assert.NoError(t, err)
+
+	list, err := comment.ListPostComments(ctx, c, &commenttypes.ListPostCommentsInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+	assert.Len(t, list.Comments, 1)
+	asst.Equal("hello", list.Comments[0].BodyMD)
+
+	got, ok := s.Post("docs", p.Number)
+	asst.True(ok)
+	asst.Equal(1, got.CommentCount)
+
+	_, err = comment.DeleteComment(ctx, c, &commenttypes.DeleteCommentInput{TeamName: "docs", CommentID: created.ID})
+	assert.NoError(t, err)
+
+	team, err := comment.ListTeamComments(ctx, c, &commenttypes.ListTeamCommentsInput{TeamName: "docs"})
+	assert.NoError(t, err)
+	asst.Empty(team.Comments)
+}
+
+func Test_Server_StarAndWatch(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestServer(t)
+	asst := assert.New(t)
+
+	p := s.AddPost("docs", models.Post{Name: "post", CreatedBy: models.User{ScreenName: "bob"}})
+	cm, _ := s.AddComment("docs", p.Number, models.Comment{BodyMD: "comment"})
+
+	_, err := star.CreatePostStar(ctx, c, &startypes.CreatePostStarInput{TeamName: "docs", PostNumber: p.Number, Body: "nice"})
+	assert.NoError(t, err)
+	_, err = star.CreateCommentStar(ctx, c, &startypes.CreateCommentStarInput{TeamName: "docs", CommentID: cm.ID, Body: "good"})
+	assert.NoError(t, err)
+	_, err = watch.CreateWatch(ctx, c, &watchtypes.CreateWatchInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+
+	sgs, err := star.ListPostStargazers(ctx, c, &startypes.ListPostStargazersInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+	assert.Len(t, sgs.Stargazers, 1)
+	asst.Equal("nice", sgs.Stargazers[0].Body)
+	asst.Equal("alice", sgs.Stargazers[0].User.ScreenName)
+
+	csgs, err := star.ListCommentStargazers(ctx, c, &startypes.ListCommentStargazersInput{TeamName: "docs", CommentID: cm.ID})
+	assert.NoError(t, err)
+	asst.Len(csgs.Stargazers, 1)
+
+	ws, err := watch.ListWatchers(ctx, c, &watchtypes.ListWatchersInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+	assert.Len(t, ws.Watchers, 2)
+	asst.Equal("bob", ws.Watchers[0].User.ScreenName)
+	asst.Equal("alice", ws.Watchers[1].User.ScreenName)
+
+	got, err := post.GetPost(ctx, c, &posttypes.GetPostInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+	asst.True(got.Star)
+	asst.True(got.Watch)
+	asst.Equal(1, got.StargazersCount)
+	asst.Equal(2, got.WatchersCount)
+
+	_, err = star.DeletePostStar(ctx, c, &startypes.DeletePostStarInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+	_, err = watch.DeleteWatch(ctx, c, &watchtypes.DeleteWatchInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+
+	got, err = post.GetPost(ctx, c, &posttypes.GetPostInput{TeamName: "docs", PostNumber: p.Number})
+	assert.NoError(t, err)
+	asst.False(got.Star)
+	asst.False(got.Watch)
+}
+
+func Test_Server_TagsAndCategories(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestServer(t)
+	asst := assert.New(t)
+
+	s.AddPost("docs", models.Post{Name: "a", Category: "dev/api", Tags: []string{"go", "api"}})
+	s.AddPost("docs", models.Post{Name: "b", Category: "dev/api/v2", Tags: []string{"go"}})
+	s.AddPost("docs", models.Post{Name: "c", Category: "dev/apis"})
+
+	tags, err := tag.ListTags(ctx, c, &tagtypes.ListTagsInput{TeamName: "docs"})
+	assert.NoError(t, err)
+	asst.Equal([]models.Tag{{Name: "go", PostsCount: 2}, {Name: "api", PostsCount: 1}}, tags.Tags)
+
+	moved, err := category.BatchMove(ctx, c, &categorytypes.BatchMoveInput{TeamName: "docs", From: "/dev/api/", To: "/archive/"})
+	assert.NoError(t, err)
+	asst.Equal(2, moved.Count)
+
+	for number, expect := range map[int]string{1: "archive", 2: "archive/v2", 3: "dev/apis"} {
+		p, _ := s.Post("docs", number)
+		asst.Equal(expect, p.Category)
+	}
+}
+
+func Test_Server_MembersAndEmojis(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	asst := assert.New(t)
+
+	members, err := member.ListMembers(ctx, c, &membertypes.ListMembersInput{TeamName: "docs"})
+	assert.NoError(t, err)
+	assert.Len(t, members.Members, 2)
+	asst.True(members.Members[0].Myself)
+	asst.Equal("owner", members.Members[0].Role)
+
+	_, err = member.DeleteMember(ctx, c, &membertypes.DeleteMemberInput{TeamName: "docs", ScreenNameOrEmail: "bob"})
+	assert.NoError(t, err)
+
+	_, err = emoji.CreateEmoji(ctx, c, &emojitypes.CreateEmojiInput{TeamName: "docs", Code: "gopher", Image: gesa.String("aW1hZ2U=")})
+	assert.NoError(t, err)
+	_, err = emoji.CreateEmoji(ctx, c, &emojitypes.CreateEmojiInput{TeamName: "docs", Code: "go", OriginCode: gesa.String("gopher")})
+	assert.NoError(t, err)
+
+	emojis, err := emoji.ListEmojis(ctx, c, &emojitypes.ListEmojisInput{TeamName: "docs"})
+	assert.NoError(t, err)
+	assert.Len(t, emojis.Emojis, 1)
+	asst.Equal("gopher", emojis.Emojis[0].Code)
+	asst.Equal([]string{"go"}, emojis.Emojis[0].Aliases)
+
+	_, err = emoji.DeleteEmoji(ctx, c, &emojitypes.DeleteEmojiInput{TeamName: "docs", Code: "gopher"})
+	assert.NoError(t, err)
+
+	me, err := user.GetMe(ctx, c, &usertypes.GetMeInput{Include: "teams"})
+	assert.NoError(t, err)
+	asst.Equal("alice", me.ScreenName)
+	assert.Len(t, me.Teams, 1)
+	asst.Equal("docs", me.Teams[0].Name)
+}
+
+func Test_Server_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name         string
+		opts         []esatest.Option
+		token        string
+		requests     int
+		expectStatus int
+	}{
+		{
+			name:         "ng: unknown team",
+			token:        "alice-token",
+			requests:     1,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "ng: unknown token with strict auth",
+			opts:         []esatest.Option{esatest.WithStrictAuth()},
+			token:        "unknown-token",
+			requests:     1,
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "ng: rate limit exceeded",
+			opts:         []esatest.Option{esatest.WithRateLimit(2, time.Minute)},
+			token:        "alice-token",
+			requests:     3,
+			expectStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			s, _ := newTestServer(tt, c.opts...)
+			client, err := s.NewClient(c.token)
+			assert.NoError(tt, err)
+
+			for i := 0; i < c.requests; i++ {
+				_, err = post.ListPosts(ctx, client, &posttypes.ListPostsInput{TeamName: "unknown"})
+				if c.expectStatus == http.StatusTooManyRequests && i < c.requests-1 {
+					asst.Equal(http.StatusNotFound, apiError(err).StatusCode)
+				}
+			}
+
+			eae := apiError(err)
+			assert.NotNil(tt, eae)
+			asst.Equal(c.expectStatus, eae.StatusCode)
+			asst.Len(s.Requests(), c.requests)
+			if c.expectStatus == http.StatusTooManyRequests {
+				asst.NotNil(eae.RateLimitInfo)
+				asst.Equal(0, eae.RateLimitInfo.Remaining)
+			}
+		})
+	}
+}
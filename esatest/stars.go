@@ -0,0 +1,159 @@
+package esatest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+type starPayload struct {
+	Body string `json:"body"`
+}
+
+type listStargazersResponse struct {
+	Stargazers []stargazer `json:"stargazers"`
+	pagination
+}
+
+type listWatchersResponse struct {
+	Watchers []models.Watcher `json:"watchers"`
+	pagination
+}
+
+// stargazer is the JSON representation of models.Stargazer.
+// It is needed because models.Stargazer.User has no JSON tag.
+type stargazer struct {
+	CreatedAt *time.Time  `json:"created_at"`
+	Body      string      `json:"body"`
+	User      models.User `json:"user"`
+}
+
+func (s *Server) renderStars(ctx *requestContext, stars []*star) (int, any) {
+	start, end, pg, ok := paginate(ctx.r, len(stars))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	res := listStargazersResponse{Stargazers: []stargazer{}, pagination: pg}
+	for _, sg := range s.renderStargazers(stars[start:end], ctx.me.ScreenName) {
+		res.Stargazers = append(res.Stargazers, stargazer{CreatedAt: sg.CreatedAt, Body: sg.Body, User: sg.User})
+	}
+	return http.StatusOK, res
+}
+
+// addStar stars the target by the user. The star is replaced if the user has already starred it.
+func (s *Server) addStar(ctx *requestContext, stars []*star) ([]*star, bool) {
+	var in starPayload
+	if ctx.r.ContentLength != 0 && !ctx.decode(&in) {
+		return stars, false
+	}
+
+	stars = slices.DeleteFunc(stars, func(st *star) bool { return st.screenName == ctx.me.ScreenName })
+	return append(stars, &star{screenName: ctx.me.ScreenName, body: in.Body, createdAt: s.now()}), true
+}
+
+func removeStar(ctx *requestContext, stars []*star) []*star {
+	return slices.DeleteFunc(stars, func(st *star) bool { return st.screenName == ctx.me.ScreenName })
+}
+
+func (s *Server) listPostStargazers(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+	return s.renderStars(ctx, p.stars)
+}
+
+func (s *Server) createPostStar(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+	if p.stars, ok = s.addStar(ctx, p.stars); !ok {
+		return badRequest("Invalid JSON")
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) deletePostStar(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+	p.stars = removeStar(ctx, p.stars)
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) listCommentStargazers(ctx *requestContext) (int, any) {
+	c, ok := s.findComment(ctx)
+	if !ok {
+		return notFound()
+	}
+	return s.renderStars(ctx, c.stars)
+}
+
+func (s *Server) createCommentStar(ctx *requestContext) (int, any) {
+	c, ok := s.findComment(ctx)
+	if !ok {
+		return notFound()
+	}
+	if c.stars, ok = s.addStar(ctx, c.stars); !ok {
+		return badRequest("Invalid JSON")
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) deleteCommentStar(ctx *requestContext) (int, any) {
+	c, ok := s.findComment(ctx)
+	if !ok {
+		return notFound()
+	}
+	c.stars = removeStar(ctx, c.stars)
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) listWatchers(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	start, end, pg, ok := paginate(ctx.r, len(p.watchers))
+	if !ok {
+		return badRequest("Invalid pagination parameter")
+	}
+
+	res := listWatchersResponse{Watchers: []models.Watcher{}, pagination: pg}
+	for _, w := range p.watchers[start:end] {
+		createdAt := w.createdAt
+		res.Watchers = append(res.Watchers, models.Watcher{
+			CreatedAt: &createdAt,
+			User:      s.renderUser(w.screenName, ctx.me.ScreenName),
+		})
+	}
+	return http.StatusOK, res
+}
+
+func (s *Server) createWatch(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	if !slices.ContainsFunc(p.watchers, func(w *watcher) bool { return w.screenName == ctx.me.ScreenName }) {
+		p.watchers = append(p.watchers, &watcher{screenName: ctx.me.ScreenName, createdAt: s.now()})
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) deleteWatch(ctx *requestContext) (int, any) {
+	p, ok := s.findPost(ctx)
+	if !ok {
+		return notFound()
+	}
+
+	p.watchers = slices.DeleteFunc(p.watchers, func(w *watcher) bool { return w.screenName == ctx.me.ScreenName })
+	return http.StatusNoContent, nil
+}
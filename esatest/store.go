@@ -0,0 +1,412 @@
+package esatest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+)
+
+type team struct {
+	models.Team
+
+	members        []*models.Member
+	posts          map[int]*post
+	nextPostNumber int
+	comments       map[int]*comment
+	emojis         []*models.Emoji
+}
+
+type post struct {
+	models.Post
+
+	revisions []Revision
+	stars     []*star
+	watchers  []*watcher
+}
+
+// Revision is a revision of the body of a post kept by the server.
+type Revision struct {
+	Number     int
+	BodyMD     string
+	ScreenName string
+	CreatedAt  time.Time
+}
+
+type comment struct {
+	models.Comment
+
+	stars []*star
+}
+
+type star struct {
+	screenName string
+	body       string
+	createdAt  time.Time
+}
+
+type watcher struct {
+	screenName string
+	createdAt  time.Time
+}
+
+// AddTeam registers the team. The team is replaced if it already exists.
+func (s *Server) AddTeam(t models.Team) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tm := s.team(t.Name)
+	if t.URL == "" {
+		t.URL = tm.URL
+	}
+	if t.Privacy == "" {
+		t.Privacy = tm.Privacy
+	}
+	tm.Team = t
+}
+
+// AddMember registers the member of the team, creating the team if it does not exist.
+// The role of the member is "member" if it is empty.
+func (s *Server) AddMember(teamName string, m models.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.team(teamName)
+	u := s.user(m.ScreenName)
+	if m.Name != "" {
+		u.Name = m.Name
+	}
+	if m.Icon != "" {
+		u.Icon = m.Icon
+	}
+	m.Name = u.Name
+	m.Icon = u.Icon
+	if m.Role == "" {
+		m.Role = "member"
+	}
+	if m.JoinedAt == nil {
+		m.JoinedAt = s.timestamp()
+	}
+
+	t.members = slices.DeleteFunc(t.members, func(e *models.Member) bool {
+		return e.ScreenName == m.ScreenName
+	})
+	t.members = append(t.members, &m)
+}
+
+// AddPost stores the post to the team, creating the team if it does not exist,
+// and returns the stored post.
+// The number is assigned if it is 0, and the post is created by DefaultScreenName
+// if CreatedBy is empty.
+func (s *Server) AddPost(teamName string, p models.Post) models.Post {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.team(teamName)
+	if p.CreatedBy.ScreenName == "" {
+		p.CreatedBy.ScreenName = DefaultScreenName
+	}
+	if p.UpdatedBy.ScreenName == "" {
+		p.UpdatedBy.ScreenName = p.CreatedBy.ScreenName
+	}
+
+	return s.renderPost(t, s.insertPost(t, p), "", nil)
+}
+
+// AddComment stores the comment to the post and returns the stored comment.
+// The comment is created by DefaultScreenName if CreatedBy is empty.
+// It returns false if the team or the post does not exist.
+func (s *Server) AddComment(teamName string, postNumber int, c models.Comment) (models.Comment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamName]
+	if !ok {
+		return models.Comment{}, false
+	}
+	p, ok := t.posts[postNumber]
+	if !ok {
+		return models.Comment{}, false
+	}
+	if c.CreatedBy.ScreenName == "" {
+		c.CreatedBy.ScreenName = DefaultScreenName
+	}
+
+	return s.renderComment(s.insertComment(t, p, c), "", false), true
+}
+
+// AddEmoji stores the custom emoji to the team, creating the team if it does not exist.
+func (s *Server) AddEmoji(teamName string, e models.Emoji) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.team(teamName)
+	if e.Category == "" {
+		e.Category = "Custom"
+	}
+	t.emojis = append(t.emojis, &e)
+}
+
+// Post returns the post stored in the team.
+func (s *Server) Post(teamName string, number int) (models.Post, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamName]
+	if !ok {
+		return models.Post{}, false
+	}
+	p, ok := t.posts[number]
+	if !ok {
+		return models.Post{}, false
+	}
+	return s.renderPost(t, p, "", nil), true
+}
+
+// Revisions returns the revisions of the post in ascending order.
+func (s *Server) Revisions(teamName string, number int) []Revision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamName]
+	if !ok {
+		return nil
+	}
+	p, ok := t.posts[number]
+	if !ok {
+		return nil
+	}
+	return slices.Clone(p.revisions)
+}
+
+// Comment returns the comment stored in the team.
+func (s *Server) Comment(teamName string, id int) (models.Comment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamName]
+	if !ok {
+		return models.Comment{}, false
+	}
+	c, ok := t.comments[id]
+	if !ok {
+		return models.Comment{}, false
+	}
+	return s.renderComment(c, "", false), true
+}
+
+// team returns the team of the name, creating it if it does not exist.
+func (s *Server) team(name string) *team {
+	t, ok := s.teams[name]
+	if !ok {
+		t = &team{
+			Team: models.Team{
+				Name:    name,
+				Privacy: "closed",
+				URL:     fmt.Sprintf("https://%s.esa.io/", name),
+			},
+			posts:          map[int]*post{},
+			nextPostNumber: 1,
+			comments:       map[int]*comment{},
+		}
+		s.teams[name] = t
+		s.teamOrder = append(s.teamOrder, name)
+	}
+	return t
+}
+
+func (s *Server) timestamp() *time.Time {
+	now := s.now()
+	return &now
+}
+
+func (t *team) member(screenName string) *models.Member {
+	for _, m := range t.members {
+		if m.ScreenName == screenName {
+			return m
+		}
+	}
+	return nil
+}
+
+// isOwner reports whether the user is allowed to use the parameters only for owners.
+// Users who are not members of the team are regarded as owners to keep seeding easy.
+func (t *team) isOwner(screenName string) bool {
+	m := t.member(screenName)
+	return m == nil || m.Role == "owner"
+}
+
+func (s *Server) insertPost(t *team, mp models.Post) *post {
+	if mp.Number == 0 {
+		mp.Number = t.nextPostNumber
+	}
+	t.nextPostNumber = max(t.nextPostNumber, mp.Number+1)
+
+	mp.Category, mp.Name = splitName(mp.Category, mp.Name)
+	if mp.Tags == nil {
+		mp.Tags = []string{}
+	}
+	if mp.Kind == "" {
+		mp.Kind = "stock"
+	}
+	if mp.CreatedAt == nil {
+		mp.CreatedAt = s.timestamp()
+	}
+	if mp.UpdatedAt == nil {
+		mp.UpdatedAt = mp.CreatedAt
+	}
+	if mp.RevisionNumber == 0 {
+		mp.RevisionNumber = 1
+	}
+	mp.URL = fmt.Sprintf("%sposts/%d", t.URL, mp.Number)
+
+	p := &post{
+		Post: mp,
+		revisions: []Revision{{
+			Number:     mp.RevisionNumber,
+			BodyMD:     mp.BodyMD,
+			ScreenName: mp.UpdatedBy.ScreenName,
+			CreatedAt:  *mp.UpdatedAt,
+		}},
+		watchers: []*watcher{{screenName: mp.CreatedBy.ScreenName, createdAt: *mp.CreatedAt}},
+	}
+	t.posts[mp.Number] = p
+	return p
+}
+
+func (s *Server) insertComment(t *team, p *post, mc models.Comment) *comment {
+	mc.ID = s.nextCommentID
+	s.nextCommentID++
+	mc.PostNumber = p.Number
+	mc.URL = fmt.Sprintf("%s#comment-%d", p.URL, mc.ID)
+	if mc.CreatedAt == nil {
+		mc.CreatedAt = s.timestamp()
+	}
+	if mc.UpdatedAt == nil {
+		mc.UpdatedAt = mc.CreatedAt
+	}
+
+	c := &comment{Comment: mc}
+	t.comments[mc.ID] = c
+	return c
+}
+
+// updateBody stores the new body as the next revision of the post.
+func (s *Server) updateBody(p *post, body, screenName string) {
+	p.BodyMD = body
+	p.RevisionNumber++
+	p.revisions = append(p.revisions, Revision{
+		Number:     p.RevisionNumber,
+		BodyMD:     body,
+		ScreenName: screenName,
+		CreatedAt:  s.now(),
+	})
+}
+
+// postComments returns the comments of the post in ascending order of the ID.
+func (t *team) postComments(number int) []*comment {
+	cs := []*comment{}
+	for _, c := range t.comments {
+		if c.PostNumber == number {
+			cs = append(cs, c)
+		}
+	}
+	slices.SortFunc(cs, func(a, b *comment) int { return a.ID - b.ID })
+	return cs
+}
+
+func (s *Server) renderUser(screenName, me string) models.User {
+	u := *s.user(screenName)
+	u.Myself = screenName == me
+	return u
+}
+
+func (s *Server) renderStargazers(stars []*star, me string) []models.Stargazer {
+	sgs := make([]models.Stargazer, 0, len(stars))
+	for _, st := range stars {
+		createdAt := st.createdAt
+		sgs = append(sgs, models.Stargazer{
+			CreatedAt: &createdAt,
+			Body:      st.body,
+			User:      s.renderUser(st.screenName, me),
+		})
+	}
+	return sgs
+}
+
+// renderPost returns the post as seen by the user.
+// include is the value of the include query parameter.
+func (s *Server) renderPost(t *team, p *post, me string, include []string) models.Post {
+	mp := p.Post
+	mp.Tags = slices.Clone(p.Tags)
+	mp.FullName = fullName(p.Category, p.Name, p.Tags)
+	mp.CreatedBy = s.renderUser(p.CreatedBy.ScreenName, me)
+	mp.UpdatedBy = s.renderUser(p.UpdatedBy.ScreenName, me)
+	mp.DoneTasksCount = strings.Count(p.BodyMD, "- [x]")
+	mp.StargazersCount = len(p.stars)
+	mp.WatchersCount = len(p.watchers)
+	mp.Star = slices.ContainsFunc(p.stars, func(st *star) bool { return st.screenName == me })
+	mp.Watch = slices.ContainsFunc(p.watchers, func(w *watcher) bool { return w.screenName == me })
+
+	cs := t.postComments(p.Number)
+	mp.CommentCount = len(cs)
+	mp.Comments = nil
+	if slices.Contains(include, "comments") || slices.Contains(include, "comments.stargazers") {
+		withStargazers := slices.Contains(include, "comments.stargazers")
+		mp.Comments = make([]models.Comment, 0, len(cs))
+		for _, c := range cs {
+			mp.Comments = append(mp.Comments, s.renderComment(c, me, withStargazers))
+		}
+	}
+	mp.Stargazers = nil
+	if slices.Contains(include, "stargazers") {
+		mp.Stargazers = s.renderStargazers(p.stars, me)
+	}
+
+	return mp
+}
+
+func (s *Server) renderComment(c *comment, me string, withStargazers bool) models.Comment {
+	mc := c.Comment
+	mc.CreatedBy = s.renderUser(c.CreatedBy.ScreenName, me)
+	mc.StargazersCount = len(c.stars)
+	mc.Star = slices.ContainsFunc(c.stars, func(st *star) bool { return st.screenName == me })
+	mc.Stargazers = nil
+	if withStargazers {
+		mc.Stargazers = s.renderStargazers(c.stars, me)
+	}
+	return mc
+}
+
+// splitName splits the name such as "a/b/name" into the category and the name.
+// The category is used as is if the name has no category part.
+func splitName(category, name string) (string, string) {
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return normalizeCategory(category), name
+	}
+	return normalizeCategory(name[:i]), name[i+1:]
+}
+
+func normalizeCategory(category string) string {
+	parts := []string{}
+	for _, p := range strings.Split(category, "/") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func fullName(category, name string, tags []string) string {
+	fn := name
+	if category != "" {
+		fn = category + "/" + name
+	}
+	for _, tag := range tags {
+		fn += " #" + tag
+	}
+	return fn
+}
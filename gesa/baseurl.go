@@ -0,0 +1,52 @@
+package gesa
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the base URL of the esa API.
+// All endpoints defined in the esaapi packages start with this value.
+const DefaultBaseURL string = "https://api.esa.io"
+
+// normalizeBaseURL validates the base URL and trims its trailing slash.
+// If an empty string is passed, DefaultBaseURL is returned.
+func normalizeBaseURL(base string) (string, error) {
+	if base == "" {
+		return DefaultBaseURL, nil
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.New("base URL scheme must be http or https")
+	}
+
+	if u.Host == "" {
+		return "", errors.New("base URL host is empty")
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", errors.New("base URL must not have query or fragment")
+	}
+
+	return strings.TrimRight(base, "/"), nil
+}
+
+// replaceBaseURL replaces DefaultBaseURL at the beginning of the endpoint with the base.
+// Endpoints that do not start with DefaultBaseURL are returned as is.
+func replaceBaseURL(endpoint, base string) string {
+	if base == "" || base == DefaultBaseURL {
+		return endpoint
+	}
+
+	if !strings.HasPrefix(endpoint, DefaultBaseURL) {
+		return endpoint
+	}
+
+	return base + strings.TrimPrefix(endpoint, DefaultBaseURL)
+}
@@ -0,0 +1,170 @@
+package gesa_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_normalizeBaseURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		base    string
+		wantErr bool
+		expect  string
+	}{
+		{
+			name:   "ok: empty",
+			base:   "",
+			expect: gesa.DefaultBaseURL,
+		},
+		{
+			name:   "ok: http",
+			base:   "http://localhost:8080",
+			expect: "http://localhost:8080",
+		},
+		{
+			name:   "ok: trailing slash",
+			base:   "https://proxy.example.com/esa/",
+			expect: "https://proxy.example.com/esa",
+		},
+		{
+			name:    "ng: invalid scheme",
+			base:    "ftp://localhost",
+			wantErr: true,
+		},
+		{
+			name:    "ng: no scheme",
+			base:    "localhost:8080",
+			wantErr: true,
+		},
+		{
+			name:    "ng: empty host",
+			base:    "http://",
+			wantErr: true,
+		},
+		{
+			name:    "ng: with query",
+			base:    "http://localhost?a=b",
+			wantErr: true,
+		},
+		{
+			name:    "ng: parse error",
+			base:    "http://local host",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			b, err := gesa.ExportNormalizeBaseURL(c.base)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Empty(b)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, b)
+		})
+	}
+}
+
+func Test_replaceBaseURL(t *testing.T) {
+	cases := []struct {
+		name     string
+		endpoint string
+		base     string
+		expect   string
+	}{
+		{
+			name:     "ok",
+			endpoint: "https://api.esa.io/:esa_api_version/teams",
+			base:     "http://localhost:8080",
+			expect:   "http://localhost:8080/:esa_api_version/teams",
+		},
+		{
+			name:     "ok: with path prefix",
+			endpoint: "https://api.esa.io/oauth/token/info",
+			base:     "https://proxy.example.com/esa",
+			expect:   "https://proxy.example.com/esa/oauth/token/info",
+		},
+		{
+			name:     "ok: default base url",
+			endpoint: "https://api.esa.io/:esa_api_version/teams",
+			base:     gesa.DefaultBaseURL,
+			expect:   "https://api.esa.io/:esa_api_version/teams",
+		},
+		{
+			name:     "ok: empty base url",
+			endpoint: "https://api.esa.io/:esa_api_version/teams",
+			base:     "",
+			expect:   "https://api.esa.io/:esa_api_version/teams",
+		},
+		{
+			name:     "ok: other endpoint",
+			endpoint: "https://example.com/teams",
+			base:     "http://localhost:8080",
+			expect:   "https://example.com/teams",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			e := gesa.ExportReplaceBaseURL(c.endpoint, c.base)
+			asst.Equal(c.expect, e)
+		})
+	}
+}
+
+func Test_CallAPI_BaseURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		baseURL string
+		expect  string
+	}{
+		{
+			name:    "default",
+			baseURL: "",
+			expect:  "https://api.esa.io/v1/teams",
+		},
+		{
+			name:    "custom",
+			baseURL: "http://127.0.0.1:8080/proxy/",
+			expect:  "http://127.0.0.1:8080/proxy/v1/teams",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			var called string
+			hc := newMockClient(func(req *http.Request) *http.Response {
+				called = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			})
+
+			client, err := gesa.NewClient(&gesa.NewClientInput{
+				HTTPClient:  hc,
+				AccessToken: "test-token",
+				BaseURL:     c.baseURL,
+			})
+			asst.NoError(err)
+
+			err = client.CallAPI(context.Background(), "https://api.esa.io/:esa_api_version/teams", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+			asst.Nil(err)
+			asst.Equal(c.expect, called)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package gesa
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheCapacity is the default number of responses kept by the LRU cache store.
+const DefaultCacheCapacity = 256
+
+// CacheEntry is a response stored in CacheStore.
+type CacheEntry struct {
+	Header http.Header
+	Body   []byte
+	// ExpiresAt is the time until when the response is used without sending a request.
+	// After that, the response is revalidated with its ETag or Last-Modified header.
+	ExpiresAt time.Time
+}
+
+// CacheStore stores responses of GET requests.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, e *CacheEntry)
+	// DeletePrefix deletes the entries whose keys start with the prefix.
+	DeletePrefix(prefix string)
+}
+
+// LRUCacheStore is an in-memory CacheStore that evicts the least recently used entry.
+type LRUCacheStore struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCacheStore generates *LRUCacheStore.
+// If the capacity is 0 or less, DefaultCacheCapacity is used.
+func NewLRUCacheStore(capacity int) *LRUCacheStore {
+	if capacity <= 0 {
+		capacity = DefaultCacheCapacity
+	}
+	return &LRUCacheStore{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+func (s *LRUCacheStore) Get(key string) (*CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (s *LRUCacheStore) Set(key string, e *CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*lruItem).entry = e
+		s.order.MoveToFront(el)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(&lruItem{key: key, entry: e})
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+func (s *LRUCacheStore) DeletePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, el := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			s.order.Remove(el)
+			delete(s.entries, key)
+		}
+	}
+}
+
+// Len returns the number of entries.
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+type NewResponseCacheInput struct {
+	// Store stores responses (default: NewLRUCacheStore(DefaultCacheCapacity)).
+	Store CacheStore
+
+	// DefaultTTL is the duration a response is used without sending a request.
+	// If it is 0, responses are always revalidated and stored only if they have validators.
+	DefaultTTL time.Duration
+
+	// TTLs overrides DefaultTTL per operation name such as "post.GetPost".
+	TTLs map[string]time.Duration
+}
+
+// ResponseCache caches responses of GET requests.
+// Fresh responses are returned without sending a request, and stale responses are
+// revalidated by conditional requests with If-None-Match and If-Modified-Since.
+// A successful request other than GET invalidates the cached responses of the same team.
+type ResponseCache struct {
+	store      CacheStore
+	defaultTTL time.Duration
+	ttls       map[string]time.Duration
+	now        func() time.Time
+}
+
+// NewResponseCache generates *ResponseCache.
+func NewResponseCache(in *NewResponseCacheInput) *ResponseCache {
+	if in == nil {
+		in = &NewResponseCacheInput{}
+	}
+
+	rc := &ResponseCache{
+		store:      in.Store,
+		defaultTTL: in.DefaultTTL,
+		ttls:       map[string]time.Duration{},
+		now:        time.Now,
+	}
+	if rc.store == nil {
+		rc.store = NewLRUCacheStore(DefaultCacheCapacity)
+	}
+	for k, v := range in.TTLs {
+		rc.ttls[k] = v
+	}
+	return rc
+}
+
+func (rc *ResponseCache) ttl(req *http.Request) time.Duration {
+	if ttl, ok := rc.ttls[OperationName(req.Context())]; ok {
+		return ttl
+	}
+	return rc.defaultTTL
+}
+
+// cacheKey returns the key of the request. The access token is hashed into the key
+// because responses such as "star" depend on the user.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + " " + hex.EncodeToString(sum[:8])
+}
+
+// invalidationPrefix returns the key prefix of the responses invalidated by the request,
+// which is the URL of the team such as "https://api.esa.io/v1/teams/docs/".
+func invalidationPrefix(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery = ""
+	u.Fragment = ""
+	s := u.String()
+
+	if _, rest, ok := strings.Cut(s, "/teams/"); ok {
+		team, _, _ := strings.Cut(rest, "/")
+		return s[:len(s)-len(rest)] + team + "/"
+	}
+	return s
+}
+
+// lookup returns the cached response if it is fresh.
+// Otherwise it adds the validators of the stale response to the request.
+func (rc *ResponseCache) lookup(req *http.Request) (*http.Response, []byte, bool) {
+	if rc == nil || req.Method != http.MethodGet {
+		return nil, nil, false
+	}
+
+	e, ok := rc.store.Get(cacheKey(req))
+	if !ok {
+		return nil, nil, false
+	}
+
+	if rc.now().Before(e.ExpiresAt) {
+		h := e.Header.Clone()
+		// No request is sent, so the rate limit information is unknown.
+		for _, k := range []string{RATE_LIMIT_LIMIT_HEADER_KEY, RATE_LIMIT_REMAINING_HEADER_KEY, RATE_LIMIT_RESET_HEADER_KEY} {
+			h.Del(k)
+		}
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       io.NopCloser(bytes.NewReader(e.Body)),
+			Request:    req,
+		}, e.Body, true
+	}
+
+	if etag := e.Header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lm := e.Header.Get("Last-Modified"); lm != "" {
+		req.Header.Set("If-Modified-Since", lm)
+	}
+	return nil, nil, false
+}
+
+// isConditional reports whether the request has the validators of a cached response.
+func isConditional(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
+
+// withoutValidators returns a copy of the request without the validators.
+func withoutValidators(req *http.Request) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Del("If-None-Match")
+	r.Header.Del("If-Modified-Since")
+	return r
+}
+
+// update stores the response, or replaces 304 Not Modified with the cached response.
+// 304 Not Modified is returned as is if the cached response has been evicted.
+func (rc *ResponseCache) update(req *http.Request, res *http.Response, body []byte) (*http.Response, []byte) {
+	if rc == nil {
+		return res, body
+	}
+
+	if req.Method != http.MethodGet {
+		if res.StatusCode >= 200 && res.StatusCode < 300 {
+			rc.store.DeletePrefix(invalidationPrefix(req))
+		}
+		return res, body
+	}
+
+	key := cacheKey(req)
+	switch res.StatusCode {
+	case http.StatusNotModified:
+		e, ok := rc.store.Get(key)
+		if !ok {
+			return res, body
+		}
+
+		h := e.Header.Clone()
+		for k, v := range res.Header {
+			h[k] = v
+		}
+		rc.store.Set(key, &CacheEntry{Header: h, Body: e.Body, ExpiresAt: rc.now().Add(rc.ttl(req))})
+
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       io.NopCloser(bytes.NewReader(e.Body)),
+			Request:    req,
+		}, e.Body
+
+	case http.StatusOK:
+		if strings.Contains(res.Header.Get("Cache-Control"), "no-store") {
+			return res, body
+		}
+		ttl := rc.ttl(req)
+		if ttl <= 0 && res.Header.Get("ETag") == "" && res.Header.Get("Last-Modified") == "" {
+			return res, body
+		}
+		rc.store.Set(key, &CacheEntry{Header: res.Header.Clone(), Body: body, ExpiresAt: rc.now().Add(ttl)})
+	}
+
+	return res, body
+}
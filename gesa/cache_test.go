@@ -0,0 +1,346 @@
+package gesa_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+type cachedOutput struct {
+	Message   string `json:"message"`
+	Remaining string
+}
+
+func (r *cachedOutput) SetRateLimitInfo(h http.Header) {
+	r.Remaining = h.Get("X-Ratelimit-Remaining")
+}
+
+func Test_LRUCacheStore(t *testing.T) {
+	asst := assert.New(t)
+	s := gesa.NewLRUCacheStore(2)
+
+	s.Set("https://api.esa.io/v1/teams/a/posts/1", &gesa.CacheEntry{Body: []byte("1")})
+	s.Set("https://api.esa.io/v1/teams/a/posts/2", &gesa.CacheEntry{Body: []byte("2")})
+
+	// "1" becomes the most recently used, so "2" is evicted.
+	_, ok := s.Get("https://api.esa.io/v1/teams/a/posts/1")
+	asst.True(ok)
+	s.Set("https://api.esa.io/v1/teams/b/posts/3", &gesa.CacheEntry{Body: []byte("3")})
+	asst.Equal(2, s.Len())
+	_, ok = s.Get("https://api.esa.io/v1/teams/a/posts/2")
+	asst.False(ok)
+
+	s.Set("https://api.esa.io/v1/teams/b/posts/3", &gesa.CacheEntry{Body: []byte("3'")})
+	e, ok := s.Get("https://api.esa.io/v1/teams/b/posts/3")
+	asst.True(ok)
+	asst.Equal([]byte("3'"), e.Body)
+
+	s.DeletePrefix("https://api.esa.io/v1/teams/a/")
+	asst.Equal(1, s.Len())
+	_, ok = s.Get("https://api.esa.io/v1/teams/a/posts/1")
+	asst.False(ok)
+}
+
+func Test_Client_Cache(t *testing.T) {
+	rateLimitHeader := func(remaining string) http.Header {
+		return http.Header{
+			"X-Ratelimit-Limit":     {"75"},
+			"X-Ratelimit-Remaining": {remaining},
+			"X-Ratelimit-Reset":     {"1700000000"},
+		}
+	}
+
+	type step struct {
+		method string
+		path   string
+		// advance advances the clock before the request.
+		advance time.Duration
+		// response is returned by the server. nil means no request is expected.
+		response      *http.Response
+		expectMessage string
+		expectHeader  http.Header
+		// expectRemaining is the rate limit information passed to the output.
+		expectRemaining string
+	}
+
+	okResponse := func(msg string, h http.Header) *http.Response {
+		if h == nil {
+			h = http.Header{}
+		}
+		h.Set("Content-Type", "application/json")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       io.NopCloser(strings.NewReader(`{"message":"` + msg + `"}`)),
+		}
+	}
+
+	withETag := func(h http.Header, etag string) http.Header {
+		h.Set("ETag", etag)
+		return h
+	}
+
+	cases := []struct {
+		name  string
+		in    *gesa.NewResponseCacheInput
+		steps []step
+	}{
+		{
+			name: "ok: fresh response is returned without request",
+			in:   &gesa.NewResponseCacheInput{DefaultTTL: time.Minute},
+			steps: []step{
+				{path: "/v1/teams/docs/stats", response: okResponse("first", rateLimitHeader("74")), expectMessage: "first", expectRemaining: "74"},
+				{path: "/v1/teams/docs/stats", advance: 30 * time.Second, expectMessage: "first"},
+				{path: "/v1/teams/docs/stats", advance: 31 * time.Second, response: okResponse("second", nil), expectMessage: "second"},
+			},
+		},
+		{
+			name: "ok: ttl per operation",
+			in: &gesa.NewResponseCacheInput{
+				DefaultTTL: time.Hour,
+				TTLs:       map[string]time.Duration{"test.Get": 0},
+			},
+			steps: []step{
+				{path: "/v1/teams/docs/stats", response: okResponse("first", nil), expectMessage: "first"},
+				{path: "/v1/teams/docs/stats", response: okResponse("second", nil), expectMessage: "second"},
+			},
+		},
+		{
+			name: "ok: stale response is revalidated",
+			in:   &gesa.NewResponseCacheInput{},
+			steps: []step{
+				{
+					path:            "/v1/teams/docs/posts/1",
+					response:        okResponse("first", withETag(rateLimitHeader("74"), `W/"abc"`)),
+					expectMessage:   "first",
+					expectRemaining: "74",
+				},
+				{
+					path: "/v1/teams/docs/posts/1",
+					response: &http.Response{
+						StatusCode: http.StatusNotModified,
+						Header:     rateLimitHeader("73"),
+						Body:       http.NoBody,
+					},
+					expectHeader:    http.Header{"If-None-Match": {`W/"abc"`}},
+					expectMessage:   "first",
+					expectRemaining: "73",
+				},
+				{
+					path:          "/v1/teams/docs/posts/1",
+					response:      okResponse("second", withETag(http.Header{}, `W/"def"`)),
+					expectHeader:  http.Header{"If-None-Match": {`W/"abc"`}},
+					expectMessage: "second",
+				},
+				{
+					path:          "/v1/teams/docs/posts/1",
+					response:      okResponse("third", nil),
+					expectHeader:  http.Header{"If-None-Match": {`W/"def"`}},
+					expectMessage: "third",
+				},
+			},
+		},
+		{
+			name: "ok: last modified",
+			in:   &gesa.NewResponseCacheInput{},
+			steps: []step{
+				{
+					path:          "/v1/teams/docs/tags",
+					response:      okResponse("first", http.Header{"Last-Modified": {"Mon, 01 Jan 2024 00:00:00 GMT"}}),
+					expectMessage: "first",
+				},
+				{
+					path:          "/v1/teams/docs/tags",
+					response:      &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody},
+					expectHeader:  http.Header{"If-Modified-Since": {"Mon, 01 Jan 2024 00:00:00 GMT"}},
+					expectMessage: "first",
+				},
+			},
+		},
+		{
+			name: "ok: no-store",
+			in:   &gesa.NewResponseCacheInput{DefaultTTL: time.Minute},
+			steps: []step{
+				{path: "/v1/teams/docs/stats", response: okResponse("first", http.Header{"Cache-Control": {"no-store"}}), expectMessage: "first"},
+				{path: "/v1/teams/docs/stats", response: okResponse("second", nil), expectMessage: "second"},
+			},
+		},
+		{
+			name: "ok: query is a part of the key",
+			in:   &gesa.NewResponseCacheInput{DefaultTTL: time.Minute},
+			steps: []step{
+				{path: "/v1/teams/docs/posts?page=1", response: okResponse("page1", nil), expectMessage: "page1"},
+				{path: "/v1/teams/docs/posts?page=2", response: okResponse("page2", nil), expectMessage: "page2"},
+				{path: "/v1/teams/docs/posts?page=1", expectMessage: "page1"},
+			},
+		},
+		{
+			name: "ok: mutation invalidates the team",
+			in:   &gesa.NewResponseCacheInput{DefaultTTL: time.Minute},
+			steps: []step{
+				{path: "/v1/teams/docs/posts/1", response: okResponse("docs", nil), expectMessage: "docs"},
+				{path: "/v1/teams/other/posts/1", response: okResponse("other", nil), expectMessage: "other"},
+				{method: http.MethodPatch, path: "/v1/teams/docs/posts/1", response: okResponse("updated", nil), expectMessage: "updated"},
+				{path: "/v1/teams/docs/posts/1", response: okResponse("docs2", nil), expectMessage: "docs2"},
+				{path: "/v1/teams/other/posts/1", expectMessage: "other"},
+			},
+		},
+		{
+			name: "ok: failed mutation does not invalidate",
+			in:   &gesa.NewResponseCacheInput{DefaultTTL: time.Minute},
+			steps: []step{
+				{path: "/v1/teams/docs/posts/1", response: okResponse("docs", nil), expectMessage: "docs"},
+				{
+					method: http.MethodPatch,
+					path:   "/v1/teams/docs/posts/1",
+					response: &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Header:     http.Header{"Content-Type": {"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"error":"bad_request","message":"bad"}`)),
+					},
+				},
+				{path: "/v1/teams/docs/posts/1", expectMessage: "docs"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			rc := gesa.NewResponseCache(c.in)
+			gesa.ExportSetResponseCacheNow(rc, func() time.Time { return now })
+
+			var (
+				response *http.Response
+				reqs     []*http.Request
+			)
+			client, err := gesa.NewClient(&gesa.NewClientInput{
+				HTTPClient: newMockClient(func(req *http.Request) *http.Response {
+					reqs = append(reqs, req)
+					return response
+				}),
+				AccessToken: "token",
+				Cache:       rc,
+			})
+			asst.NoError(err)
+
+			for i, s := range c.steps {
+				now = now.Add(s.advance)
+				response = s.response
+				reqs = nil
+
+				method := s.method
+				if method == "" {
+					method = http.MethodGet
+				}
+				req, err := http.NewRequestWithContext(
+					gesa.WithOperationName(context.Background(), "test.Get"),
+					method, "https://api.esa.io"+s.path, nil)
+				asst.NoError(err)
+				req.Header.Set("Authorization", "Bearer token")
+
+				out := &cachedOutput{}
+				_, err = client.Exec(req, out)
+				asst.NoError(err, "step %d", i)
+
+				if s.response == nil {
+					asst.Empty(reqs, "step %d", i)
+				} else if asst.Len(reqs, 1, "step %d", i) {
+					for k := range s.expectHeader {
+						asst.Equal(s.expectHeader.Get(k), reqs[0].Header.Get(k), "step %d", i)
+					}
+					if s.expectHeader == nil {
+						asst.Empty(reqs[0].Header.Get("If-None-Match"), "step %d", i)
+						asst.Empty(reqs[0].Header.Get("If-Modified-Since"), "step %d", i)
+					}
+				}
+				asst.Equal(s.expectMessage, out.Message, "step %d", i)
+				asst.Equal(s.expectRemaining, out.Remaining, "step %d", i)
+			}
+		})
+	}
+}
+
+func Test_Client_Cache_Token(t *testing.T) {
+	asst := assert.New(t)
+
+	count := 0
+	rc := gesa.NewResponseCache(&gesa.NewResponseCacheInput{DefaultTTL: time.Minute})
+	for _, token := range []string{"alice", "bob", "alice"} {
+		client, err := gesa.NewClient(&gesa.NewClientInput{
+			HTTPClient: newMockClient(func(req *http.Request) *http.Response {
+				count++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"message":"` + token + `"}`)),
+				}
+			}),
+			AccessToken: token,
+			Cache:       rc,
+		})
+		asst.NoError(err)
+
+		out := &cachedOutput{}
+		err = client.CallAPI(context.Background(), "https://api.esa.io/v1/user", http.MethodGet, &mockAPIParameter{}, out)
+		asst.NoError(err)
+		asst.Equal(token, out.Message)
+	}
+	asst.Equal(2, count)
+}
+
+// evictingStore evicts an entry when it is got, as if it were evicted by other requests.
+type evictingStore struct {
+	*gesa.LRUCacheStore
+}
+
+func (s evictingStore) Get(key string) (*gesa.CacheEntry, bool) {
+	e, ok := s.LRUCacheStore.Get(key)
+	if ok {
+		s.DeletePrefix(key)
+	}
+	return e, ok
+}
+
+func Test_Client_Cache_Evicted(t *testing.T) {
+	asst := assert.New(t)
+
+	reqs := []*http.Request{}
+	client, err := gesa.NewClient(&gesa.NewClientInput{
+		HTTPClient: newMockClient(func(req *http.Request) *http.Response {
+			reqs = append(reqs, req)
+			if req.Header.Get("If-None-Match") == `"abc"` {
+				return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"abc"`}},
+				Body:       io.NopCloser(strings.NewReader(`{"message":"ok"}`)),
+			}
+		}),
+		AccessToken: "token",
+		Cache:       gesa.NewResponseCache(&gesa.NewResponseCacheInput{Store: evictingStore{gesa.NewLRUCacheStore(10)}}),
+	})
+	asst.NoError(err)
+
+	for i := 0; i < 2; i++ {
+		out := &cachedOutput{}
+		err := client.CallAPI(context.Background(), "https://api.esa.io/v1/user", http.MethodGet, &mockAPIParameter{}, out)
+		asst.NoError(err)
+		asst.Equal("ok", out.Message)
+	}
+
+	// 304 Not Modified without the cached response is sent again without the validators
+	if asst.Len(reqs, 3) {
+		asst.Equal(`"abc"`, reqs[1].Header.Get("If-None-Match"))
+		asst.Empty(reqs[2].Header.Get("If-None-Match"))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -18,18 +19,84 @@ type NewClientInput struct {
 	HTTPClient  *http.Client
 	AccessToken string
 	APIVersion  EsaAPIVersion
-	Debug       bool
+
+	// Debug writes requests and responses to stderr at the debug level if Logger is nil.
+	Debug bool
+
+	// Logger receives requests and responses at the debug level.
+	// Sensitive headers such as Authorization and the tokens and secrets in the JSON bodies are redacted.
+	Logger *slog.Logger
+
+	// LogBodyLimit is the maximum number of bytes of a body written to the log (default: DefaultLogBodyLimit).
+	// If it is negative, bodies are not written.
+	LogBodyLimit int
+
+	// BeforeRequestHooks are called in order before each request is sent.
+	BeforeRequestHooks []BeforeRequestHook
+
+	// AfterResponseHooks are called in order after each response is received.
+	AfterResponseHooks []AfterResponseHook
+
+	// BaseURL overrides the base URL of the esa API (default: https://api.esa.io).
+	// It is useful to send requests to a local stub server or a proxy.
+	BaseURL string
+
+	// RetryPolicy decides whether a failed request is retried.
+	// If it is nil, requests are never retried.
+	RetryPolicy RetryPolicy
+
+	// RateLimiter blocks requests while the remaining number of requests is
+	// less than or equal to its floor. If it is nil, requests are never blocked.
+	RateLimiter *RateLimiter
+
+	// TokenSource provides the access token for each request.
+	// It is used instead of AccessToken to rotate the token without rebuilding the client.
+	// Either AccessToken or TokenSource is required, and TokenSource takes precedence.
+	TokenSource TokenSource
+
+	// Instrumentation observes each call of CallAPI to record traces or metrics.
+	// The gesaotel module provides the implementation with OpenTelemetry.
+	Instrumentation Instrumentation
+
+	// Cache caches responses of GET requests. If it is nil, responses are never cached.
+	Cache *ResponseCache
+
+	// DryRun records the POST, PATCH, PUT and DELETE requests instead of sending them.
+	// If it is nil, all requests are sent.
+	DryRun *DryRun
 }
 
 type IClient interface {
 	Exec(req *http.Request, r internal.IOutput) error
 }
 
+// IInput is the interface of the inputs of the esa API, such as *types.GetPostInput.
+type IInput = internal.IInput
+
+// IOutput is the interface of the outputs of the esa API, such as *types.GetPostOutput.
+type IOutput = internal.IOutput
+
+// IAPIClient is the interface of the client that the functions of the esaapi packages call.
+// *Client implements it, and it can be replaced with a fake such as gesamock.Client in tests.
+type IAPIClient interface {
+	CallAPI(ctx context.Context, endpoint, method string, p IInput, r IOutput) error
+}
+
+var _ IAPIClient = (*Client)(nil)
+
 type Client struct {
-	client      *http.Client
-	accessToken string
-	apiVersion  EsaAPIVersion
-	debug       bool
+	client             *http.Client
+	tokenSource        TokenSource
+	apiVersion         EsaAPIVersion
+	baseURL            string
+	retryPolicy        RetryPolicy
+	rateLimiter        *RateLimiter
+	logger             *requestLogger
+	beforeRequestHooks []BeforeRequestHook
+	afterResponseHooks []AfterResponseHook
+	instrumentation    Instrumentation
+	cache              *ResponseCache
+	dryRun             *DryRun
 }
 
 type ClientResponse struct {
@@ -47,10 +114,28 @@ func NewClient(in *NewClientInput) (*Client, error) {
 		return nil, fmt.Errorf("NewClientInput is nil.")
 	}
 
-	if in.AccessToken == "" {
+	if in.AccessToken == "" && in.TokenSource == nil {
 		return nil, fmt.Errorf("AccessToken is empty.")
 	}
 
+	return newClient(in)
+}
+
+// NewUnauthenticatedClient generates *Client that sends requests without the Authorization header.
+// It is used to call APIs that do not require an access token, such as the OAuth token API.
+// NewClientInput.AccessToken and NewClientInput.TokenSource are ignored.
+func NewUnauthenticatedClient(in *NewClientInput) (*Client, error) {
+	if in == nil {
+		return nil, fmt.Errorf("NewClientInput is nil.")
+	}
+
+	ui := *in
+	ui.AccessToken = ""
+	ui.TokenSource = nil
+	return newClient(&ui)
+}
+
+func newClient(in *NewClientInput) (*Client, error) {
 	apiVersion := in.APIVersion
 	if apiVersion.IsEmpty() {
 		apiVersion = DefaultAPIVersion
@@ -58,14 +143,28 @@ func NewClient(in *NewClientInput) (*Client, error) {
 		return nil, fmt.Errorf("Invalid esa API version.")
 	}
 
+	baseURL, err := normalizeBaseURL(in.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid base URL. : %w", err)
+	}
+
 	c := Client{
-		client:      defaultHTTPClient,
-		accessToken: in.AccessToken,
-		apiVersion:  apiVersion,
+		client:             defaultHTTPClient,
+		tokenSource:        in.TokenSource,
+		apiVersion:         apiVersion,
+		baseURL:            baseURL,
+		retryPolicy:        in.RetryPolicy,
+		rateLimiter:        in.RateLimiter,
+		logger:             newRequestLogger(in),
+		beforeRequestHooks: in.BeforeRequestHooks,
+		afterResponseHooks: in.AfterResponseHooks,
+		instrumentation:    in.Instrumentation,
+		cache:              in.Cache,
+		dryRun:             in.DryRun,
 	}
 
-	if in.Debug {
-		c.debug = true
+	if c.tokenSource == nil && in.AccessToken != "" {
+		c.tokenSource = StaticTokenSource(in.AccessToken)
 	}
 
 	if in.HTTPClient != nil {
@@ -75,26 +174,67 @@ func NewClient(in *NewClientInput) (*Client, error) {
 	return &c, nil
 }
 
+// AccessToken returns the current access token provided by the token source.
+// It returns an empty string if the token cannot be provided.
 func (c *Client) AccessToken() string {
+	if c == nil || c.tokenSource == nil {
+		return ""
+	}
+
+	token, err := c.tokenSource.Token(context.Background())
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+func (c *Client) BaseURL() string {
 	if c == nil {
 		return ""
 	}
-	return c.accessToken
+	return c.baseURL
 }
 
 func (c *Client) CallAPI(ctx context.Context, endpoint, method string, p internal.IInput, r internal.IOutput) error {
-	req, err := c.prepare(ctx, endpoint, method, p)
-	if err != nil {
-		return wrapErr(err)
-	}
+	return c.instrument(ctx, endpoint, method, p, func(ctx context.Context) error {
+		return c.callAPI(ctx, endpoint, method, p, r)
+	})
+}
+
+func (c *Client) callAPI(ctx context.Context, endpoint, method string, p internal.IInput, r internal.IOutput) error {
+	for attempt := 1; ; attempt++ {
+		// The request is prepared for each attempt because its body can be read only once.
+		req, err := c.prepare(ctx, endpoint, method, p)
+		if err != nil {
+			return wrapErr(err)
+		}
+
+		if ok, err := c.dryRun.intercept(req, r, c.logger); ok {
+			if err != nil {
+				return wrapErr(err)
+			}
+			return nil
+		}
 
-	if n2xe, err := c.Exec(req, r); err != nil {
-		return wrapErr(err)
-	} else if n2xe != nil {
+		n2xe, err := c.Exec(req, r)
+		if err == nil && n2xe == nil {
+			return nil
+		}
+
+		if c.retryPolicy != nil {
+			if d, retry := c.retryPolicy.RetryDelay(attempt, method, n2xe, err); retry {
+				if werr := wait(ctx, d); werr != nil {
+					return wrapErr(werr)
+				}
+				continue
+			}
+		}
+
+		if err != nil {
+			return wrapErr(err)
+		}
 		return wrapWithAPIErr(n2xe)
 	}
-
-	return nil
 }
 
 var okCodes map[int]struct{} = map[int]struct{}{
@@ -104,12 +244,76 @@ var okCodes map[int]struct{} = map[int]struct{}{
 }
 
 func (c *Client) Exec(req *http.Request, r internal.IOutput) (*EsaAPIError, error) {
-	res, err := c.client.Do(req)
+	for _, h := range c.beforeRequestHooks {
+		hreq, err := h.BeforeRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		if hreq == nil {
+			return nil, errors.New("BeforeRequestHook returned nil request")
+		}
+		req = hreq
+	}
+
+	if res, body, ok := c.cache.lookup(req); ok {
+		return c.handleResponse(res, body, r)
+	}
+
+	res, body, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	res, body = c.cache.update(req, res, body)
+
+	if c.cache != nil && res.StatusCode == http.StatusNotModified && isConditional(req) {
+		// The cached response was evicted after the validators were added to the request,
+		// so the request is sent again without them.
+		req = withoutValidators(req)
+		if res, body, err = c.send(req); err != nil {
+			return nil, err
+		}
+		res, body = c.cache.update(req, res, body)
+	}
+
+	return c.handleResponse(res, body, r)
+}
+
+// send sends the request and returns the response with the whole body.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if err := c.rateLimiter.Wait(req.Context()); err != nil {
+		return nil, nil, err
+	}
+
+	c.logger.logRequest(req)
+
+	start := time.Now()
+	res, err := c.client.Do(req)
+	var body []byte
+	if err == nil {
+		if body, err = bufferBody(res); err != nil {
+			res = nil
+		}
+	}
+	recorderFromContext(req.Context()).record(res)
+
+	c.logger.logResponse(req, res, body, time.Since(start), err)
+	for _, h := range c.afterResponseHooks {
+		h.AfterResponse(req, res, err)
+		if res != nil {
+			// The hook may have read the body.
+			res.Body = io.NopCloser(bytes.NewReader(body))
+		}
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.rateLimiter.updateWithHeader(res.Header)
+	return res, body, nil
+}
 
+func (c *Client) handleResponse(res *http.Response, body []byte, r internal.IOutput) (*EsaAPIError, error) {
 	if _, ok := okCodes[res.StatusCode]; !ok {
 		non200err, err := resolveEsaAPIError(res)
 		if err != nil {
@@ -118,24 +322,12 @@ func (c *Client) Exec(req *http.Request, r internal.IOutput) (*EsaAPIError, erro
 		return non200err, nil
 	}
 
-	var tr io.Reader
-	debugBuf := new(bytes.Buffer)
-	if c.debug {
-		tr = io.TeeReader(res.Body, debugBuf)
-	} else {
-		tr = res.Body
-	}
-
-	if err := json.NewDecoder(tr).Decode(r); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(r); err != nil {
 		if err != io.EOF {
 			return nil, err
 		}
 	}
 
-	if c.debug {
-		fmt.Printf("------DEBUG------\n[response header]\n%v\n[response body]\n%s\n------DEBUG END------\n", res.Header, debugBuf.String())
-	}
-
 	r.SetRateLimitInfo(res.Header)
 
 	return nil, nil
@@ -143,7 +335,7 @@ func (c *Client) Exec(req *http.Request, r internal.IOutput) (*EsaAPIError, erro
 
 func (c *Client) prepare(ctx context.Context, endpointBase, method string, p internal.IInput) (*http.Request, error) {
 	if p == nil {
-		return nil, errors.New("parameter is nil")
+		return nil, &ParameterError{Message: "parameter is nil"}
 	}
 
 	eap, err := p.EsaAPIParameter()
@@ -151,7 +343,7 @@ func (c *Client) prepare(ctx context.Context, endpointBase, method string, p int
 		return nil, err
 	}
 	if eap == nil {
-		return nil, errors.New("parameter for esa API is nil")
+		return nil, &ParameterError{Message: "parameter for esa API is nil"}
 	}
 
 	// resolve query parameters
@@ -165,13 +357,19 @@ func (c *Client) prepare(ctx context.Context, endpointBase, method string, p int
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken()))
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
 
 	return req, nil
 }
 
 func (c *Client) resolveEndpoint(base string, eap internal.EsaAPIParameter) (string, error) {
-	endpoint := internal.ResolveEndpoint(base, eap.Path, eap.Query)
+	endpoint := internal.ResolveEndpoint(replaceBaseURL(base, c.baseURL), eap.Path, eap.Query)
 	return c.apiVersion.ResolveEndpoint(endpoint)
 }
 
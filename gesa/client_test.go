@@ -57,6 +57,19 @@ func Test_NewClient(t *testing.T) {
 				Debug:       true,
 			},
 		},
+		{
+			name: "ok: token source",
+			in: &gesa.NewClientInput{
+				TokenSource: gesa.StaticTokenSource("test-token"),
+			},
+		},
+		{
+			name: "ok: base url",
+			in: &gesa.NewClientInput{
+				AccessToken: "test-token",
+				BaseURL:     "http://localhost:8080",
+			},
+		},
 		{
 			name:    "ng: empty parameters",
 			in:      &gesa.NewClientInput{},
@@ -75,6 +88,14 @@ func Test_NewClient(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ng: invalid base url",
+			in: &gesa.NewClientInput{
+				AccessToken: "test-token",
+				BaseURL:     "localhost:8080",
+			},
+			wantErr: true,
+		},
 		{
 			name:    "ng: nil",
 			in:      nil,
@@ -94,7 +115,70 @@ func Test_NewClient(t *testing.T) {
 			}
 
 			asst.NotNil(client)
-			asst.Equal(c.in.AccessToken, client.AccessToken())
+			asst.Equal("test-token", client.AccessToken())
+		})
+	}
+}
+
+func Test_NewUnauthenticatedClient(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *gesa.NewClientInput
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &gesa.NewClientInput{},
+		},
+		{
+			name: "ok: access token is ignored",
+			in: &gesa.NewClientInput{
+				AccessToken: "test-token",
+			},
+		},
+		{
+			name: "ng: invalid base url",
+			in: &gesa.NewClientInput{
+				BaseURL: "localhost:8080",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil",
+			in:      nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			var header http.Header
+			hc := newMockClient(func(req *http.Request) *http.Response {
+				header = req.Header
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			})
+			if c.in != nil {
+				c.in.HTTPClient = hc
+			}
+
+			client, err := gesa.NewUnauthenticatedClient(c.in)
+			if c.wantErr {
+				asst.NotNil(err)
+				asst.Nil(client)
+				return
+			}
+
+			asst.NotNil(client)
+			asst.Empty(client.AccessToken())
+
+			err = client.CallAPI(context.Background(), "test-endpoint", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+			asst.Nil(err)
+			asst.Empty(header.Get("Authorization"))
 		})
 	}
 }
@@ -119,6 +203,28 @@ func Test_Client_AccessToken(t *testing.T) {
 	}
 }
 
+func Test_Client_BaseURL(t *testing.T) {
+	defaultClient, _ := gesa.NewClient(&gesa.NewClientInput{AccessToken: "test-token"})
+	customClient, _ := gesa.NewClient(&gesa.NewClientInput{AccessToken: "test-token", BaseURL: "http://localhost:8080/"})
+	cases := []struct {
+		name   string
+		client *gesa.Client
+		expect string
+	}{
+		{"default", defaultClient, gesa.DefaultBaseURL},
+		{"custom", customClient, "http://localhost:8080"},
+		{"nil", nil, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			b := c.client.BaseURL()
+			asst.Equal(c.expect, b)
+		})
+	}
+}
+
 func Test_CallAPI(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -0,0 +1,197 @@
+package gesa
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/michimani/go-esa/internal"
+)
+
+// DryRunOperation is a request that was not sent in the dry-run mode.
+type DryRunOperation struct {
+	// Operation is the operation name such as "post.UpdatePost".
+	Operation string
+	Method    string
+	// Endpoint is the URL with the path and query parameters resolved.
+	Endpoint string
+	// Body is the JSON payload of the request. It is empty if the request has no body.
+	Body string
+}
+
+// String returns the operation in one line. The tokens and secrets in the body are redacted.
+func (o DryRunOperation) String() string {
+	s := fmt.Sprintf("%s %s", o.Method, o.Endpoint)
+	if o.Operation != "" {
+		s += fmt.Sprintf(" (%s)", o.Operation)
+	}
+	if o.Body != "" {
+		u, _ := url.Parse(o.Endpoint)
+		s += " " + string(redactBody(u, []byte(o.Body)))
+	}
+	return s
+}
+
+type NewDryRunInput struct {
+	// Logger receives each operation at the info level. The tokens and secrets in the body
+	// are redacted, and the body is truncated to DefaultLogBodyLimit bytes.
+	// If it is nil, the Logger of the client is used, and nothing is logged if both are nil.
+	Logger *slog.Logger
+
+	// Synthesize fills the output of the operation instead of the response.
+	// If it is nil, the object in the top-level key of the payload, such as "post" of
+	// {"post":{...}}, is decoded into the output, and the output is left empty otherwise.
+	Synthesize func(op *DryRunOperation, r IOutput) error
+}
+
+// DryRun records the POST, PATCH, PUT and DELETE requests instead of sending them.
+// GET requests are sent as usual, so that the operations can be built from the current data.
+// The outputs of the recorded requests are synthesized from their payloads, so fields
+// that only the esa API fills, such as the number of a new post, are empty.
+// It is safe for concurrent use and can be shared by clients.
+type DryRun struct {
+	logger     *slog.Logger
+	synthesize func(op *DryRunOperation, r IOutput) error
+
+	mu         sync.Mutex
+	operations []DryRunOperation
+}
+
+// NewDryRun generates *DryRun.
+func NewDryRun(in *NewDryRunInput) *DryRun {
+	if in == nil {
+		in = &NewDryRunInput{}
+	}
+
+	d := &DryRun{
+		logger:     in.Logger,
+		synthesize: in.Synthesize,
+	}
+	if d.synthesize == nil {
+		d.synthesize = synthesizeFromPayload
+	}
+	return d
+}
+
+// Operations returns the recorded operations in the order they would have been sent.
+func (d *DryRun) Operations() []DryRunOperation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ops := make([]DryRunOperation, len(d.operations))
+	copy(ops, d.operations)
+	return ops
+}
+
+// Report returns the recorded operations, one per line.
+func (d *DryRun) Report() string {
+	ops := d.Operations()
+	if len(ops) == 0 {
+		return "no operations\n"
+	}
+
+	var sb strings.Builder
+	for n, op := range ops {
+		fmt.Fprintf(&sb, "%d. %s\n", n+1, op)
+	}
+	return sb.String()
+}
+
+// Reset removes the recorded operations.
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.operations = nil
+}
+
+// intercept records the request and synthesizes the output if the request is not sent.
+// It reports whether the request is intercepted.
+func (d *DryRun) intercept(req *http.Request, r internal.IOutput, fallback *requestLogger) (bool, error) {
+	if d == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return false, nil
+	}
+
+	op := DryRunOperation{
+		Operation: OperationName(req.Context()),
+		Method:    req.Method,
+		Endpoint:  req.URL.String(),
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return true, err
+		}
+		b, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return true, err
+		}
+		op.Body = string(b)
+	}
+
+	d.mu.Lock()
+	d.operations = append(d.operations, op)
+	d.mu.Unlock()
+
+	d.log(req, &op, fallback)
+
+	if r == nil {
+		return true, nil
+	}
+	return true, d.synthesize(&op, r)
+}
+
+// log writes the operation at the info level. The body is redacted and truncated like the debug log.
+func (d *DryRun) log(req *http.Request, op *DryRunOperation, fallback *requestLogger) {
+	l := fallback
+	if d.logger != nil {
+		l = &requestLogger{logger: d.logger, bodyLimit: DefaultLogBodyLimit}
+	}
+	if l == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", op.Operation),
+		slog.String("method", op.Method),
+		slog.String("url", op.Endpoint),
+	}
+	if l.bodyLimit > 0 && op.Body != "" {
+		attrs = append(attrs, slog.String("body", l.truncate(redactBody(req.URL, []byte(op.Body)))))
+	}
+	l.logger.LogAttrs(req.Context(), slog.LevelInfo, "esa API request skipped by dry run", attrs...)
+}
+
+// synthesizeFromPayload decodes the object in the single top-level key of the payload into the output
+// as far as the types of the fields match.
+func synthesizeFromPayload(op *DryRunOperation, r IOutput) error {
+	if op.Body == "" {
+		return nil
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(op.Body), &payload); err != nil || len(payload) != 1 {
+		return nil
+	}
+	for _, v := range payload {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(v, &fields); err != nil {
+			return nil
+		}
+		// The fields are decoded one by one, and the fields whose types differ between
+		// the payload and the output, such as "updated_by", are skipped.
+		for k, fv := range fields {
+			b, err := json.Marshal(map[string]json.RawMessage{k: fv})
+			if err != nil {
+				continue
+			}
+			_ = json.Unmarshal(b, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package gesa_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/esaapi/oauth"
+	oauthtypes "github.com/michimani/go-esa/esaapi/oauth/types"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Client_DryRun(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+
+	sent := []string{}
+	hc := newMockClient(func(req *http.Request) *http.Response {
+		sent = append(sent, req.Method+" "+req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"number":1,"name":"hello","body_md":"a"}`)),
+		}
+	})
+
+	var buf bytes.Buffer
+	dr := gesa.NewDryRun(&gesa.NewDryRunInput{Logger: slog.New(slog.NewTextHandler(&buf, nil))})
+	c, err := gesa.NewClient(&gesa.NewClientInput{AccessToken: "token", HTTPClient: hc, DryRun: dr})
+	asst.NoError(err)
+
+	got, err := post.GetPost(ctx, c, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+	asst.NoError(err)
+	asst.Equal("hello", got.Name)
+
+	updated, err := post.UpdatePost(ctx, c, &types.UpdatePostInput{TeamName: "docs", PostNumber: 1, Name: "renamed", BodyMD: gesa.String("b")})
+	asst.NoError(err)
+	asst.Equal("renamed", updated.Name)
+	asst.Equal("b", updated.BodyMD)
+	asst.Nil(updated.RateLimitInfo)
+
+	deleted, err := post.DeletePost(ctx, c, &types.DeletePostInput{TeamName: "docs", PostNumber: 2})
+	asst.NoError(err)
+	asst.NotNil(deleted)
+
+	// the parameter errors are returned as usual
+	_, err = post.DeletePost(ctx, c, &types.DeletePostInput{TeamName: "docs"})
+	asst.ErrorIs(err, gesa.ErrInvalidParameter)
+
+	asst.Equal([]string{"GET /v1/teams/docs/posts/1"}, sent)
+
+	ops := dr.Operations()
+	if asst.Len(ops, 2) {
+		asst.Equal("post.UpdatePost", ops[0].Operation)
+		asst.Equal(http.MethodPatch, ops[0].Method)
+		asst.Equal("https://api.esa.io/v1/teams/docs/posts/1", ops[0].Endpoint)
+		asst.Contains(ops[0].Body, `"name":"renamed"`)
+		asst.Equal(gesa.DryRunOperation{Operation: "post.DeletePost", Method: http.MethodDelete, Endpoint: "https://api.esa.io/v1/teams/docs/posts/2"}, ops[1])
+	}
+
+	report := dr.Report()
+	asst.True(strings.HasPrefix(report, "1. PATCH https://api.esa.io/v1/teams/docs/posts/1 (post.UpdatePost) {"))
+	asst.Contains(report, "\n2. DELETE https://api.esa.io/v1/teams/docs/posts/2 (post.DeletePost)\n")
+
+	asst.Contains(buf.String(), "esa API request skipped by dry run")
+	asst.Contains(buf.String(), "method=DELETE")
+	asst.NotContains(buf.String(), "token")
+
+	dr.Reset()
+	asst.Empty(dr.Operations())
+	asst.Equal("no operations\n", dr.Report())
+}
+
+func Test_Client_DryRun_PayloadTypes(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+
+	dr := gesa.NewDryRun(nil)
+	c, err := gesa.NewClient(&gesa.NewClientInput{AccessToken: "token", HTTPClient: newMockClient(func(req *http.Request) *http.Response {
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return nil
+	}), DryRun: dr})
+	asst.NoError(err)
+
+	// "created_by" and "updated_by" are screen names in the payload, but users in the output.
+	updated, err := post.UpdatePost(ctx, c, &types.UpdatePostInput{
+		TeamName:   "docs",
+		PostNumber: 1,
+		Name:       "renamed",
+		CreatedBy:  gesa.String("esa_bot"),
+		UpdatedBy:  gesa.String("esa_bot"),
+	})
+	if asst.NoError(err) {
+		asst.Equal("renamed", updated.Name)
+		asst.Equal("", updated.UpdatedBy.ScreenName)
+	}
+
+	created, err := post.CreatePost(ctx, c, &types.CreatePostInput{TeamName: "docs", Name: "new", User: gesa.String("esa_bot")})
+	if asst.NoError(err) {
+		asst.Equal("new", created.Name)
+	}
+
+	asst.Len(dr.Operations(), 2)
+}
+
+func Test_Client_DryRun_Redaction(t *testing.T) {
+	asst := assert.New(t)
+
+	var buf bytes.Buffer
+	dr := gesa.NewDryRun(nil)
+	c, err := gesa.NewUnauthenticatedClient(&gesa.NewClientInput{
+		Logger: slog.New(slog.NewTextHandler(&buf, nil)),
+		HTTPClient: newMockClient(func(req *http.Request) *http.Response {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			return nil
+		}),
+		DryRun: dr,
+	})
+	asst.NoError(err)
+
+	_, err = oauth.RevokeToken(context.Background(), c, &oauthtypes.RevokeTokenInput{ClientID: "id", ClientSecret: "cs-secret", Token: "token-secret"})
+	asst.NoError(err)
+
+	// the client logger is used at the info level
+	asst.Contains(buf.String(), "esa API request skipped by dry run")
+	for _, secret := range []string{"cs-secret", "token-secret"} {
+		asst.NotContains(buf.String(), secret)
+		asst.NotContains(dr.Report(), secret)
+	}
+
+	// Operations returns the payload as is.
+	ops := dr.Operations()
+	if asst.Len(ops, 1) {
+		asst.Contains(ops[0].Body, "token-secret")
+	}
+}
+
+func Test_Client_DryRun_Synthesize(t *testing.T) {
+	asst := assert.New(t)
+
+	hc := newMockClient(func(req *http.Request) *http.Response {
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return nil
+	})
+	dr := gesa.NewDryRun(&gesa.NewDryRunInput{
+		Synthesize: func(op *gesa.DryRunOperation, r gesa.IOutput) error {
+			if out, ok := r.(*types.CreatePostOutput); ok {
+				out.Number = 100
+			}
+			return nil
+		},
+	})
+	c, err := gesa.NewClient(&gesa.NewClientInput{AccessToken: "token", HTTPClient: hc, DryRun: dr})
+	asst.NoError(err)
+
+	out, err := post.CreatePost(context.Background(), c, &types.CreatePostInput{TeamName: "docs", Name: "new"})
+	asst.NoError(err)
+	asst.Equal(100, out.Number)
+	asst.Equal("", out.Name)
+	asst.Len(dr.Operations(), 1)
+}
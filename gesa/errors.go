@@ -3,11 +3,31 @@ package gesa
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/michimani/go-esa/internal"
 )
 
+// The errors matched by errors.Is with *GesaError by the status code of the error response.
+var (
+	ErrNotFound         = errors.New("esa API: not found")
+	ErrUnauthorized     = errors.New("esa API: unauthorized")
+	ErrForbidden        = errors.New("esa API: forbidden")
+	ErrRateLimited      = errors.New("esa API: rate limited")
+	ErrValidationFailed = errors.New("esa API: validation failed")
+	ErrConflict         = errors.New("esa API: conflict")
+	ErrServerError      = errors.New("esa API: server error")
+)
+
+// ErrInvalidParameter is matched by errors.Is with the errors of the parameters validated
+// before calling the esa API, such as empty required parameters.
+var ErrInvalidParameter = internal.ErrInvalidParameter
+
+// ParameterError is the error of a parameter validated before calling the esa API.
+type ParameterError = internal.ParameterError
+
 type GesaError struct {
 	err   error
 	OnAPI bool
@@ -45,6 +65,22 @@ func wrapWithAPIErr(eae *EsaAPIError) *GesaError {
 	}
 }
 
+// NewAPIError returns the error of the error response of the esa API.
+// It is useful for fakes of IAPIClient to return the same error as *Client.
+func NewAPIError(eae *EsaAPIError) *GesaError {
+	return wrapWithAPIErr(eae)
+}
+
+// WrapError returns the error that wraps err as *Client does for the errors other than
+// the error responses, such as the errors of the parameters. It returns nil if err is nil.
+// It is useful for fakes of IAPIClient to return the same error as *Client.
+func WrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return wrapErr(err)
+}
+
 func (e *GesaError) Error() string {
 	if e == nil {
 		return ""
@@ -64,6 +100,41 @@ func (e *GesaError) Unwrap() error {
 	return e.err
 }
 
+// Is reports whether the error response matches the target, such as ErrNotFound.
+func (e *GesaError) Is(target error) bool {
+	if e == nil || !e.OnAPI {
+		return false
+	}
+
+	code := e.EsaAPIError.StatusCode
+	switch target {
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrRateLimited:
+		return code == http.StatusTooManyRequests
+	case ErrValidationFailed:
+		return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+	case ErrConflict:
+		return code == http.StatusConflict
+	case ErrServerError:
+		return code >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// RetryAfter returns the duration until the rate limit is reset, if the request is rate limited
+// and the response has the X-RateLimit-Reset header.
+func (e *GesaError) RetryAfter() (time.Duration, bool) {
+	if !errors.Is(e, ErrRateLimited) {
+		return 0, false
+	}
+	return untilReset(e.EsaAPIError.RateLimitInfo, time.Now())
+}
+
 func (e *EsaAPIError) Summary() string {
 	if e == nil {
 		return ""
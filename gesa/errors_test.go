@@ -2,8 +2,10 @@ package gesa_test
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/michimani/go-esa/gesa"
 	"github.com/michimani/go-esa/internal"
@@ -283,3 +285,121 @@ func Test_AccessEsaAPIErrorFields(t *testing.T) {
 	a.Equal(20, ge.RateLimitInfo.Remaining)
 	a.Equal(reset, *ge.RateLimitInfo.Reset)
 }
+
+func Test_GesaError_Is(t *testing.T) {
+	sentinels := []error{
+		gesa.ErrNotFound,
+		gesa.ErrUnauthorized,
+		gesa.ErrForbidden,
+		gesa.ErrRateLimited,
+		gesa.ErrValidationFailed,
+		gesa.ErrConflict,
+		gesa.ErrServerError,
+		gesa.ErrInvalidParameter,
+	}
+
+	cases := []struct {
+		name   string
+		err    error
+		expect error
+	}{
+		{name: "400", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 400}), expect: gesa.ErrValidationFailed},
+		{name: "401", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 401}), expect: gesa.ErrUnauthorized},
+		{name: "403", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 403}), expect: gesa.ErrForbidden},
+		{name: "404", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 404}), expect: gesa.ErrNotFound},
+		{name: "409", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 409}), expect: gesa.ErrConflict},
+		{name: "422", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 422}), expect: gesa.ErrValidationFailed},
+		{name: "429", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 429}), expect: gesa.ErrRateLimited},
+		{name: "500", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 500}), expect: gesa.ErrServerError},
+		{name: "503 wrapped", err: fmt.Errorf("wrapped: %w", gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 503})), expect: gesa.ErrServerError},
+		{name: "418", err: gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 418}), expect: nil},
+		{name: "not on API", err: gesa.ExportWrapErr(errors.New("error test")), expect: nil},
+		{name: "required parameter empty", err: gesa.ExportWrapErr(internal.NewRequiredParameterEmptyError("Input.A")), expect: gesa.ErrInvalidParameter},
+		{name: "parameter is nil", err: internal.NewParameterIsNilError(), expect: gesa.ErrInvalidParameter},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			for _, s := range sentinels {
+				asst.Equal(s == c.expect, errors.Is(c.err, s), s.Error())
+			}
+		})
+	}
+}
+
+func Test_ParameterError(t *testing.T) {
+	asst := assert.New(t)
+
+	err := gesa.ExportWrapErr(internal.NewRequiredParameterEmptyError("GetPostInput.TeamName, GetPostInput.PostNumber"))
+	asst.Equal("Required parameters are empty. : GetPostInput.TeamName, GetPostInput.PostNumber", err.Error())
+
+	var pe *gesa.ParameterError
+	if asst.True(errors.As(err, &pe)) {
+		asst.Equal([]string{"GetPostInput.TeamName", "GetPostInput.PostNumber"}, pe.Fields)
+	}
+
+	pe = nil
+	if asst.True(errors.As(internal.NewParameterIsNilError(), &pe)) {
+		asst.Equal(internal.ErrorParameterIsNil, pe.Error())
+		asst.Empty(pe.Fields)
+	}
+}
+
+func Test_GesaError_RetryAfter(t *testing.T) {
+	future := gesa.Timestamp(time.Now().Add(time.Minute).Unix())
+	past := gesa.Timestamp(time.Now().Add(-time.Minute).Unix())
+
+	cases := []struct {
+		name     string
+		ge       *gesa.GesaError
+		expectOK bool
+		expectGT time.Duration
+		expectLE time.Duration
+	}{
+		{
+			name:     "ok: rate limited",
+			ge:       gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 429, RateLimitInfo: &gesa.RateLimitInformation{Reset: &future}}),
+			expectOK: true,
+			expectGT: 58 * time.Second,
+			expectLE: time.Minute,
+		},
+		{
+			name:     "ok: already reset",
+			ge:       gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 429, RateLimitInfo: &gesa.RateLimitInformation{Reset: &past}}),
+			expectOK: true,
+			expectGT: -1,
+			expectLE: 0,
+		},
+		{
+			name: "ng: no rate limit information",
+			ge:   gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 429}),
+		},
+		{
+			name: "ng: not rate limited",
+			ge:   gesa.ExportWrapWithAPIErr(&gesa.EsaAPIError{StatusCode: 500, RateLimitInfo: &gesa.RateLimitInformation{Reset: &future}}),
+		},
+		{
+			name: "ng: not on API",
+			ge:   gesa.ExportWrapErr(errors.New("error test")),
+		},
+		{
+			name: "ng: nil",
+			ge:   nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			d, ok := c.ge.RetryAfter()
+			asst.Equal(c.expectOK, ok)
+			if !c.expectOK {
+				asst.Zero(d)
+				return
+			}
+			asst.Greater(d, c.expectGT)
+			asst.LessOrEqual(d, c.expectLE)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package gesa
 
+import "time"
+
 var (
 	_ interface{}
 
@@ -8,4 +10,19 @@ var (
 
 	ExportWrapErr        = wrapErr
 	ExportWrapWithAPIErr = wrapWithAPIErr
+
+	ExportNormalizeBaseURL = normalizeBaseURL
+	ExportReplaceBaseURL   = replaceBaseURL
 )
+
+func ExportSetBackoffRetryPolicyNow(p *BackoffRetryPolicy, now func() time.Time) {
+	p.now = now
+}
+
+func ExportSetRateLimiterNow(l *RateLimiter, now func() time.Time) {
+	l.now = now
+}
+
+func ExportSetResponseCacheNow(c *ResponseCache, now func() time.Time) {
+	c.now = now
+}
@@ -0,0 +1,33 @@
+package gesa
+
+import (
+	"net/http"
+)
+
+// BeforeRequestHook is called before each request is sent, including retries.
+// It can return the request with a new context or headers. If it returns an error,
+// the request is not sent and the error is returned from CallAPI.
+type BeforeRequestHook interface {
+	BeforeRequest(req *http.Request) (*http.Request, error)
+}
+
+// AfterResponseHook is called after each response is received, including retries.
+// err is the error of sending the request, and res is nil if err is not nil.
+// The body of res can be read by the hook without affecting the client.
+type AfterResponseHook interface {
+	AfterResponse(req *http.Request, res *http.Response, err error)
+}
+
+// BeforeRequestFunc is an adapter to use a function as BeforeRequestHook.
+type BeforeRequestFunc func(req *http.Request) (*http.Request, error)
+
+func (f BeforeRequestFunc) BeforeRequest(req *http.Request) (*http.Request, error) {
+	return f(req)
+}
+
+// AfterResponseFunc is an adapter to use a function as AfterResponseHook.
+type AfterResponseFunc func(req *http.Request, res *http.Response, err error)
+
+func (f AfterResponseFunc) AfterResponse(req *http.Request, res *http.Response, err error) {
+	f(req, res, err)
+}
@@ -0,0 +1,177 @@
+package gesa_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+type messageOutput struct {
+	Message string `json:"message"`
+}
+
+func (r *messageOutput) SetRateLimitInfo(h http.Header) {}
+
+func Test_Client_BeforeRequestHooks(t *testing.T) {
+	cases := []struct {
+		name        string
+		hooks       []gesa.BeforeRequestHook
+		expectSent  bool
+		expectValue string
+		wantErr     bool
+	}{
+		{
+			name: "ok: hooks are called in order",
+			hooks: []gesa.BeforeRequestHook{
+				gesa.BeforeRequestFunc(func(req *http.Request) (*http.Request, error) {
+					req.Header.Set("X-Test", "first")
+					return req, nil
+				}),
+				gesa.BeforeRequestFunc(func(req *http.Request) (*http.Request, error) {
+					req.Header.Set("X-Test", req.Header.Get("X-Test")+",second")
+					return req, nil
+				}),
+			},
+			expectSent:  true,
+			expectValue: "first,second",
+		},
+		{
+			name: "ok: hook replaces request",
+			hooks: []gesa.BeforeRequestHook{
+				gesa.BeforeRequestFunc(func(req *http.Request) (*http.Request, error) {
+					r := req.Clone(req.Context())
+					r.Header.Set("X-Test", "cloned")
+					return r, nil
+				}),
+			},
+			expectSent:  true,
+			expectValue: "cloned",
+		},
+		{
+			name: "ng: hook returns error",
+			hooks: []gesa.BeforeRequestHook{
+				gesa.BeforeRequestFunc(func(req *http.Request) (*http.Request, error) {
+					return nil, errors.New("hook error")
+				}),
+			},
+			expectSent: false,
+			wantErr:    true,
+		},
+		{
+			name: "ng: hook returns nil request",
+			hooks: []gesa.BeforeRequestHook{
+				gesa.BeforeRequestFunc(func(req *http.Request) (*http.Request, error) {
+					return nil, nil
+				}),
+			},
+			expectSent: false,
+			wantErr:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			sent := false
+			value := ""
+			client, _ := gesa.NewClient(&gesa.NewClientInput{
+				AccessToken: "test-token",
+				HTTPClient: newMockClient(func(req *http.Request) *http.Response {
+					sent = true
+					value = req.Header.Get("X-Test")
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}
+				}),
+				BeforeRequestHooks: c.hooks,
+			})
+
+			err := client.CallAPI(context.Background(), "https://api.esa.io/test", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+			asst.Equal(c.expectSent, sent)
+			if c.wantErr {
+				asst.Error(err)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expectValue, value)
+		})
+	}
+}
+
+func Test_Client_AfterResponseHooks(t *testing.T) {
+	cases := []struct {
+		name         string
+		roundTrip    func(req *http.Request) (*http.Response, error)
+		expectStatus int
+		expectBody   string
+		expectErr    bool
+	}{
+		{
+			name: "ok: hooks can read body",
+			roundTrip: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"message":"ok"}`))}, nil
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `{"message":"ok"}`,
+		},
+		{
+			name: "ng: transport error",
+			roundTrip: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("transport error")
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			bodies := []string{}
+			var status int
+			var hookErr error
+			hook := gesa.AfterResponseFunc(func(req *http.Request, res *http.Response, err error) {
+				hookErr = err
+				if res == nil {
+					return
+				}
+				status = res.StatusCode
+				b, _ := io.ReadAll(res.Body)
+				bodies = append(bodies, string(b))
+			})
+
+			client, _ := gesa.NewClient(&gesa.NewClientInput{
+				AccessToken:        "test-token",
+				HTTPClient:         &http.Client{Transport: roundTripErrFunc(c.roundTrip)},
+				AfterResponseHooks: []gesa.AfterResponseHook{hook, hook},
+			})
+
+			out := &messageOutput{}
+			err := client.CallAPI(context.Background(), "https://api.esa.io/test", http.MethodGet, &mockAPIParameter{}, out)
+			if c.expectErr {
+				asst.Error(err)
+				asst.Error(hookErr)
+				asst.Empty(bodies)
+				return
+			}
+
+			asst.NoError(err)
+			asst.NoError(hookErr)
+			asst.Equal(c.expectStatus, status)
+			asst.Equal([]string{c.expectBody, c.expectBody}, bodies)
+			asst.Equal("ok", out.Message)
+		})
+	}
+}
+
+type roundTripErrFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripErrFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
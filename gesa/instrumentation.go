@@ -0,0 +1,145 @@
+package gesa
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/michimani/go-esa/internal"
+)
+
+// Instrumentation observes each call of CallAPI to record traces or metrics.
+// StartCall is called before the first attempt of the call, and the returned function
+// is called with the result after the last attempt.
+type Instrumentation interface {
+	StartCall(ctx context.Context, info CallInfo) (context.Context, func(CallResult))
+}
+
+// CallInfo is the information of a call of CallAPI.
+type CallInfo struct {
+	// Operation is the name of the operation such as "post.ListPosts".
+	// It is empty if the context has no operation name.
+	Operation string
+	Method    string
+	// Endpoint is the endpoint template such as "https://api.esa.io/:esa_api_version/teams/:team_name/posts".
+	Endpoint string
+	TeamName string
+}
+
+// CallResult is the result of a call of CallAPI.
+type CallResult struct {
+	// StatusCode is the status code of the last response. It is 0 if no response is received.
+	StatusCode int
+	// Attempts is the number of requests sent, including retries.
+	Attempts int
+	// StatusCodes is the status code of each attempt in order. It is 0 for an attempt without a response.
+	StatusCodes []int
+	// RateLimited is the number of responses with 429 Too Many Requests.
+	RateLimited int
+	// RateLimitInfo is the rate limit information of the last response, or nil.
+	RateLimitInfo *RateLimitInformation
+	Duration      time.Duration
+	Err           error
+}
+
+type operationNameKey struct{}
+
+// WithOperationName returns the context with the operation name passed to Instrumentation.
+// The functions of the esaapi packages set their names such as "post.ListPosts".
+func WithOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationNameKey{}, name)
+}
+
+// OperationName returns the operation name of the context, or an empty string.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationNameKey{}).(string)
+	return name
+}
+
+// callRecorder records the responses of a call for Instrumentation.
+type callRecorder struct {
+	statusCode    int
+	attempts      int
+	statusCodes   []int
+	rateLimited   int
+	rateLimitInfo *RateLimitInformation
+}
+
+type callRecorderKey struct{}
+
+func recorderFromContext(ctx context.Context) *callRecorder {
+	r, _ := ctx.Value(callRecorderKey{}).(*callRecorder)
+	return r
+}
+
+// record records the response of an attempt. res is nil if the request failed.
+func (r *callRecorder) record(res *http.Response) {
+	if r == nil {
+		return
+	}
+
+	r.attempts++
+	if res == nil {
+		r.statusCodes = append(r.statusCodes, 0)
+		r.statusCode = 0
+		r.rateLimitInfo = nil
+		return
+	}
+
+	r.statusCodes = append(r.statusCodes, res.StatusCode)
+	r.statusCode = res.StatusCode
+	if res.StatusCode == http.StatusTooManyRequests {
+		r.rateLimited++
+	}
+	r.rateLimitInfo = nil
+	if rri, err := GetRateLimitInformation(res.Header); err == nil {
+		r.rateLimitInfo = rri
+	}
+}
+
+func (c *Client) instrument(ctx context.Context, endpoint, method string, p internal.IInput, call func(ctx context.Context) error) error {
+	if c.instrumentation == nil {
+		return call(ctx)
+	}
+
+	ctx, end := c.instrumentation.StartCall(ctx, CallInfo{
+		Operation: OperationName(ctx),
+		Method:    method,
+		Endpoint:  endpoint,
+		TeamName:  teamNameOf(p),
+	})
+
+	rec := &callRecorder{}
+	start := time.Now()
+	err := call(context.WithValue(ctx, callRecorderKey{}, rec))
+	end(CallResult{
+		StatusCode:    rec.statusCode,
+		Attempts:      rec.attempts,
+		StatusCodes:   rec.statusCodes,
+		RateLimited:   rec.rateLimited,
+		RateLimitInfo: rec.rateLimitInfo,
+		Duration:      time.Since(start),
+		Err:           err,
+	})
+
+	return err
+}
+
+// teamNameOf returns the team name in the path parameters, or an empty string.
+func teamNameOf(p internal.IInput) string {
+	if p == nil {
+		return ""
+	}
+
+	eap, err := p.EsaAPIParameter()
+	if err != nil || eap == nil {
+		return ""
+	}
+
+	for _, pp := range eap.Path {
+		if pp.Key == ":team_name" {
+			return pp.Value
+		}
+	}
+	return ""
+}
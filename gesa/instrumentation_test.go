@@ -0,0 +1,175 @@
+package gesa_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeInstrumentation struct {
+	infos   []gesa.CallInfo
+	results []gesa.CallResult
+	ctxs    []context.Context
+}
+
+type fakeInstrumentationKey struct{}
+
+func (f *fakeInstrumentation) StartCall(ctx context.Context, info gesa.CallInfo) (context.Context, func(gesa.CallResult)) {
+	f.infos = append(f.infos, info)
+	ctx = context.WithValue(ctx, fakeInstrumentationKey{}, "started")
+	return ctx, func(r gesa.CallResult) {
+		f.results = append(f.results, r)
+	}
+}
+
+type retryAlways struct{}
+
+func (retryAlways) RetryDelay(attempt int, method string, eae *gesa.EsaAPIError, err error) (time.Duration, bool) {
+	return 0, attempt < 3
+}
+
+type teamParameter struct{}
+
+func (teamParameter) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	return &internal.EsaAPIParameter{
+		Path: internal.PathParameterList{{Key: ":team_name", Value: "docs"}},
+	}, nil
+}
+
+func Test_WithOperationName(t *testing.T) {
+	asst := assert.New(t)
+	asst.Equal("", gesa.OperationName(context.Background()))
+	asst.Equal("post.ListPosts", gesa.OperationName(gesa.WithOperationName(context.Background(), "post.ListPosts")))
+}
+
+func Test_Client_Instrumentation(t *testing.T) {
+	rateLimitHeader := func(remaining string) http.Header {
+		return http.Header{
+			"Content-Type":          {"application/json"},
+			"X-Ratelimit-Limit":     {"75"},
+			"X-Ratelimit-Remaining": {remaining},
+			"X-Ratelimit-Reset":     {"1700000000"},
+		}
+	}
+
+	reset := gesa.Timestamp(1700000000)
+
+	cases := []struct {
+		name      string
+		responses []func() (*http.Response, error)
+		params    internal.IInput
+		expect    gesa.CallResult
+		expectErr bool
+		teamName  string
+	}{
+		{
+			name: "ok",
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusOK, Header: rateLimitHeader("70"), Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+				},
+			},
+			params:   teamParameter{},
+			teamName: "docs",
+			expect: gesa.CallResult{
+				StatusCode:    http.StatusOK,
+				Attempts:      1,
+				StatusCodes:   []int{http.StatusOK},
+				RateLimitInfo: &gesa.RateLimitInformation{Limit: 75, Remaining: 70, Reset: &reset},
+			},
+		},
+		{
+			name: "ok: retried after rate limited",
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusTooManyRequests, Header: rateLimitHeader("0"), Body: io.NopCloser(strings.NewReader(`{"error":"too_many_requests"}`))}, nil
+				},
+				func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusOK, Header: rateLimitHeader("74"), Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+				},
+			},
+			params: &mockAPIParameter{},
+			expect: gesa.CallResult{
+				StatusCode:    http.StatusOK,
+				Attempts:      2,
+				StatusCodes:   []int{http.StatusTooManyRequests, http.StatusOK},
+				RateLimited:   1,
+				RateLimitInfo: &gesa.RateLimitInformation{Limit: 75, Remaining: 74, Reset: &reset},
+			},
+		},
+		{
+			name: "ng: transport error",
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) { return nil, errors.New("transport error") },
+				func() (*http.Response, error) { return nil, errors.New("transport error") },
+				func() (*http.Response, error) { return nil, errors.New("transport error") },
+			},
+			params:    &mockAPIParameter{},
+			expect:    gesa.CallResult{Attempts: 3, StatusCodes: []int{0, 0, 0}},
+			expectErr: true,
+		},
+		{
+			name:      "ng: invalid parameter",
+			params:    &mockAPIParameter{EsaAPINil: true},
+			expect:    gesa.CallResult{},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			inst := &fakeInstrumentation{}
+			i := 0
+			client, _ := gesa.NewClient(&gesa.NewClientInput{
+				AccessToken:     "test-token",
+				RetryPolicy:     retryAlways{},
+				Instrumentation: inst,
+				HTTPClient: &http.Client{Transport: roundTripErrFunc(func(req *http.Request) (*http.Response, error) {
+					inst.ctxs = append(inst.ctxs, req.Context())
+					res, err := c.responses[i]()
+					i++
+					return res, err
+				})},
+			})
+
+			ctx := gesa.WithOperationName(context.Background(), "test.Operation")
+			err := client.CallAPI(ctx, "https://api.esa.io/test", http.MethodGet, c.params, &mockAPIOutput{})
+			if c.expectErr {
+				asst.Error(err)
+			} else {
+				asst.NoError(err)
+			}
+
+			if !asst.Len(inst.infos, 1) || !asst.Len(inst.results, 1) {
+				return
+			}
+			asst.Equal(gesa.CallInfo{
+				Operation: "test.Operation",
+				Method:    http.MethodGet,
+				Endpoint:  "https://api.esa.io/test",
+				TeamName:  c.teamName,
+			}, inst.infos[0])
+
+			r := inst.results[0]
+			asst.Greater(r.Duration, time.Duration(0))
+			asst.Equal(err, r.Err)
+			r.Duration = 0
+			r.Err = nil
+			asst.Equal(c.expect, r)
+
+			for _, rctx := range inst.ctxs {
+				asst.Equal("started", rctx.Value(fakeInstrumentationKey{}), "the context returned by StartCall must be used for requests")
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package gesa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLogBodyLimit is the default maximum number of bytes of a body written to the log.
+	DefaultLogBodyLimit = 1024
+
+	redacted = "[REDACTED]"
+)
+
+var (
+	// sensitiveHeaderKeys are the header keys whose values are never written to the log.
+	sensitiveHeaderKeys = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+	// sensitiveBodyKeys are the keys of the JSON bodies whose values are never written to the log.
+	sensitiveBodyKeys = map[string]struct{}{
+		"access_token":  {},
+		"refresh_token": {},
+		"client_secret": {},
+	}
+
+	// sensitiveOAuthBodyKeys are also redacted in the bodies of the OAuth APIs.
+	// "code" is also used by other APIs such as emojis, so it is redacted only for OAuth.
+	sensitiveOAuthBodyKeys = map[string]struct{}{
+		"code":  {},
+		"token": {},
+	}
+)
+
+// RedactHeader returns a copy of the header whose sensitive values such as
+// the Authorization header are replaced.
+func RedactHeader(h http.Header) http.Header {
+	rh := h.Clone()
+	if rh == nil {
+		return http.Header{}
+	}
+	for _, k := range sensitiveHeaderKeys {
+		if _, ok := rh[k]; ok {
+			rh[k] = []string{redacted}
+		}
+	}
+	return rh
+}
+
+// redactBody returns the body whose values of the sensitive JSON keys such as
+// "access_token" are replaced. The body is returned as is if it is not JSON.
+func redactBody(u *url.URL, b []byte) []byte {
+	if len(b) == 0 {
+		return b
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return b
+	}
+
+	oauth := u != nil && strings.HasPrefix(u.Path, "/oauth/")
+	if !redactValue(v, oauth) {
+		return b
+	}
+
+	rb, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return rb
+}
+
+// redactValue redacts the value recursively and reports whether it is changed.
+func redactValue(v any, oauth bool) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			_, sensitive := sensitiveBodyKeys[k]
+			if _, ok := sensitiveOAuthBodyKeys[k]; ok && oauth {
+				sensitive = true
+			}
+			if sensitive {
+				if s, ok := child.(string); ok && s != "" {
+					v[k] = redacted
+					changed = true
+					continue
+				}
+			}
+			if redactValue(child, oauth) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if redactValue(child, oauth) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// requestLogger writes requests and responses to the log at the debug level.
+type requestLogger struct {
+	logger    *slog.Logger
+	bodyLimit int
+}
+
+func newRequestLogger(in *NewClientInput) *requestLogger {
+	logger := in.Logger
+	if logger == nil && in.Debug {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	if logger == nil {
+		return nil
+	}
+
+	limit := in.LogBodyLimit
+	if limit == 0 {
+		limit = DefaultLogBodyLimit
+	}
+	return &requestLogger{logger: logger, bodyLimit: limit}
+}
+
+func (l *requestLogger) enabled(ctx context.Context) bool {
+	return l != nil && l.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+func (l *requestLogger) logRequest(req *http.Request) {
+	if !l.enabled(req.Context()) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Any("header", RedactHeader(req.Header)),
+	}
+	if l.bodyLimit > 0 && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, slog.String("body", l.truncate(redactBody(req.URL, b))))
+		}
+	}
+
+	l.logger.LogAttrs(req.Context(), slog.LevelDebug, "esa API request", attrs...)
+}
+
+func (l *requestLogger) logResponse(req *http.Request, res *http.Response, body []byte, elapsed time.Duration, err error) {
+	if !l.enabled(req.Context()) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("elapsed", elapsed),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		l.logger.LogAttrs(req.Context(), slog.LevelDebug, "esa API request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", res.StatusCode),
+		slog.Any("header", RedactHeader(res.Header)),
+	)
+	if l.bodyLimit > 0 {
+		attrs = append(attrs, slog.String("body", l.truncate(redactBody(req.URL, body))))
+	}
+
+	l.logger.LogAttrs(req.Context(), slog.LevelDebug, "esa API response", attrs...)
+}
+
+func (l *requestLogger) truncate(b []byte) string {
+	if len(b) <= l.bodyLimit {
+		return string(b)
+	}
+	// The last rune may be cut, so invalid bytes are dropped.
+	return fmt.Sprintf("%s...(%d bytes truncated)", strings.ToValidUTF8(string(b[:l.bodyLimit]), ""), len(b)-l.bodyLimit)
+}
+
+// bufferBody reads the whole body of the response and replaces it with a re-readable one.
+func bufferBody(res *http.Response) ([]byte, error) {
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
@@ -0,0 +1,205 @@
+package gesa_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+type bodyParameter struct {
+	body string
+}
+
+func (p bodyParameter) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	return &internal.EsaAPIParameter{Body: strings.NewReader(p.body)}, nil
+}
+
+func Test_RedactHeader(t *testing.T) {
+	cases := []struct {
+		name   string
+		h      http.Header
+		expect http.Header
+	}{
+		{
+			name:   "nil",
+			h:      nil,
+			expect: http.Header{},
+		},
+		{
+			name: "redacted",
+			h: http.Header{
+				"Authorization": {"Bearer secret"},
+				"Cookie":        {"a=b"},
+				"Content-Type":  {"application/json"},
+			},
+			expect: http.Header{
+				"Authorization": {"[REDACTED]"},
+				"Cookie":        {"[REDACTED]"},
+				"Content-Type":  {"application/json"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			rh := gesa.RedactHeader(c.h)
+			asst.Equal(c.expect, rh)
+			if c.h != nil {
+				asst.Equal("Bearer secret", c.h.Get("Authorization"), "original header must not be changed")
+			}
+		})
+	}
+}
+
+func Test_Client_Logger(t *testing.T) {
+	cases := []struct {
+		name            string
+		endpoint        string
+		limit           int
+		level           slog.Level
+		reqBody         string
+		resBody         string
+		expectLogs      int
+		expectReqBody   *string
+		expectResBody   *string
+		unexpectedInLog []string
+	}{
+		{
+			name:            "ok",
+			reqBody:         `{"post":{"name":"test"}}`,
+			resBody:         `{"message":"ok"}`,
+			level:           slog.LevelDebug,
+			expectLogs:      2,
+			expectReqBody:   gesa.String(`{"post":{"name":"test"}}`),
+			expectResBody:   gesa.String(`{"message":"ok"}`),
+			unexpectedInLog: []string{"test-token"},
+		},
+		{
+			name:            "ok: secrets in the bodies are redacted",
+			endpoint:        "https://api.esa.io/oauth/token",
+			reqBody:         `{"client_id":"id","client_secret":"cs-secret","code":"code-secret","grant_type":"authorization_code"}`,
+			resBody:         `{"access_token":"at-secret","message":"ok","refresh_token":"rt-secret"}`,
+			level:           slog.LevelDebug,
+			expectLogs:      2,
+			expectReqBody:   gesa.String(`{"client_id":"id","client_secret":"[REDACTED]","code":"[REDACTED]","grant_type":"authorization_code"}`),
+			expectResBody:   gesa.String(`{"access_token":"[REDACTED]","message":"ok","refresh_token":"[REDACTED]"}`),
+			unexpectedInLog: []string{"cs-secret", "code-secret", "at-secret", "rt-secret"},
+		},
+		{
+			name:            "ok: token of the revoke request is redacted",
+			endpoint:        "https://api.esa.io/oauth/revoke",
+			reqBody:         `{"token":"token-secret"}`,
+			resBody:         `{"message":"ok"}`,
+			level:           slog.LevelDebug,
+			expectLogs:      2,
+			expectReqBody:   gesa.String(`{"token":"[REDACTED]"}`),
+			expectResBody:   gesa.String(`{"message":"ok"}`),
+			unexpectedInLog: []string{"token-secret"},
+		},
+		{
+			name:          "ok: code is not redacted out of the OAuth APIs",
+			reqBody:       `{"emoji":{"code":"smile"}}`,
+			resBody:       `{"message":"ok"}`,
+			level:         slog.LevelDebug,
+			expectLogs:    2,
+			expectReqBody: gesa.String(`{"emoji":{"code":"smile"}}`),
+			expectResBody: gesa.String(`{"message":"ok"}`),
+		},
+		{
+			name:          "ok: truncated",
+			limit:         4,
+			reqBody:       `{"post":{"name":"test"}}`,
+			resBody:       `{"message":"ok"}`,
+			level:         slog.LevelDebug,
+			expectLogs:    2,
+			expectReqBody: gesa.String(`{"po...(20 bytes truncated)`),
+			expectResBody: gesa.String(`{"me...(12 bytes truncated)`),
+		},
+		{
+			name:       "ok: bodies are not logged",
+			limit:      -1,
+			reqBody:    `{"post":{"name":"test"}}`,
+			resBody:    `{"message":"ok"}`,
+			level:      slog.LevelDebug,
+			expectLogs: 2,
+		},
+		{
+			name:       "ok: debug level is disabled",
+			reqBody:    `{}`,
+			resBody:    `{"message":"ok"}`,
+			level:      slog.LevelInfo,
+			expectLogs: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			buf := new(bytes.Buffer)
+			logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: c.level}))
+			client, _ := gesa.NewClient(&gesa.NewClientInput{
+				AccessToken:  "test-token",
+				Logger:       logger,
+				LogBodyLimit: c.limit,
+				HTTPClient: newMockHTTPClient(&mockInput{
+					ResponseStatusCode: http.StatusOK,
+					ResponseBody:       io.NopCloser(strings.NewReader(c.resBody)),
+				}),
+			})
+
+			endpoint := c.endpoint
+			if endpoint == "" {
+				endpoint = "https://api.esa.io/test"
+			}
+			out := &messageOutput{}
+			err := client.CallAPI(context.Background(), endpoint, http.MethodPost, bodyParameter{body: c.reqBody}, out)
+			asst.NoError(err)
+			asst.Equal("ok", out.Message)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if c.expectLogs == 0 {
+				asst.Empty(buf.String())
+				return
+			}
+			if !asst.Len(lines, c.expectLogs) {
+				return
+			}
+
+			for _, s := range c.unexpectedInLog {
+				asst.NotContains(buf.String(), s)
+			}
+
+			var reqLog, resLog map[string]any
+			asst.NoError(json.Unmarshal([]byte(lines[0]), &reqLog))
+			asst.NoError(json.Unmarshal([]byte(lines[1]), &resLog))
+
+			asst.Equal("esa API request", reqLog["msg"])
+			asst.Equal(http.MethodPost, reqLog["method"])
+			asst.Equal([]any{"[REDACTED]"}, reqLog["header"].(map[string]any)["Authorization"])
+			asst.Equal("esa API response", resLog["msg"])
+			asst.Equal(float64(http.StatusOK), resLog["status"])
+
+			if c.expectReqBody == nil {
+				asst.NotContains(reqLog, "body")
+			} else {
+				asst.Equal(*c.expectReqBody, reqLog["body"])
+			}
+			if c.expectResBody == nil {
+				asst.NotContains(resLog, "body")
+			} else {
+				asst.Equal(*c.expectResBody, resLog["body"])
+			}
+		})
+	}
+}
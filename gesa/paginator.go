@@ -0,0 +1,81 @@
+package gesa
+
+import (
+	"context"
+	"iter"
+
+	"github.com/michimani/go-esa/internal"
+)
+
+// IPaginationInput is the interface of the input for list APIs that support pagination.
+type IPaginationInput interface {
+	internal.IInput
+	internal.IPaginationParameters
+	SetPage(page *PageNumber)
+}
+
+// IPaginationOutput is the interface of the output for list APIs that support pagination.
+type IPaginationOutput[T any] interface {
+	internal.IOutput
+	PageItems() []T
+	NextPageNumber() *PageNumber
+}
+
+// PageFetcher is a function that calls a list API, such as post.ListPosts.
+type PageFetcher[In IPaginationInput, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Pages returns an iterator over every page of a list API, starting at the page of the input.
+// The Page field of the input is updated for each request.
+// Each page has its own rate limit information in the RateLimitInfo field.
+// The iteration stops after yielding an error, including the error of the context.
+func Pages[In IPaginationInput, Out IPaginationOutput[T], T any](ctx context.Context, in In, fetch PageFetcher[In, Out]) iter.Seq2[Out, error] {
+	return func(yield func(Out, error) bool) {
+		var zero Out
+		current, _ := in.PageValue()
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			out, err := fetch(ctx, in)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			if !yield(out, nil) {
+				return
+			}
+
+			next := out.NextPageNumber()
+			if next.IsNull() || next.SafeInt() <= current {
+				return
+			}
+
+			current = next.SafeInt()
+			in.SetPage(NewPageNumber(current))
+		}
+	}
+}
+
+// Paginate returns an iterator over every item of a list API, walking all pages.
+// See Pages for the details.
+func Paginate[In IPaginationInput, Out IPaginationOutput[T], T any](ctx context.Context, in In, fetch PageFetcher[In, Out]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for out, err := range Pages(ctx, in, fetch) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range out.PageItems() {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
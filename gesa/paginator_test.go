@@ -0,0 +1,198 @@
+package gesa_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+type testListInput struct {
+	Page *gesa.PageNumber
+}
+
+func (p *testListInput) EsaAPIParameter() (*internal.EsaAPIParameter, error) {
+	return &internal.EsaAPIParameter{}, nil
+}
+
+func (p *testListInput) PageValue() (int, bool) {
+	if p.Page.IsNull() {
+		return 0, false
+	}
+	return p.Page.SafeInt(), true
+}
+
+func (p *testListInput) PerPageValue() (int, bool) {
+	return 0, false
+}
+
+func (p *testListInput) SetPage(page *gesa.PageNumber) {
+	p.Page = page
+}
+
+type testListOutput struct {
+	Items         []int
+	NextPage      *gesa.PageNumber
+	RateLimitInfo *gesa.RateLimitInformation
+}
+
+func (r *testListOutput) SetRateLimitInfo(h http.Header) {}
+
+func (r *testListOutput) PageItems() []int {
+	return r.Items
+}
+
+func (r *testListOutput) NextPageNumber() *gesa.PageNumber {
+	return r.NextPage
+}
+
+// newTestFetcher returns a fetcher that serves the pages.
+// pages[i] is the items of the page number i+1.
+func newTestFetcher(pages [][]int, errPage int, called *[]int) gesa.PageFetcher[*testListInput, *testListOutput] {
+	return func(ctx context.Context, in *testListInput) (*testListOutput, error) {
+		page, ok := in.PageValue()
+		if !ok {
+			page = 1
+		}
+		*called = append(*called, page)
+
+		if page == errPage {
+			return nil, errors.New("fetch error")
+		}
+
+		out := &testListOutput{
+			Items:         pages[page-1],
+			RateLimitInfo: &gesa.RateLimitInformation{Remaining: 100 - page},
+		}
+		if page < len(pages) {
+			out.NextPage = gesa.NewPageNumber(page + 1)
+		}
+		return out, nil
+	}
+}
+
+func Test_Paginate(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+
+	cases := []struct {
+		name         string
+		in           *testListInput
+		errPage      int
+		breakAt      int
+		expect       []int
+		expectCalled []int
+		wantErr      bool
+	}{
+		{
+			name:         "ok: all pages",
+			in:           &testListInput{},
+			expect:       []int{1, 2, 3, 4, 5},
+			expectCalled: []int{1, 2, 3},
+		},
+		{
+			name:         "ok: start from page 2",
+			in:           &testListInput{Page: gesa.NewPageNumber(2)},
+			expect:       []int{3, 4, 5},
+			expectCalled: []int{2, 3},
+		},
+		{
+			name:         "ok: break",
+			in:           &testListInput{},
+			breakAt:      3,
+			expect:       []int{1, 2, 3},
+			expectCalled: []int{1, 2},
+		},
+		{
+			name:         "error: fetch error",
+			in:           &testListInput{},
+			errPage:      2,
+			expect:       []int{1, 2},
+			expectCalled: []int{1, 2},
+			wantErr:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			called := []int{}
+			items := []int{}
+			var gotErr error
+			for item, err := range gesa.Paginate(context.Background(), c.in, newTestFetcher(pages, c.errPage, &called)) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				items = append(items, item)
+				if c.breakAt > 0 && item == c.breakAt {
+					break
+				}
+			}
+
+			asst.Equal(c.expect, items)
+			asst.Equal(c.expectCalled, called)
+			if c.wantErr {
+				asst.Error(gotErr)
+				return
+			}
+			asst.NoError(gotErr)
+		})
+	}
+}
+
+func Test_Pages(t *testing.T) {
+	asst := assert.New(t)
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+
+	called := []int{}
+	remainings := []int{}
+	for out, err := range gesa.Pages(context.Background(), &testListInput{}, newTestFetcher(pages, 0, &called)) {
+		asst.NoError(err)
+		remainings = append(remainings, out.RateLimitInfo.Remaining)
+	}
+
+	asst.Equal([]int{1, 2, 3}, called)
+	asst.Equal([]int{99, 98, 97}, remainings)
+}
+
+func Test_Pages_ContextCanceled(t *testing.T) {
+	asst := assert.New(t)
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	called := []int{}
+	var gotErr error
+	for _, err := range gesa.Pages(ctx, &testListInput{}, newTestFetcher(pages, 0, &called)) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		cancel()
+	}
+
+	asst.Equal([]int{1}, called)
+	asst.ErrorIs(gotErr, context.Canceled)
+}
+
+func Test_Pages_NextPageNotIncreasing(t *testing.T) {
+	asst := assert.New(t)
+
+	called := 0
+	fetch := func(ctx context.Context, in *testListInput) (*testListOutput, error) {
+		called++
+		return &testListOutput{Items: []int{1}, NextPage: gesa.NewPageNumber(1)}, nil
+	}
+
+	for _, err := range gesa.Pages(context.Background(), &testListInput{Page: gesa.NewPageNumber(1)}, fetch) {
+		asst.NoError(err)
+	}
+
+	asst.Equal(1, called)
+}
@@ -0,0 +1,134 @@
+package gesa
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles requests proactively based on the rate limit information
+// returned by the esa API, so that requests never exceed the quota.
+// It is safe for concurrent use and can be shared by some clients
+// that use the same access token.
+type RateLimiter struct {
+	floor int
+
+	mu   sync.Mutex
+	info *RateLimitInformation
+	now  func() time.Time
+}
+
+// NewRateLimiter generates *RateLimiter.
+// Requests are blocked until the rate limit is reset
+// while the remaining number of requests is less than or equal to the floor.
+// If a negative value is passed, a value equivalent to 0 is used.
+func NewRateLimiter(floor int) *RateLimiter {
+	if floor < 0 {
+		floor = 0
+	}
+	return &RateLimiter{floor: floor, now: time.Now}
+}
+
+// Wait blocks until a request can be sent or the context is done.
+// When it returns nil, one request is reserved from the remaining number.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+
+		if err := wait(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve reserves one request and returns 0 if a request can be sent now.
+// Otherwise it returns the duration until the rate limit is reset.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.info == nil || l.info.Reset == nil {
+		return 0
+	}
+
+	d := l.info.Reset.Time().Sub(l.now())
+	if d <= 0 {
+		// The rate limit has been reset, so the remaining number is unknown.
+		l.info = nil
+		return 0
+	}
+
+	if l.info.Remaining <= l.floor {
+		return d
+	}
+
+	l.info.Remaining--
+	return 0
+}
+
+// Update records the rate limit information returned by the esa API.
+func (l *RateLimiter) Update(info *RateLimitInformation) {
+	if l == nil || info == nil || info.Reset == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := *info
+	if l.info == nil || l.info.Reset == nil {
+		l.info = &n
+		return
+	}
+
+	switch {
+	case n.Reset.SafeTimestamp() > l.info.Reset.SafeTimestamp():
+		// new rate limit window
+		l.info = &n
+	case n.Reset.SafeTimestamp() == l.info.Reset.SafeTimestamp():
+		// Responses of concurrent requests may arrive out of order.
+		if n.Remaining < l.info.Remaining {
+			l.info.Remaining = n.Remaining
+		}
+		l.info.Limit = n.Limit
+	}
+}
+
+// RateLimitInfo returns a copy of the last rate limit information.
+func (l *RateLimiter) RateLimitInfo() *RateLimitInformation {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.info == nil {
+		return nil
+	}
+
+	i := *l.info
+	return &i
+}
+
+func (l *RateLimiter) updateWithHeader(h http.Header) {
+	if l == nil {
+		return
+	}
+
+	if rli, err := GetRateLimitInformation(h); err == nil {
+		l.Update(rli)
+	}
+}
@@ -0,0 +1,231 @@
+package gesa_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateLimiter_Update(t *testing.T) {
+	ts := func(i int64) *gesa.Timestamp {
+		t := gesa.Timestamp(i)
+		return &t
+	}
+
+	cases := []struct {
+		name    string
+		updates []*gesa.RateLimitInformation
+		expect  *gesa.RateLimitInformation
+	}{
+		{
+			name:    "no update",
+			updates: nil,
+			expect:  nil,
+		},
+		{
+			name: "one update",
+			updates: []*gesa.RateLimitInformation{
+				{Limit: 75, Remaining: 10, Reset: ts(100)},
+			},
+			expect: &gesa.RateLimitInformation{Limit: 75, Remaining: 10, Reset: ts(100)},
+		},
+		{
+			name: "ignore without reset",
+			updates: []*gesa.RateLimitInformation{
+				{Limit: 75, Remaining: 10, Reset: ts(100)},
+				{Limit: 75, Remaining: 70},
+				nil,
+			},
+			expect: &gesa.RateLimitInformation{Limit: 75, Remaining: 10, Reset: ts(100)},
+		},
+		{
+			name: "same window, out of order",
+			updates: []*gesa.RateLimitInformation{
+				{Limit: 75, Remaining: 8, Reset: ts(100)},
+				{Limit: 75, Remaining: 9, Reset: ts(100)},
+			},
+			expect: &gesa.RateLimitInformation{Limit: 75, Remaining: 8, Reset: ts(100)},
+		},
+		{
+			name: "new window",
+			updates: []*gesa.RateLimitInformation{
+				{Limit: 75, Remaining: 1, Reset: ts(100)},
+				{Limit: 75, Remaining: 74, Reset: ts(200)},
+			},
+			expect: &gesa.RateLimitInformation{Limit: 75, Remaining: 74, Reset: ts(200)},
+		},
+		{
+			name: "old window",
+			updates: []*gesa.RateLimitInformation{
+				{Limit: 75, Remaining: 74, Reset: ts(200)},
+				{Limit: 75, Remaining: 1, Reset: ts(100)},
+			},
+			expect: &gesa.RateLimitInformation{Limit: 75, Remaining: 74, Reset: ts(200)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			l := gesa.NewRateLimiter(0)
+			for _, u := range c.updates {
+				l.Update(u)
+			}
+			asst.Equal(c.expect, l.RateLimitInfo())
+		})
+	}
+}
+
+func Test_RateLimiter_Wait(t *testing.T) {
+	now := time.Unix(1000, 0)
+	reset := gesa.Timestamp(1001)
+	pastReset := gesa.Timestamp(999)
+
+	cases := []struct {
+		name            string
+		floor           int
+		info            *gesa.RateLimitInformation
+		timeout         time.Duration
+		wantErr         bool
+		expectRemaining int
+		expectNil       bool
+	}{
+		{
+			name:      "ok: no information",
+			floor:     5,
+			info:      nil,
+			expectNil: true,
+		},
+		{
+			name:            "ok: remaining is more than floor",
+			floor:           5,
+			info:            &gesa.RateLimitInformation{Limit: 75, Remaining: 6, Reset: &reset},
+			expectRemaining: 5,
+		},
+		{
+			name:      "ok: already reset",
+			floor:     5,
+			info:      &gesa.RateLimitInformation{Limit: 75, Remaining: 0, Reset: &pastReset},
+			expectNil: true,
+		},
+		{
+			name:    "error: blocked until context is done",
+			floor:   5,
+			info:    &gesa.RateLimitInformation{Limit: 75, Remaining: 5, Reset: &reset},
+			timeout: 10 * time.Millisecond,
+			wantErr: true,
+		},
+		{
+			name:    "error: negative floor is treated as 0",
+			floor:   -1,
+			info:    &gesa.RateLimitInformation{Limit: 75, Remaining: 0, Reset: &reset},
+			timeout: 10 * time.Millisecond,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			l := gesa.NewRateLimiter(c.floor)
+			gesa.ExportSetRateLimiterNow(l, func() time.Time { return now })
+			l.Update(c.info)
+
+			ctx := context.Background()
+			if c.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+			}
+
+			err := l.Wait(ctx)
+			if c.wantErr {
+				asst.ErrorIs(err, context.DeadlineExceeded)
+				return
+			}
+
+			asst.NoError(err)
+			if c.expectNil {
+				asst.Nil(l.RateLimitInfo())
+				return
+			}
+			asst.Equal(c.expectRemaining, l.RateLimitInfo().Remaining)
+		})
+	}
+}
+
+func Test_RateLimiter_Wait_Nil(t *testing.T) {
+	var l *gesa.RateLimiter
+	assert.NoError(t, l.Wait(context.Background()))
+	assert.Nil(t, l.RateLimitInfo())
+}
+
+func Test_CallAPI_RateLimiter(t *testing.T) {
+	asst := assert.New(t)
+
+	reset := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	var mu sync.Mutex
+	remaining := 10
+	called := 0
+	hc := newMockClient(func(req *http.Request) *http.Response {
+		mu.Lock()
+		defer mu.Unlock()
+		called++
+		remaining--
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"X-Ratelimit-Limit":     {"10"},
+				"X-Ratelimit-Remaining": {strconv.Itoa(remaining)},
+				"X-Ratelimit-Reset":     {reset},
+			},
+			Body: io.NopCloser(strings.NewReader(`{}`)),
+		}
+	})
+
+	l := gesa.NewRateLimiter(3)
+	client, _ := gesa.NewClient(&gesa.NewClientInput{
+		HTTPClient:  hc,
+		AccessToken: "test-token",
+		RateLimiter: l,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The first request is sent without rate limit information.
+	err := client.CallAPI(ctx, "https://api.esa.io/:esa_api_version/teams", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+	asst.Nil(err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.CallAPI(ctx, "https://api.esa.io/:esa_api_version/teams", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+
+	// remaining: 9 -> 3 (floor)
+	asst.Equal(7, called)
+	asst.Equal(4, failed)
+	asst.Equal(3, l.RateLimitInfo().Remaining)
+}
@@ -0,0 +1,153 @@
+package gesa
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request should be sent again.
+type RetryPolicy interface {
+	// RetryDelay returns the duration to wait before the next attempt and
+	// whether the request should be retried.
+	// attempt is the number of attempts already made (starts at 1).
+	// eae is the error response of the esa API, and err is the error that occurred
+	// before receiving the response. Either of them is not nil.
+	RetryDelay(attempt int, method string, eae *EsaAPIError, err error) (time.Duration, bool)
+}
+
+const (
+	DefaultRetryMaxAttempts int           = 3
+	DefaultRetryBaseDelay   time.Duration = 1 * time.Second
+	DefaultRetryMaxDelay    time.Duration = 30 * time.Second
+)
+
+// BackoffRetryPolicy is a RetryPolicy with exponential backoff and jitter.
+//
+// A response with status 429 is retried for every method, waiting until the
+// time of x-ratelimit-reset if it is available.
+// Responses with status 502, 503 and 504 are retried only for idempotent methods.
+// Zero values of the fields are replaced with the default values.
+type BackoffRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay is the upper limit of the backoff delay.
+	MaxDelay time.Duration
+	// DisableJitter disables randomizing the backoff delay.
+	DisableJitter bool
+
+	now func() time.Time
+}
+
+var retryableStatusCodes = map[int]struct{}{
+	http.StatusBadGateway:         {},
+	http.StatusServiceUnavailable: {},
+	http.StatusGatewayTimeout:     {},
+}
+
+var idempotentMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodOptions: {},
+	http.MethodPut:     {},
+	http.MethodDelete:  {},
+}
+
+func (p *BackoffRetryPolicy) RetryDelay(attempt int, method string, eae *EsaAPIError, err error) (time.Duration, bool) {
+	if p == nil || eae == nil {
+		return 0, false
+	}
+
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRetryMaxAttempts
+	}
+	if attempt >= maxAttempts {
+		return 0, false
+	}
+
+	if eae.StatusCode == http.StatusTooManyRequests {
+		if d, ok := p.untilReset(eae.RateLimitInfo); ok {
+			return d, true
+		}
+		return p.backoff(attempt), true
+	}
+
+	if _, ok := retryableStatusCodes[eae.StatusCode]; !ok {
+		return 0, false
+	}
+	if _, ok := idempotentMethods[method]; !ok {
+		return 0, false
+	}
+
+	return p.backoff(attempt), true
+}
+
+func (p *BackoffRetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = DefaultRetryMaxDelay
+	}
+
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	if p.DisableJitter {
+		return d
+	}
+
+	// equal jitter: a random duration between d/2 and d
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+func (p *BackoffRetryPolicy) untilReset(rli *RateLimitInformation) (time.Duration, bool) {
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	return untilReset(rli, now())
+}
+
+// untilReset returns the duration from now until the rate limit is reset.
+func untilReset(rli *RateLimitInformation, now time.Time) (time.Duration, bool) {
+	if rli == nil || rli.Reset == nil {
+		return 0, false
+	}
+
+	d := rli.Reset.Time().Sub(now)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
+
+// wait blocks until the duration elapses or the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,240 @@
+package gesa_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BackoffRetryPolicy_RetryDelay(t *testing.T) {
+	now := time.Unix(1000, 0)
+	reset := gesa.Timestamp(1010)
+	pastReset := gesa.Timestamp(990)
+
+	cases := []struct {
+		name        string
+		policy      *gesa.BackoffRetryPolicy
+		attempt     int
+		method      string
+		eae         *gesa.EsaAPIError
+		err         error
+		expectRetry bool
+		expectDelay time.Duration
+	}{
+		{
+			name:        "retry: 429 with reset",
+			policy:      &gesa.BackoffRetryPolicy{},
+			attempt:     1,
+			method:      http.MethodPost,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusTooManyRequests, RateLimitInfo: &gesa.RateLimitInformation{Reset: &reset}},
+			expectRetry: true,
+			expectDelay: 10 * time.Second,
+		},
+		{
+			name:        "retry: 429 with past reset",
+			policy:      &gesa.BackoffRetryPolicy{},
+			attempt:     1,
+			method:      http.MethodGet,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusTooManyRequests, RateLimitInfo: &gesa.RateLimitInformation{Reset: &pastReset}},
+			expectRetry: true,
+			expectDelay: 0,
+		},
+		{
+			name:        "retry: 429 without reset",
+			policy:      &gesa.BackoffRetryPolicy{BaseDelay: time.Second, DisableJitter: true},
+			attempt:     1,
+			method:      http.MethodPatch,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusTooManyRequests},
+			expectRetry: true,
+			expectDelay: time.Second,
+		},
+		{
+			name:        "retry: 503 GET, second attempt",
+			policy:      &gesa.BackoffRetryPolicy{BaseDelay: time.Second, DisableJitter: true},
+			attempt:     2,
+			method:      http.MethodGet,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusServiceUnavailable},
+			expectRetry: true,
+			expectDelay: 2 * time.Second,
+		},
+		{
+			name:        "retry: 502 DELETE, capped by max delay",
+			policy:      &gesa.BackoffRetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 3 * time.Second, DisableJitter: true},
+			attempt:     5,
+			method:      http.MethodDelete,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusBadGateway},
+			expectRetry: true,
+			expectDelay: 3 * time.Second,
+		},
+		{
+			name:        "no retry: 504 POST",
+			policy:      &gesa.BackoffRetryPolicy{},
+			attempt:     1,
+			method:      http.MethodPost,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusGatewayTimeout},
+			expectRetry: false,
+		},
+		{
+			name:        "no retry: 500",
+			policy:      &gesa.BackoffRetryPolicy{},
+			attempt:     1,
+			method:      http.MethodGet,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusInternalServerError},
+			expectRetry: false,
+		},
+		{
+			name:        "no retry: max attempts",
+			policy:      &gesa.BackoffRetryPolicy{},
+			attempt:     gesa.DefaultRetryMaxAttempts,
+			method:      http.MethodGet,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusTooManyRequests},
+			expectRetry: false,
+		},
+		{
+			name:        "no retry: not api error",
+			policy:      &gesa.BackoffRetryPolicy{},
+			attempt:     1,
+			method:      http.MethodGet,
+			err:         errors.New("some error"),
+			expectRetry: false,
+		},
+		{
+			name:        "no retry: nil policy",
+			policy:      nil,
+			attempt:     1,
+			method:      http.MethodGet,
+			eae:         &gesa.EsaAPIError{StatusCode: http.StatusTooManyRequests},
+			expectRetry: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			if c.policy != nil {
+				gesa.ExportSetBackoffRetryPolicyNow(c.policy, func() time.Time { return now })
+			}
+
+			d, retry := c.policy.RetryDelay(c.attempt, c.method, c.eae, c.err)
+			asst.Equal(c.expectRetry, retry)
+			asst.Equal(c.expectDelay, d)
+		})
+	}
+}
+
+func Test_BackoffRetryPolicy_RetryDelay_Jitter(t *testing.T) {
+	p := &gesa.BackoffRetryPolicy{BaseDelay: 100 * time.Millisecond}
+	eae := &gesa.EsaAPIError{StatusCode: http.StatusServiceUnavailable}
+
+	for range 100 {
+		d, retry := p.RetryDelay(2, http.MethodGet, eae, nil)
+		assert.True(t, retry)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 200*time.Millisecond)
+	}
+}
+
+func Test_CallAPI_Retry(t *testing.T) {
+	cases := []struct {
+		name         string
+		statusCodes  []int
+		method       string
+		policy       gesa.RetryPolicy
+		expectCalled int
+		wantErr      bool
+	}{
+		{
+			name:         "ok: succeeded after retry",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			method:       http.MethodGet,
+			policy:       &gesa.BackoffRetryPolicy{BaseDelay: time.Millisecond},
+			expectCalled: 3,
+		},
+		{
+			name:         "error: exceeded max attempts",
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			method:       http.MethodPost,
+			policy:       &gesa.BackoffRetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			expectCalled: 2,
+			wantErr:      true,
+		},
+		{
+			name:         "error: not retryable",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			method:       http.MethodPost,
+			policy:       &gesa.BackoffRetryPolicy{BaseDelay: time.Millisecond},
+			expectCalled: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "error: no retry policy",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			method:       http.MethodGet,
+			policy:       nil,
+			expectCalled: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			called := 0
+			hc := newMockClient(func(req *http.Request) *http.Response {
+				sc := c.statusCodes[called]
+				called++
+				return &http.Response{
+					StatusCode: sc,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			})
+
+			client, _ := gesa.NewClient(&gesa.NewClientInput{
+				HTTPClient:  hc,
+				AccessToken: "test-token",
+				RetryPolicy: c.policy,
+			})
+
+			err := client.CallAPI(context.Background(), "https://api.esa.io/:esa_api_version/teams", c.method, &mockAPIParameter{}, &mockAPIOutput{})
+			asst.Equal(c.expectCalled, called)
+			if c.wantErr {
+				asst.Error(err)
+				return
+			}
+			asst.Nil(err)
+		})
+	}
+}
+
+func Test_CallAPI_Retry_ContextCanceled(t *testing.T) {
+	asst := assert.New(t)
+
+	hc := newMockClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}
+	})
+
+	client, _ := gesa.NewClient(&gesa.NewClientInput{
+		HTTPClient:  hc,
+		AccessToken: "test-token",
+		RetryPolicy: &gesa.BackoffRetryPolicy{BaseDelay: time.Hour},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := client.CallAPI(ctx, "https://api.esa.io/:esa_api_version/teams", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+	asst.ErrorIs(err, context.DeadlineExceeded)
+}
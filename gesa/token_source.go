@@ -0,0 +1,104 @@
+package gesa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource provides the access token used for each request.
+// Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is an adapter to use an ordinary function as TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type staticTokenSource struct {
+	token string
+}
+
+// StaticTokenSource returns TokenSource that always provides the same token.
+func StaticTokenSource(token string) TokenSource {
+	return &staticTokenSource{token: token}
+}
+
+func (s *staticTokenSource) Token(_ context.Context) (string, error) {
+	if s.token == "" {
+		return "", errors.New("access token is empty")
+	}
+	return s.token, nil
+}
+
+type envTokenSource struct {
+	key string
+}
+
+// EnvTokenSource returns TokenSource that reads the token from the environment variable
+// every time a request is sent.
+func EnvTokenSource(key string) TokenSource {
+	return &envTokenSource{key: key}
+}
+
+func (s *envTokenSource) Token(_ context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(s.key))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is empty", s.key)
+	}
+	return token, nil
+}
+
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// FileTokenSource returns TokenSource that reads the token from the file.
+// The file is read again only when its modification time or size has changed,
+// so the token can be rotated by rewriting the file.
+func FileTokenSource(path string) TokenSource {
+	return &fileTokenSource{path: path}
+}
+
+func (s *fileTokenSource) Token(_ context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return "", err
+	}
+
+	if s.token != "" && fi.ModTime().Equal(s.modTime) && fi.Size() == s.size {
+		return s.token, nil
+	}
+
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("access token file %s is empty", s.path)
+	}
+
+	s.token = token
+	s.modTime = fi.ModTime()
+	s.size = fi.Size()
+
+	return s.token, nil
+}
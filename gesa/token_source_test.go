@@ -0,0 +1,175 @@
+package gesa_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StaticTokenSource(t *testing.T) {
+	cases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"ok", "test-token", false},
+		{"ng: empty", "", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			token, err := gesa.StaticTokenSource(c.token).Token(context.Background())
+			if c.wantErr {
+				asst.Error(err)
+				asst.Empty(token)
+				return
+			}
+			asst.NoError(err)
+			asst.Equal(c.token, token)
+		})
+	}
+}
+
+func Test_EnvTokenSource(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		expect  string
+		wantErr bool
+	}{
+		{"ok", "test-token", "test-token", false},
+		{"ok: trim spaces", " test-token\n", "test-token", false},
+		{"ng: empty", "", "", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			tt.Setenv("GESA_TEST_ACCESS_TOKEN", c.value)
+
+			token, err := gesa.EnvTokenSource("GESA_TEST_ACCESS_TOKEN").Token(context.Background())
+			if c.wantErr {
+				asst.Error(err)
+				asst.Empty(token)
+				return
+			}
+			asst.NoError(err)
+			asst.Equal(c.expect, token)
+		})
+	}
+}
+
+func Test_FileTokenSource(t *testing.T) {
+	asst := assert.New(t)
+	path := filepath.Join(t.TempDir(), "token")
+	ts := gesa.FileTokenSource(path)
+
+	// not exists
+	_, err := ts.Token(context.Background())
+	asst.Error(err)
+
+	// empty
+	asst.NoError(os.WriteFile(path, []byte("\n"), 0600))
+	_, err = ts.Token(context.Background())
+	asst.Error(err)
+
+	asst.NoError(os.WriteFile(path, []byte("test-token-1\n"), 0600))
+	token, err := ts.Token(context.Background())
+	asst.NoError(err)
+	asst.Equal("test-token-1", token)
+
+	// rotated
+	asst.NoError(os.WriteFile(path, []byte("test-token-02\n"), 0600))
+	mt := time.Now().Add(time.Second)
+	asst.NoError(os.Chtimes(path, mt, mt))
+	token, err = ts.Token(context.Background())
+	asst.NoError(err)
+	asst.Equal("test-token-02", token)
+}
+
+func Test_TokenSourceFunc(t *testing.T) {
+	asst := assert.New(t)
+	ts := gesa.TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "test-token", nil
+	})
+
+	token, err := ts.Token(context.Background())
+	asst.NoError(err)
+	asst.Equal("test-token", token)
+}
+
+func Test_CallAPI_TokenSource(t *testing.T) {
+	cases := []struct {
+		name         string
+		in           *gesa.NewClientInput
+		expectHeader string
+		expectToken  string
+		wantErr      bool
+	}{
+		{
+			name: "ok: access token",
+			in: &gesa.NewClientInput{
+				AccessToken: "test-token",
+			},
+			expectHeader: "Bearer test-token",
+			expectToken:  "test-token",
+		},
+		{
+			name: "ok: token source takes precedence",
+			in: &gesa.NewClientInput{
+				AccessToken: "test-token",
+				TokenSource: gesa.StaticTokenSource("test-token-from-source"),
+			},
+			expectHeader: "Bearer test-token-from-source",
+			expectToken:  "test-token-from-source",
+		},
+		{
+			name: "ng: token source error",
+			in: &gesa.NewClientInput{
+				TokenSource: gesa.TokenSourceFunc(func(ctx context.Context) (string, error) {
+					return "", errors.New("token error")
+				}),
+			},
+			expectToken: "",
+			wantErr:     true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			var header http.Header
+			c.in.HTTPClient = newMockClient(func(req *http.Request) *http.Response {
+				header = req.Header
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			})
+
+			client, err := gesa.NewClient(c.in)
+			asst.NoError(err)
+			asst.Equal(c.expectToken, client.AccessToken())
+
+			err = client.CallAPI(context.Background(), "test-endpoint", http.MethodGet, &mockAPIParameter{}, &mockAPIOutput{})
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(header)
+				return
+			}
+			asst.Nil(err)
+			asst.Equal(c.expectHeader, header.Get("Authorization"))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Package gesamock provides a fake of gesa.IAPIClient for unit tests.
+//
+// The responses are programmed for each operation name such as "post.GetPost",
+// and the calls are recorded without sending any requests.
+//
+//	m := gesamock.New()
+//	m.Respond("post.GetPost", &types.GetPostOutput{Post: models.Post{Number: 1}})
+//	m.Fail("post.UpdatePost", gesamock.APIError(http.StatusForbidden, "forbidden", "Forbidden"))
+//
+//	out, err := post.GetPost(ctx, m, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+//	m.AssertCalled(t, "post.GetPost", 1)
+package gesamock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/internal"
+)
+
+// ErrUnexpectedCall is returned for the calls of the operations that have no handlers.
+var ErrUnexpectedCall = errors.New("gesamock: unexpected call")
+
+// Call is a recorded call of CallAPI.
+type Call struct {
+	// Operation is the operation name such as "post.GetPost".
+	Operation string
+	Method    string
+	// Endpoint is the URL with the path and query parameters resolved.
+	Endpoint string
+	// Path is the path of Endpoint such as "/v1/teams/docs/posts/1".
+	Path  string
+	Query url.Values
+	// Body is the request body such as the JSON payload.
+	Body []byte
+	// Input is the input passed to the API function.
+	Input gesa.IInput
+	// Index is the number of the previous calls of the operation.
+	Index int
+}
+
+// Handler returns the output of the call. The output must be the pointer to the output type
+// of the operation such as *types.GetPostOutput, or nil to leave the output empty.
+type Handler func(ctx context.Context, call *Call) (any, error)
+
+// Client is a fake of gesa.IAPIClient. It is safe for concurrent use.
+type Client struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+	calls    []*Call
+}
+
+var _ gesa.IAPIClient = (*Client)(nil)
+
+// New returns a client that has no handlers.
+func New() *Client {
+	return &Client{handlers: map[string]Handler{}}
+}
+
+// Handle sets the handler of the operation.
+func (c *Client) Handle(operation string, h Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[operation] = h
+}
+
+// Respond sets the output returned for every call of the operation.
+func (c *Client) Respond(operation string, out any) {
+	c.Handle(operation, func(context.Context, *Call) (any, error) {
+		return out, nil
+	})
+}
+
+// Fail sets the error returned for every call of the operation.
+func (c *Client) Fail(operation string, err error) {
+	c.Handle(operation, func(context.Context, *Call) (any, error) {
+		return nil, err
+	})
+}
+
+// APIError returns the same error as the error response of the esa API with the status code.
+func APIError(statusCode int, errorCode, message string) error {
+	return gesa.NewAPIError(&gesa.EsaAPIError{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Error:      errorCode,
+		Message:    message,
+	})
+}
+
+// CallAPI records the call and returns the output of the handler of the operation.
+// Like gesa.Client, the error of the input is returned without calling the handler,
+// and the errors are wrapped as *gesa.GesaError.
+func (c *Client) CallAPI(ctx context.Context, endpoint, method string, p gesa.IInput, r gesa.IOutput) error {
+	return gesa.WrapError(c.callAPI(ctx, endpoint, method, p, r))
+}
+
+func (c *Client) callAPI(ctx context.Context, endpoint, method string, p gesa.IInput, r gesa.IOutput) error {
+	if p == nil {
+		return &gesa.ParameterError{Message: "parameter is nil"}
+	}
+	eap, err := p.EsaAPIParameter()
+	if err != nil {
+		return err
+	}
+	if eap == nil {
+		return &gesa.ParameterError{Message: "parameter for esa API is nil"}
+	}
+
+	call := &Call{
+		Operation: gesa.OperationName(ctx),
+		Method:    method,
+		Input:     p,
+	}
+	call.Endpoint, err = gesa.DefaultAPIVersion.ResolveEndpoint(internal.ResolveEndpoint(endpoint, eap.Path, eap.Query))
+	if err != nil {
+		return err
+	}
+	if u, err := url.Parse(call.Endpoint); err == nil {
+		call.Path = u.Path
+		call.Query = u.Query()
+	}
+	if eap.Body != nil {
+		if call.Body, err = io.ReadAll(eap.Body); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	for _, prev := range c.calls {
+		if prev.Operation == call.Operation {
+			call.Index++
+		}
+	}
+	c.calls = append(c.calls, call)
+	h, ok := c.handlers[call.Operation]
+	c.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s %s (operation %q)", ErrUnexpectedCall, method, call.Path, call.Operation)
+	}
+
+	out, err := h(ctx, call)
+	if err != nil {
+		return err
+	}
+	return setOutput(r, out)
+}
+
+// setOutput copies the output returned by the handler to the output of the API function.
+func setOutput(r gesa.IOutput, out any) error {
+	if out == nil {
+		return nil
+	}
+	if r == nil {
+		return fmt.Errorf("gesamock: the output of the API function is nil")
+	}
+
+	dst := reflect.ValueOf(r)
+	src := reflect.ValueOf(out)
+	if src.Type() == dst.Type() {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if dst.Kind() != reflect.Pointer || src.Type() != dst.Type().Elem() {
+		return fmt.Errorf("gesamock: the output of the handler is %T, but %T is expected", out, r)
+	}
+
+	dst.Elem().Set(src)
+	return nil
+}
+
+// Calls returns the recorded calls. If operations are given, only their calls are returned.
+func (c *Client) Calls(operations ...string) []*Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := []*Call{}
+	for _, call := range c.calls {
+		if len(operations) == 0 || slices.Contains(operations, call.Operation) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes the recorded calls. The handlers are kept.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// TestingT is the subset of *testing.T used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertCalled asserts that the operation was called the number of times.
+func (c *Client) AssertCalled(t TestingT, operation string, times int) bool {
+	t.Helper()
+	if n := len(c.Calls(operation)); n != times {
+		t.Errorf("gesamock: %s was called %d times, but %d times are expected", operation, n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled asserts that the operation was not called.
+func (c *Client) AssertNotCalled(t TestingT, operation string) bool {
+	t.Helper()
+	return c.AssertCalled(t, operation, 0)
+}
+
+// AssertExpectations asserts that every operation with a handler was called
+// and no operation without a handler was called.
+func (c *Client) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	c.mu.Lock()
+	called := map[string]bool{}
+	unexpected := []string{}
+	for _, call := range c.calls {
+		called[call.Operation] = true
+		if _, ok := c.handlers[call.Operation]; !ok {
+			unexpected = append(unexpected, call.Operation)
+		}
+	}
+	notCalled := []string{}
+	for op := range c.handlers {
+		if !called[op] {
+			notCalled = append(notCalled, op)
+		}
+	}
+	c.mu.Unlock()
+
+	ok := true
+	if len(notCalled) > 0 {
+		slices.Sort(notCalled)
+		t.Errorf("gesamock: not called: %s", strings.Join(notCalled, ", "))
+		ok = false
+	}
+	if len(unexpected) > 0 {
+		t.Errorf("gesamock: unexpected calls: %s", strings.Join(unexpected, ", "))
+		ok = false
+	}
+	return ok
+}
@@ -0,0 +1,306 @@
+package gesamock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/michimani/go-esa/esa"
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	revisiontypes "github.com/michimani/go-esa/esaapi/revision/types"
+	statstypes "github.com/michimani/go-esa/esaapi/stats/types"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/gesamock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func Test_Client_CallAPI(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		setup     func(m *gesamock.Client)
+		call      func(m *gesamock.Client) (any, error)
+		expect    any
+		expectErr error
+		wantErr   bool
+	}{
+		{
+			name: "ok: respond",
+			setup: func(m *gesamock.Client) {
+				m.Respond("post.GetPost", &types.GetPostOutput{Post: models.Post{Number: 1, Name: "hello"}})
+			},
+			call: func(m *gesamock.Client) (any, error) {
+				out, err := post.GetPost(ctx, m, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+				if err != nil {
+					return nil, err
+				}
+				return out.Post, nil
+			},
+			expect: models.Post{Number: 1, Name: "hello"},
+		},
+		{
+			name: "ok: respond with a value",
+			setup: func(m *gesamock.Client) {
+				m.Respond("stats.GetStats", statstypes.GetStatsOutput{Members: 3})
+			},
+			call: func(m *gesamock.Client) (any, error) {
+				out, err := esa.New(m).Team("docs").Stats.Get(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return out.Members, nil
+			},
+			expect: 3,
+		},
+		{
+			name: "ok: nil output",
+			setup: func(m *gesamock.Client) {
+				m.Respond("post.DeletePost", nil)
+			},
+			call: func(m *gesamock.Client) (any, error) {
+				_, err := post.DeletePost(ctx, m, &types.DeletePostInput{TeamName: "docs", PostNumber: 1})
+				return nil, err
+			},
+		},
+		{
+			name: "ng: api error",
+			setup: func(m *gesamock.Client) {
+				m.Fail("post.GetPost", gesamock.APIError(http.StatusNotFound, "not_found", "Not found"))
+			},
+			call: func(m *gesamock.Client) (any, error) {
+				return post.GetPost(ctx, m, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+			},
+			expectErr: gesa.ErrNotFound,
+			wantErr:   true,
+		},
+		{
+			name: "ng: unexpected call",
+			call: func(m *gesamock.Client) (any, error) {
+				return post.GetPost(ctx, m, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+			},
+			expectErr: gesamock.ErrUnexpectedCall,
+			wantErr:   true,
+		},
+		{
+			name: "ng: invalid parameter",
+			setup: func(m *gesamock.Client) {
+				m.Respond("post.GetPost", &types.GetPostOutput{})
+			},
+			call: func(m *gesamock.Client) (any, error) {
+				return post.GetPost(ctx, m, &types.GetPostInput{TeamName: "docs"})
+			},
+			expectErr: gesa.ErrInvalidParameter,
+			wantErr:   true,
+		},
+		{
+			name: "ng: output of another type",
+			setup: func(m *gesamock.Client) {
+				m.Respond("post.GetPost", &types.UpdatePostOutput{})
+			},
+			call: func(m *gesamock.Client) (any, error) {
+				return post.GetPost(ctx, m, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			m := gesamock.New()
+			if c.setup != nil {
+				c.setup(m)
+			}
+
+			got, err := c.call(m)
+			if c.wantErr {
+				asst.Error(err)
+				if c.expectErr != nil {
+					asst.ErrorIs(err, c.expectErr)
+				}
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, got)
+		})
+	}
+}
+
+func Test_Client_Calls(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+
+	m := gesamock.New()
+	m.Handle("post.GetPost", func(ctx context.Context, call *gesamock.Call) (any, error) {
+		in := call.Input.(*types.GetPostInput)
+		return &types.GetPostOutput{Post: models.Post{Number: in.PostNumber, BodyMD: "# hello\n", RevisionNumber: 1}}, nil
+	})
+	m.Handle("post.UpdatePost", func(ctx context.Context, call *gesamock.Call) (any, error) {
+		// the first update is overlapped
+		return &types.UpdatePostOutput{Post: models.Post{Number: 1, BodyMD: "merged", RevisionNumber: 3}, Overlapped: call.Index == 0}, nil
+	})
+	m.Handle("revision.GetRevision", func(ctx context.Context, call *gesamock.Call) (any, error) {
+		in := call.Input.(*revisiontypes.GetRevisionInput)
+		return &revisiontypes.GetRevisionOutput{Revision: models.Revision{Number: in.RevisionNumber, BodyMD: "# hello\nremote\n"}}, nil
+	})
+
+	remote := ""
+	out, err := post.Modify(ctx, m, "docs", 1, func(p *models.Post) error {
+		p.BodyMD += "world\n"
+		return nil
+	}, post.WithMergeFunc(func(ctx context.Context, c *post.ConflictError) (string, error) {
+		remote = c.Remote
+		return "resolved", nil
+	}))
+	asst.NoError(err)
+	asst.Equal("merged", out.BodyMD)
+	asst.Equal("# hello\nremote\n", remote)
+
+	asst.True(m.AssertCalled(t, "post.GetPost", 1))
+	asst.True(m.AssertCalled(t, "revision.GetRevision", 1))
+	asst.Equal("/v1/teams/docs/posts/1/revisions/2", m.Calls("revision.GetRevision")[0].Path)
+	asst.True(m.AssertCalled(t, "post.UpdatePost", 2))
+	asst.True(m.AssertNotCalled(t, "post.DeletePost"))
+	asst.True(m.AssertExpectations(t))
+
+	calls := m.Calls("post.UpdatePost")
+	if asst.Len(calls, 2) {
+		asst.Equal(http.MethodPatch, calls[0].Method)
+		asst.Equal("https://api.esa.io/v1/teams/docs/posts/1", calls[0].Endpoint)
+		asst.Equal("/v1/teams/docs/posts/1", calls[0].Path)
+		asst.Contains(string(calls[0].Body), `"body_md":"# hello\nworld\n"`)
+		asst.Equal(1, calls[1].Index)
+		asst.Contains(string(calls[1].Body), `"body_md":"resolved"`)
+	}
+	asst.Len(m.Calls(), 4)
+
+	m.Reset()
+	asst.Empty(m.Calls())
+}
+
+func Test_Client_Query(t *testing.T) {
+	asst := assert.New(t)
+
+	m := gesamock.New()
+	m.Respond("post.ListPosts", &types.ListPostsOutput{})
+
+	_, err := post.ListPosts(context.Background(), m, &types.ListPostsInput{TeamName: "docs", Q: "in:dev", Page: gesa.NewPageNumber(2)})
+	asst.NoError(err)
+
+	calls := m.Calls()
+	if asst.Len(calls, 1) {
+		asst.Equal("/v1/teams/docs/posts", calls[0].Path)
+		asst.Equal("in:dev", calls[0].Query.Get("q"))
+		asst.Equal("2", calls[0].Query.Get("page"))
+		asst.Nil(calls[0].Body)
+	}
+}
+
+func Test_Client_Assertions(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+
+	m := gesamock.New()
+	m.Respond("post.GetPost", &types.GetPostOutput{})
+	m.Respond("post.DeletePost", nil)
+	_, err := post.ListPosts(ctx, m, &types.ListPostsInput{TeamName: "docs"})
+	asst.True(errors.Is(err, gesamock.ErrUnexpectedCall))
+
+	ft := &fakeT{}
+	asst.False(m.AssertCalled(ft, "post.GetPost", 1))
+	asst.False(m.AssertNotCalled(ft, "post.ListPosts"))
+	asst.False(m.AssertExpectations(ft))
+	asst.Equal([]string{
+		"gesamock: post.GetPost was called 0 times, but 1 times are expected",
+		"gesamock: post.ListPosts was called 1 times, but 0 times are expected",
+		"gesamock: not called: post.DeletePost, post.GetPost",
+		"gesamock: unexpected calls: post.ListPosts",
+	}, ft.errors)
+}
+
+func Test_Client_ErrorTypes(t *testing.T) {
+	ctx := context.Background()
+	errHandler := errors.New("handler error")
+
+	s := esatest.NewServer()
+	defer s.Close()
+	s.AddTeam(models.Team{Name: "docs"})
+	live, _ := s.NewClient("token")
+
+	m := gesamock.New()
+	m.Fail("post.GetPost", gesamock.APIError(http.StatusNotFound, "not_found", "Not found"))
+	m.Fail("post.DeletePost", errHandler)
+
+	cases := []struct {
+		name      string
+		clients   []gesa.IAPIClient
+		call      func(c gesa.IAPIClient) error
+		expectErr error
+	}{
+		{
+			name:    "invalid parameter",
+			clients: []gesa.IAPIClient{live, m},
+			call: func(c gesa.IAPIClient) error {
+				_, err := post.GetPost(ctx, c, &types.GetPostInput{TeamName: "docs"})
+				return err
+			},
+			expectErr: gesa.ErrInvalidParameter,
+		},
+		{
+			name:    "api error",
+			clients: []gesa.IAPIClient{live, m},
+			call: func(c gesa.IAPIClient) error {
+				_, err := post.GetPost(ctx, c, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+				return err
+			},
+			expectErr: gesa.ErrNotFound,
+		},
+		{
+			name:    "error of the handler",
+			clients: []gesa.IAPIClient{m},
+			call: func(c gesa.IAPIClient) error {
+				_, err := post.DeletePost(ctx, c, &types.DeletePostInput{TeamName: "docs", PostNumber: 1})
+				return err
+			},
+			expectErr: errHandler,
+		},
+		{
+			name:    "unexpected call",
+			clients: []gesa.IAPIClient{m},
+			call: func(c gesa.IAPIClient) error {
+				_, err := post.ListPosts(ctx, c, &types.ListPostsInput{TeamName: "docs"})
+				return err
+			},
+			expectErr: gesamock.ErrUnexpectedCall,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			for _, client := range c.clients {
+				err := c.call(client)
+				var ge *gesa.GesaError
+				asst.True(errors.As(err, &ge), "%T: %v", client, err)
+				asst.ErrorIs(err, c.expectErr)
+			}
+		})
+	}
+}
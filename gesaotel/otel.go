@@ -0,0 +1,176 @@
+// Package gesaotel provides gesa.Instrumentation with OpenTelemetry.
+//
+// It is a separate module so that users who do not use OpenTelemetry do not depend on it.
+//
+//	inst, err := gesaotel.New()
+//	client, err := gesa.NewClient(&gesa.NewClientInput{
+//		AccessToken:     token,
+//		Instrumentation: inst,
+//	})
+package gesaotel
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/michimani/go-esa/gesa"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of tracers and meters.
+const ScopeName = "github.com/michimani/go-esa/gesaotel"
+
+// Attribute keys recorded to spans and metrics.
+const (
+	AttributeOperation          = attribute.Key("esa.operation")
+	AttributeTeamName           = attribute.Key("esa.team_name")
+	AttributeAttempts           = attribute.Key("esa.attempts")
+	AttributeRateLimitRemaining = attribute.Key("esa.rate_limit.remaining")
+	AttributeMethod             = attribute.Key("http.request.method")
+	AttributeStatusCode         = attribute.Key("http.response.status_code")
+)
+
+// Metric names.
+const (
+	MetricRequests    = "esa.client.requests"
+	MetricDuration    = "esa.client.duration"
+	MetricRateLimited = "esa.client.rate_limited"
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures Instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider (default: the global tracer provider).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider (default: the global meter provider).
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// Instrumentation creates a span per call of gesa.Client.CallAPI and records metrics.
+type Instrumentation struct {
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+	rateLimited metric.Int64Counter
+}
+
+var _ gesa.Instrumentation = (*Instrumentation)(nil)
+
+// New returns Instrumentation with OpenTelemetry.
+func New(opts ...Option) (*Instrumentation, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	requests, err := meter.Int64Counter(MetricRequests,
+		metric.WithDescription("Number of requests sent to the esa API, including retries."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram(MetricDuration,
+		metric.WithDescription("Duration of calls of the esa API, including retries."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimited, err := meter.Int64Counter(MetricRateLimited,
+		metric.WithDescription("Number of responses with 429 Too Many Requests from the esa API."),
+		metric.WithUnit("{response}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		tracer:      cfg.tracerProvider.Tracer(ScopeName),
+		requests:    requests,
+		duration:    duration,
+		rateLimited: rateLimited,
+	}, nil
+}
+
+// spanName returns the span name such as "post.ListPosts".
+func spanName(info gesa.CallInfo) string {
+	if info.Operation != "" {
+		return info.Operation
+	}
+	return "esa " + info.Method
+}
+
+// StartCall implements gesa.Instrumentation.
+func (i *Instrumentation) StartCall(ctx context.Context, info gesa.CallInfo) (context.Context, func(gesa.CallResult)) {
+	attrs := []attribute.KeyValue{
+		AttributeOperation.String(info.Operation),
+		AttributeMethod.String(info.Method),
+	}
+	if info.TeamName != "" {
+		attrs = append(attrs, AttributeTeamName.String(info.TeamName))
+	}
+
+	ctx, span := i.tracer.Start(ctx, spanName(info),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return ctx, func(r gesa.CallResult) {
+		defer span.End()
+
+		span.SetAttributes(AttributeAttempts.Int(r.Attempts))
+		if r.StatusCode != 0 {
+			span.SetAttributes(AttributeStatusCode.Int(r.StatusCode))
+		}
+		if r.RateLimitInfo != nil {
+			span.SetAttributes(AttributeRateLimitRemaining.Int(r.RateLimitInfo.Remaining))
+		}
+		if r.Err != nil {
+			span.RecordError(r.Err)
+			span.SetStatus(codes.Error, r.Err.Error())
+		}
+
+		// The requests are counted per attempt with its own status code, so that the
+		// retried errors are recorded even if the last attempt succeeds.
+		for _, code := range r.StatusCodes {
+			i.requests.Add(ctx, 1, metric.WithAttributes(withStatusCode(attrs, code)...))
+		}
+		set := metric.WithAttributes(withStatusCode(attrs, r.StatusCode)...)
+		i.duration.Record(ctx, r.Duration.Seconds(), set)
+		if r.RateLimited > 0 {
+			i.rateLimited.Add(ctx, int64(r.RateLimited), metric.WithAttributes(
+				withStatusCode(attrs, http.StatusTooManyRequests)...))
+		}
+	}
+}
+
+// withStatusCode returns a copy of attrs with the status code, or without it if the code is 0.
+func withStatusCode(attrs []attribute.KeyValue, code int) []attribute.KeyValue {
+	a := make([]attribute.KeyValue, len(attrs), len(attrs)+1)
+	copy(a, attrs)
+	if code != 0 {
+		a = append(a, AttributeStatusCode.Int(code))
+	}
+	return a
+}
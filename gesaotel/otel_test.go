@@ -0,0 +1,212 @@
+package gesaotel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/gesaotel"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func attrValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func sumOf(rm metricdata.ResourceMetrics, name string) int64 {
+	var sum int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			if s, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range s.DataPoints {
+					sum += dp.Value
+				}
+			}
+		}
+	}
+	return sum
+}
+
+func histogramCount(rm metricdata.ResourceMetrics, name string) uint64 {
+	var count uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			if h, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				for _, dp := range h.DataPoints {
+					count += dp.Count
+				}
+			}
+		}
+	}
+	return count
+}
+
+func Test_Instrumentation(t *testing.T) {
+	cases := []struct {
+		name              string
+		opts              []esatest.Option
+		calls             int
+		teamName          string
+		expectStatus      int64
+		expectCode        codes.Code
+		expectRemaining   int64
+		expectRequests    int64
+		expectRateLimited int64
+	}{
+		{
+			name:            "ok",
+			calls:           1,
+			teamName:        "docs",
+			expectStatus:    200,
+			expectCode:      codes.Unset,
+			expectRemaining: 74,
+			expectRequests:  1,
+		},
+		{
+			name:            "ng: not found",
+			calls:           1,
+			teamName:        "unknown",
+			expectStatus:    404,
+			expectCode:      codes.Error,
+			expectRemaining: 74,
+			expectRequests:  1,
+		},
+		{
+			name:              "ng: rate limited",
+			opts:              []esatest.Option{esatest.WithRateLimit(1, time.Hour)},
+			calls:             2,
+			teamName:          "docs",
+			expectStatus:      429,
+			expectCode:        codes.Error,
+			expectRemaining:   0,
+			expectRequests:    2,
+			expectRateLimited: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			spans := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			inst, err := gesaotel.New(gesaotel.WithTracerProvider(tp), gesaotel.WithMeterProvider(mp))
+			if !asst.NoError(err) {
+				return
+			}
+
+			s := esatest.NewServer(c.opts...)
+			defer s.Close()
+			s.AddPost("docs", models.Post{Name: "hello"})
+
+			client, _ := gesa.NewClient(&gesa.NewClientInput{
+				AccessToken:     "test-token",
+				BaseURL:         s.URL,
+				Instrumentation: inst,
+			})
+
+			for i := 0; i < c.calls; i++ {
+				post.ListPosts(context.Background(), client, &types.ListPostsInput{TeamName: c.teamName})
+			}
+
+			ended := spans.Ended()
+			if !asst.Len(ended, c.calls) {
+				return
+			}
+			span := ended[len(ended)-1]
+			asst.Equal("post.ListPosts", span.Name())
+			asst.Equal(trace.SpanKindClient, span.SpanKind())
+			asst.Equal(c.expectCode, span.Status().Code)
+
+			attrs := span.Attributes()
+			v, _ := attrValue(attrs, gesaotel.AttributeTeamName)
+			asst.Equal(c.teamName, v.AsString())
+			v, _ = attrValue(attrs, gesaotel.AttributeStatusCode)
+			asst.Equal(c.expectStatus, v.AsInt64())
+			v, _ = attrValue(attrs, gesaotel.AttributeRateLimitRemaining)
+			asst.Equal(c.expectRemaining, v.AsInt64())
+			v, _ = attrValue(attrs, gesaotel.AttributeMethod)
+			asst.Equal("GET", v.AsString())
+
+			rm := metricdata.ResourceMetrics{}
+			if !asst.NoError(reader.Collect(context.Background(), &rm)) {
+				return
+			}
+			asst.Equal(c.expectRequests, sumOf(rm, gesaotel.MetricRequests))
+			asst.Equal(c.expectRateLimited, sumOf(rm, gesaotel.MetricRateLimited))
+			asst.Equal(uint64(c.calls), histogramCount(rm, gesaotel.MetricDuration))
+		})
+	}
+}
+
+func Test_Instrumentation_SpanNameWithoutOperation(t *testing.T) {
+	asst := assert.New(t)
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	inst, _ := gesaotel.New(gesaotel.WithTracerProvider(tp))
+
+	_, end := inst.StartCall(context.Background(), gesa.CallInfo{Method: "GET"})
+	end(gesa.CallResult{})
+
+	if asst.Len(spans.Ended(), 1) {
+		asst.Equal("esa GET", spans.Ended()[0].Name())
+	}
+}
+
+func Test_Instrumentation_RequestsPerAttempt(t *testing.T) {
+	asst := assert.New(t)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	inst, _ := gesaotel.New(gesaotel.WithMeterProvider(mp))
+
+	_, end := inst.StartCall(context.Background(), gesa.CallInfo{Operation: "post.ListPosts", Method: "GET", TeamName: "docs"})
+	end(gesa.CallResult{StatusCode: 200, Attempts: 3, StatusCodes: []int{503, 0, 200}})
+
+	rm := metricdata.ResourceMetrics{}
+	if !asst.NoError(reader.Collect(context.Background(), &rm)) {
+		return
+	}
+
+	requests := map[int64]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != gesaotel.MetricRequests {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				v, _ := dp.Attributes.Value(gesaotel.AttributeStatusCode)
+				requests[v.AsInt64()] += dp.Value
+			}
+		}
+	}
+	// the attempt without a response has no status code
+	asst.Equal(map[int64]int64{503: 1, 0: 1, 200: 1}, requests)
+}
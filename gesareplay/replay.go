@@ -0,0 +1,418 @@
+// Package gesareplay provides an http.RoundTripper that records esa API interactions into
+// a cassette file and replays them, for deterministic tests that run offline.
+//
+// Sensitive values are scrubbed before they are recorded: the Authorization header is never
+// stored, and the emails, invitation codes, OAuth codes and tokens in the bodies are replaced with Scrubbed.
+//
+//	mode := gesareplay.ModeReplay
+//	if os.Getenv("RECORD") != "" {
+//		mode = gesareplay.ModeRecord
+//	}
+//	tr, _ := gesareplay.NewTransport(&gesareplay.NewTransportInput{
+//		Path: "testdata/update_conflict.json",
+//		Mode: mode,
+//	})
+//	defer tr.Save()
+//
+//	c, _ := gesa.NewClient(&gesa.NewClientInput{
+//		AccessToken: os.Getenv("ESA_ACCESS_TOKEN"),
+//		HTTPClient:  &http.Client{Transport: tr},
+//	})
+package gesareplay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Scrubbed is the value that sensitive values are replaced with.
+const Scrubbed = "[SCRUBBED]"
+
+// ErrInteractionNotFound is returned in ModeReplay when no recorded interaction matches the request.
+var ErrInteractionNotFound = errors.New("gesareplay: interaction not found")
+
+type Mode int
+
+const (
+	// ModeReplay returns the recorded responses without sending requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records the interactions. Save writes them to the cassette.
+	ModeRecord
+)
+
+// Cassette is the content of the cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a pair of a request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Query is the encoded query parameters sorted by key.
+	Query string `json:"query,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Scrubber edits the interaction before it is recorded.
+type Scrubber func(i *Interaction)
+
+type NewTransportInput struct {
+	// Path is the path of the cassette file. It is required.
+	Path string
+
+	Mode Mode
+
+	// Transport sends the requests in ModeRecord (default: http.DefaultTransport).
+	Transport http.RoundTripper
+
+	// Scrubbers are applied in order after the default scrubbing.
+	Scrubbers []Scrubber
+}
+
+// Transport records or replays the interactions with the esa API.
+type Transport struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrubbers []Scrubber
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewTransport returns the transport. In ModeReplay, the cassette file is loaded.
+func NewTransport(in *NewTransportInput) (*Transport, error) {
+	if in == nil {
+		return nil, errors.New("NewTransportInput is nil")
+	}
+	if in.Path == "" {
+		return nil, errors.New("NewTransportInput.Path is empty")
+	}
+
+	t := &Transport{
+		path:      in.Path,
+		mode:      in.Mode,
+		transport: in.Transport,
+		scrubbers: in.Scrubbers,
+		cassette:  &Cassette{Interactions: []*Interaction{}},
+	}
+	if t.transport == nil {
+		t.transport = http.DefaultTransport
+	}
+
+	if t.mode == ModeReplay {
+		b, err := os.ReadFile(in.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, t.cassette); err != nil {
+			return nil, fmt.Errorf("gesareplay: invalid cassette %s: %w", in.Path, err)
+		}
+		t.used = make([]bool, len(t.cassette.Interactions))
+	}
+
+	return t, nil
+}
+
+// Cassette returns the recorded or loaded interactions.
+func (t *Transport) Cassette() *Cassette {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := &Cassette{Interactions: make([]*Interaction, len(t.cassette.Interactions))}
+	copy(c.Interactions, t.cassette.Interactions)
+	return c
+}
+
+// Save writes the recorded interactions to the cassette file in ModeRecord.
+// It does nothing in ModeReplay.
+func (t *Transport) Save() error {
+	if t.mode != ModeRecord {
+		return nil
+	}
+
+	t.mu.Lock()
+	b, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, append(b, '\n'), 0o644)
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.mode == ModeRecord {
+		return t.record(req, body)
+	}
+	return t.replay(req)
+}
+
+func (t *Transport) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	i := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  normalizeQuery(req.URL.RawQuery),
+			Body:   string(body),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       string(resBody),
+		},
+	}
+	scrub(i)
+	for _, s := range t.scrubbers {
+		s(i)
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, i)
+	t.mu.Unlock()
+
+	return res, nil
+}
+
+// replay returns the response of the first unused interaction that matches
+// the method, path and query of the request.
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	key := Request{
+		Method: req.Method,
+		Path:   scrubPath(req.URL.Path),
+		Query:  normalizeQuery(req.URL.RawQuery),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for n, i := range t.cassette.Interactions {
+		if t.used[n] {
+			continue
+		}
+		if i.Request.Method != key.Method || i.Request.Path != key.Path || i.Request.Query != key.Query {
+			continue
+		}
+
+		t.used[n] = true
+		header := i.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Del("Content-Length")
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s?%s", ErrInteractionNotFound, key.Method, key.Path, key.Query)
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// normalizeQuery sorts the query parameters by key so that the order does not affect the matching.
+func normalizeQuery(raw string) string {
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+	return q.Encode()
+}
+
+var (
+	// invitationPathPattern matches the paths of the invitation APIs, whose code is a path parameter.
+	invitationPathPattern = regexp.MustCompile(`(/teams/[^/]+/invitation(?:s|_regenerator)?)(/[^/]+)?$`)
+
+	// scrubbedKeys are the keys of the JSON bodies whose values are always scrubbed.
+	scrubbedKeys = map[string]struct{}{
+		"email":         {},
+		"emails":        {},
+		"access_token":  {},
+		"refresh_token": {},
+		"client_secret": {},
+	}
+
+	// invitationKeys are the keys scrubbed in the bodies of the invitation APIs.
+	// "code" is also used by other APIs such as emojis, so it is scrubbed only for invitations.
+	invitationKeys = map[string]struct{}{
+		"code": {},
+		"url":  {},
+	}
+
+	// oauthKeys are the keys scrubbed in the bodies of the OAuth APIs, such as the
+	// authorization code of /oauth/token and the token of /oauth/revoke.
+	oauthKeys = map[string]struct{}{
+		"code":  {},
+		"token": {},
+	}
+)
+
+// scrub removes the sensitive values of the interaction.
+func scrub(i *Interaction) {
+	i.Response.Header.Del("Set-Cookie")
+
+	keys := pathKeys(i.Request.Path)
+	i.Request.Path = scrubPath(i.Request.Path)
+	i.Request.Body = scrubJSON(i.Request.Body, keys)
+	i.Response.Body = scrubJSON(i.Response.Body, keys)
+}
+
+// pathKeys returns the keys scrubbed only in the bodies of the API of the path.
+func pathKeys(path string) map[string]struct{} {
+	switch {
+	case invitationPathPattern.MatchString(path):
+		return invitationKeys
+	case strings.HasPrefix(path, "/oauth/"):
+		return oauthKeys
+	}
+	return nil
+}
+
+// scrubPath replaces the invitation code in the path.
+func scrubPath(path string) string {
+	m := invitationPathPattern.FindStringSubmatchIndex(path)
+	if m == nil || m[4] < 0 {
+		return path
+	}
+	return path[:m[4]] + "/" + Scrubbed + path[m[5]:]
+}
+
+// scrubJSON replaces the values of the sensitive keys and the keys of the path in the JSON body.
+// The body is returned as is if it is not JSON.
+func scrubJSON(body string, keys map[string]struct{}) string {
+	if body == "" {
+		return body
+	}
+
+	d := json.NewDecoder(strings.NewReader(body))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return body
+	}
+
+	if !scrubValue(v, keys) {
+		return body
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+// scrubValue scrubs the value recursively and reports whether it is changed.
+func scrubValue(v any, keys map[string]struct{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			_, sensitive := scrubbedKeys[k]
+			if _, ok := keys[k]; ok {
+				sensitive = true
+			}
+			if sensitive {
+				if s, ok := scrubbedValue(child); ok {
+					v[k] = s
+					changed = true
+					continue
+				}
+			}
+			if scrubValue(child, keys) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if scrubValue(child, keys) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// scrubbedValue returns the scrubbed value of a string or a list of strings.
+func scrubbedValue(v any) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return v, false
+		}
+		return Scrubbed, true
+	case []any:
+		l := make([]any, len(v))
+		for n, e := range v {
+			if _, ok := e.(string); !ok {
+				return nil, false
+			}
+			l[n] = Scrubbed
+		}
+		return l, true
+	}
+	return nil, false
+}
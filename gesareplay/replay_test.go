@@ -0,0 +1,279 @@
+package gesareplay_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	invitationapi "github.com/michimani/go-esa/esaapi/invitation"
+	invitationtypes "github.com/michimani/go-esa/esaapi/invitation/types"
+	"github.com/michimani/go-esa/esaapi/member"
+	membertypes "github.com/michimani/go-esa/esaapi/member/types"
+	"github.com/michimani/go-esa/esaapi/models"
+	"github.com/michimani/go-esa/esaapi/oauth"
+	oauthtypes "github.com/michimani/go-esa/esaapi/oauth/types"
+	"github.com/michimani/go-esa/esaapi/post"
+	"github.com/michimani/go-esa/esaapi/post/types"
+	"github.com/michimani/go-esa/esaapi/user"
+	usertypes "github.com/michimani/go-esa/esaapi/user/types"
+	"github.com/michimani/go-esa/esatest"
+	"github.com/michimani/go-esa/gesa"
+	"github.com/michimani/go-esa/gesareplay"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(t *testing.T, baseURL string, tr http.RoundTripper) *gesa.Client {
+	t.Helper()
+	c, err := gesa.NewClient(&gesa.NewClientInput{
+		AccessToken: "secret-token",
+		BaseURL:     baseURL,
+		HTTPClient:  &http.Client{Transport: tr},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// updateConflictFlow updates a post twice based on the same revision, so that the second update is overlapped.
+func updateConflictFlow(ctx context.Context, c gesa.IAPIClient) ([]any, error) {
+	results := []any{}
+
+	got, err := post.GetPost(ctx, c, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, got.Post.BodyMD)
+
+	for _, body := range []string{"a\nB\nc\n", "a\nb\nC\n"} {
+		out, err := post.UpdatePost(ctx, c, &types.UpdatePostInput{
+			TeamName:   "docs",
+			PostNumber: 1,
+			BodyMD:     gesa.String(body),
+			OriginalRevision: &types.OriginalRevision{
+				BodyMD: gesa.String(got.BodyMD),
+				Number: gesa.Int(got.RevisionNumber),
+				User:   gesa.String(got.UpdatedBy.ScreenName),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, out.Overlapped, out.BodyMD)
+	}
+
+	got, err = post.GetPost(ctx, c, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, got.Post.RevisionNumber)
+
+	list, err := post.ListPosts(ctx, c, &types.ListPostsInput{TeamName: "docs", Q: "in:dev", PerPage: gesa.NewPageNumber(10)})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, list.TotalCount)
+
+	members, err := member.ListMembers(ctx, c, &membertypes.ListMembersInput{TeamName: "docs"})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members.Members {
+		results = append(results, m.ScreenName, m.Email)
+	}
+
+	return results, nil
+}
+
+func Test_Transport_RecordAndReplay(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "update_conflict.json")
+
+	s := esatest.NewServer()
+	s.AddToken("secret-token", "alice")
+	s.AddMember("docs", models.Member{ScreenName: "alice", Email: "alice@example.com"})
+	s.AddPost("docs", models.Post{Name: "hello", Category: "dev", BodyMD: "a\nb\nc\n"})
+
+	// record
+	rec, err := gesareplay.NewTransport(&gesareplay.NewTransportInput{Path: path, Mode: gesareplay.ModeRecord})
+	asst.NoError(err)
+	recorded, err := updateConflictFlow(ctx, newClient(t, s.URL, rec))
+	asst.NoError(err)
+	asst.NoError(rec.Save())
+	s.Close()
+
+	asst.Equal([]any{
+		"a\nb\nc\n",
+		false, "a\nB\nc\n",
+		true, "<<<<<<< current\na\nB\nc\n=======\na\nb\nC\n>>>>>>> requested\n",
+		3,
+		1,
+		"alice", "alice@example.com",
+	}, recorded)
+
+	b, err := os.ReadFile(path)
+	asst.NoError(err)
+	asst.NotContains(string(b), "secret-token")
+	asst.NotContains(string(b), "alice@example.com")
+	asst.Contains(string(b), gesareplay.Scrubbed)
+	asst.Len(rec.Cassette().Interactions, 6)
+
+	// replay without the server
+	rep, err := gesareplay.NewTransport(&gesareplay.NewTransportInput{Path: path})
+	asst.NoError(err)
+	c := newClient(t, s.URL, rep)
+	replayed, err := updateConflictFlow(ctx, c)
+	asst.NoError(err)
+
+	recorded[len(recorded)-1] = gesareplay.Scrubbed
+	asst.Equal(recorded, replayed)
+
+	// all interactions are used
+	_, err = post.GetPost(ctx, c, &types.GetPostInput{TeamName: "docs", PostNumber: 1})
+	asst.True(errors.Is(err, gesareplay.ErrInteractionNotFound))
+
+	// the Save of the replay mode does not overwrite the cassette
+	asst.NoError(rep.Save())
+	after, _ := os.ReadFile(path)
+	asst.Equal(b, after)
+}
+
+func Test_Transport_Scrub(t *testing.T) {
+	asst := assert.New(t)
+	ctx := context.Background()
+
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/teams/docs/invitation":
+			w.Write([]byte(`{"url":"https://docs.esa.io/team/invitations/member-0123456789/join"}`))
+		case "GET /v1/teams/docs/invitations":
+			w.Write([]byte(`{"invitations":[{"email":"bob@example.com","code":"mee93383edf699b525e01842d34078e28","url":"https://docs.esa.io/team/invitations/mee93383edf699b525e01842d34078e28/join"}],"total_count":1}`))
+		case "DELETE /v1/teams/docs/invitations/mee93383edf699b525e01842d34078e28":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /v1/user":
+			w.Write([]byte(`{"id":1,"screen_name":"alice","email":"alice@example.com"}`))
+		case "POST /oauth/token":
+			w.Write([]byte(`{"access_token":"at-secret","token_type":"Bearer","scope":"read"}`))
+		case "POST /oauth/revoke":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not_found","message":"Not found"}`))
+		}
+	}))
+	defer hs.Close()
+
+	path := filepath.Join(t.TempDir(), "invitations.json")
+	rec, err := gesareplay.NewTransport(&gesareplay.NewTransportInput{
+		Path: path,
+		Mode: gesareplay.ModeRecord,
+		Scrubbers: []gesareplay.Scrubber{
+			func(i *gesareplay.Interaction) {
+				i.Response.Body = strings.ReplaceAll(i.Response.Body, `"screen_name":"alice"`, `"screen_name":"someone"`)
+			},
+		},
+	})
+	asst.NoError(err)
+	c := newClient(t, hs.URL, rec)
+
+	_, err = invitationapi.GetURLInvitation(ctx, c, &invitationtypes.GetURLInvitationInput{TeamName: "docs"})
+	asst.NoError(err)
+	list, err := invitationapi.ListEmailInvitations(ctx, c, &invitationtypes.ListEmailInvitationsInput{TeamName: "docs"})
+	asst.NoError(err)
+	_, err = invitationapi.DeleteEmailInvitation(ctx, c, &invitationtypes.DeleteEmailInvitationInput{TeamName: "docs", Code: list.Invitations[0].Code})
+	asst.NoError(err)
+	_, err = user.GetMe(ctx, c, &usertypes.GetMeInput{})
+	asst.NoError(err)
+	_, err = oauth.CreateToken(ctx, c, &oauthtypes.CreateTokenInput{ClientID: "id", ClientSecret: "cs-secret", Code: "code-secret", RedirectURI: "urn:ietf:wg:oauth:2.0:oob"})
+	asst.NoError(err)
+	_, err = oauth.RevokeToken(ctx, c, &oauthtypes.RevokeTokenInput{ClientID: "id", ClientSecret: "cs-secret", Token: "token-secret"})
+	asst.NoError(err)
+	asst.NoError(rec.Save())
+
+	b, err := os.ReadFile(path)
+	asst.NoError(err)
+	for _, secret := range []string{"member-0123456789", "mee93383edf699b525e01842d34078e28", "bob@example.com", "alice", "session=secret", "secret-token", "cs-secret", "code-secret", "at-secret", "token-secret"} {
+		asst.NotContains(string(b), secret)
+	}
+
+	cassette := &gesareplay.Cassette{}
+	asst.NoError(json.Unmarshal(b, cassette))
+	if asst.Len(cassette.Interactions, 6) {
+		asst.Equal(`{"url":"[SCRUBBED]"}`, cassette.Interactions[0].Response.Body)
+		asst.Equal(`{"invitations":[{"code":"[SCRUBBED]","email":"[SCRUBBED]","url":"[SCRUBBED]"}],"total_count":1}`, cassette.Interactions[1].Response.Body)
+		asst.Equal("/v1/teams/docs/invitations/[SCRUBBED]", cassette.Interactions[2].Request.Path)
+		asst.Equal(`{"email":"[SCRUBBED]","id":1,"screen_name":"someone"}`, cassette.Interactions[3].Response.Body)
+		asst.Equal(`{"client_id":"id","client_secret":"[SCRUBBED]","code":"[SCRUBBED]","grant_type":"authorization_code","redirect_uri":"urn:ietf:wg:oauth:2.0:oob"}`, cassette.Interactions[4].Request.Body)
+		asst.Equal(`{"access_token":"[SCRUBBED]","scope":"read","token_type":"Bearer"}`, cassette.Interactions[4].Response.Body)
+		asst.Equal(`{"client_id":"id","client_secret":"[SCRUBBED]","token":"[SCRUBBED]"}`, cassette.Interactions[5].Request.Body)
+	}
+
+	// the scrubbed code in the path matches any code
+	rep, err := gesareplay.NewTransport(&gesareplay.NewTransportInput{Path: path})
+	asst.NoError(err)
+	_, err = invitationapi.DeleteEmailInvitation(ctx, newClient(t, hs.URL, rep), &invitationtypes.DeleteEmailInvitationInput{TeamName: "docs", Code: "another"})
+	asst.NoError(err)
+}
+
+func Test_Transport_ReplayQuery(t *testing.T) {
+	asst := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "list.json")
+	cassette := `{"interactions":[{"request":{"method":"GET","path":"/v1/teams/docs/posts","query":"page=2&q=in%3Adev"},"response":{"status_code":200,"header":{"Content-Type":["application/json"]},"body":"{\"posts\":[],\"total_count\":42}"}}]}`
+	asst.NoError(os.WriteFile(path, []byte(cassette), 0o644))
+
+	rep, err := gesareplay.NewTransport(&gesareplay.NewTransportInput{Path: path})
+	asst.NoError(err)
+	c := newClient(t, "", rep)
+
+	_, err = post.ListPosts(context.Background(), c, &types.ListPostsInput{TeamName: "docs", Q: "in:dev"})
+	asst.True(errors.Is(err, gesareplay.ErrInteractionNotFound))
+
+	out, err := post.ListPosts(context.Background(), c, &types.ListPostsInput{TeamName: "docs", Q: "in:dev", Page: gesa.NewPageNumber(2)})
+	if asst.NoError(err) {
+		asst.Equal(42, out.TotalCount)
+	}
+}
+
+func Test_NewTransport(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		in      *gesareplay.NewTransportInput
+		wantErr bool
+	}{
+		{name: "ok: record without the cassette", in: &gesareplay.NewTransportInput{Path: filepath.Join(dir, "new.json"), Mode: gesareplay.ModeRecord}},
+		{name: "ng: nil", in: nil, wantErr: true},
+		{name: "ng: empty path", in: &gesareplay.NewTransportInput{}, wantErr: true},
+		{name: "ng: replay without the cassette", in: &gesareplay.NewTransportInput{Path: filepath.Join(dir, "not-exists.json")}, wantErr: true},
+		{name: "ng: invalid cassette", in: &gesareplay.NewTransportInput{Path: invalid}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			tr, err := gesareplay.NewTransport(c.in)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(tr)
+				return
+			}
+			asst.NoError(err)
+			asst.NotNil(tr)
+		})
+	}
+}
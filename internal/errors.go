@@ -1,7 +1,45 @@
 package internal
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 var (
 	ErrorUndefined              string = "Undefined error."
 	ErrorParameterIsNil         string = "Parameter is nil."
 	ErrorRequiredParameterEmpty string = "Required parameters are empty. : %s"
 )
+
+// ErrInvalidParameter is matched by errors.Is with all ParameterError.
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// ParameterError is the error of a parameter validated before calling the esa API.
+type ParameterError struct {
+	Message string
+	// Fields is the names of the empty required parameters such as "GetPostInput.TeamName".
+	Fields []string
+}
+
+func (e *ParameterError) Error() string {
+	return e.Message
+}
+
+func (e *ParameterError) Is(target error) bool {
+	return target == ErrInvalidParameter
+}
+
+// NewParameterIsNilError returns the error of a nil parameter.
+func NewParameterIsNilError() error {
+	return &ParameterError{Message: ErrorParameterIsNil}
+}
+
+// NewRequiredParameterEmptyError returns the error of the empty required parameters.
+// fields is the comma separated names of the parameters.
+func NewRequiredParameterEmptyError(fields string) error {
+	return &ParameterError{
+		Message: fmt.Sprintf(ErrorRequiredParameterEmpty, fields),
+		Fields:  strings.Split(fields, ", "),
+	}
+}
@@ -0,0 +1,207 @@
+// Package linediff finds the longest common subsequence of lines.
+package linediff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxEditDistance is the limit of the edit distance searched by Match.
+// Beyond it, the lines other than the common prefix and suffix are regarded as unmatched
+// to bound the memory of the search.
+const maxEditDistance = 2000
+
+// Split splits the text into lines, keeping the line breaks.
+func Split(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Match returns the longest common subsequence of a and b as the indices of b
+// matched to each line of a, or -1 for unmatched lines.
+func Match(a, b []string) []int {
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		m[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		m[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], m[prefix:len(a)-suffix], prefix)
+	return m
+}
+
+// myers finds the shortest edit script of a and b with the Myers' algorithm and
+// stores the matched lines to m, adding the offset to the indices of b.
+func myers(a, b []string, m []int, offset int) {
+	n, mm := len(a), len(b)
+	if n == 0 || mm == 0 {
+		return
+	}
+
+	limit := min(n+mm, maxEditDistance)
+	v := make([]int, 2*limit+3)
+	center := limit + 1
+	// trace[d] is v before the d-th step, limited to the diagonals -d-1..d+1.
+	trace := [][]int{}
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[center-d-1:center+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[center+k-1] < v[center+k+1]) {
+				x = v[center+k+1]
+			} else {
+				x = v[center+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < mm && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[center+k] = x
+
+			if x >= n && y >= mm {
+				backtrack(trace, n, mm, m, offset)
+				return
+			}
+		}
+	}
+}
+
+func backtrack(trace [][]int, x, y int, m []int, offset int) {
+	for d := len(trace) - 1; d >= 0; d-- {
+		// v(k) is the furthest x on the diagonal k before the d-th step.
+		v := func(k int) int { return trace[d][k+d+1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY && x > 0 && y > 0 {
+			x--
+			y--
+			m[x] = y + offset
+		}
+		x, y = prevX, prevY
+	}
+}
+
+type edit struct {
+	kind byte // ' ', '-' or '+'
+	line string
+	// a and b are the indices of the next lines of a and b.
+	a, b int
+}
+
+// Unified returns the unified diff of a and b with n lines of context.
+// It returns an empty string if a and b are the same. A negative n is treated as 0.
+func Unified(fromName, toName, a, b string, n int) string {
+	n = max(n, 0)
+	la, lb := Split(a), Split(b)
+	m := Match(la, lb)
+
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(la) || j < len(lb) {
+		switch {
+		case i < len(la) && m[i] == j:
+			edits = append(edits, edit{kind: ' ', line: la[i], a: i, b: j})
+			i++
+			j++
+		case i < len(la) && m[i] < 0:
+			edits = append(edits, edit{kind: '-', line: la[i], a: i, b: j})
+			i++
+		default:
+			edits = append(edits, edit{kind: '+', line: lb[j], a: i, b: j})
+			j++
+		}
+	}
+
+	sb := new(strings.Builder)
+	for start := 0; start < len(edits); {
+		first := start
+		for first < len(edits) && edits[first].kind == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// extend the hunk while the next change is within the context of the previous one
+		last := first
+		for k := first + 1; k < len(edits) && k <= last+2*n+1; k++ {
+			if edits[k].kind != ' ' {
+				last = k
+			}
+		}
+
+		from := max(first-n, start)
+		to := min(last+n+1, len(edits))
+		if sb.Len() == 0 {
+			fmt.Fprintf(sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(sb, edits[from:to])
+		start = to
+	}
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, edits []edit) {
+	aStart, bStart := edits[0].a, edits[0].b
+	aLen, bLen := 0, 0
+	for _, e := range edits {
+		if e.kind != '+' {
+			aLen++
+		}
+		if e.kind != '-' {
+			bLen++
+		}
+	}
+	// The start is the line before the hunk if the range is empty.
+	if aLen > 0 {
+		aStart++
+	}
+	if bLen > 0 {
+		bStart++
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+	for _, e := range edits {
+		sb.WriteByte(e.kind)
+		sb.WriteString(e.line)
+		if !strings.HasSuffix(e.line, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func hunkRange(start, n int) string {
+	if n == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
@@ -0,0 +1,118 @@
+package linediff_test
+
+import (
+	"testing"
+
+	"github.com/michimani/go-esa/internal/linediff"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Split(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect []string
+	}{
+		{"empty", "", []string{}},
+		{"lines", "a\nb\n", []string{"a\n", "b\n"}},
+		{"no line break at the end", "a\nb", []string{"a\n", "b"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, linediff.Split(c.s))
+		})
+	}
+}
+
+func Test_Match(t *testing.T) {
+	cases := []struct {
+		name   string
+		a      []string
+		b      []string
+		expect []int
+	}{
+		{"same", []string{"a", "b"}, []string{"a", "b"}, []int{0, 1}},
+		{"empty a", []string{}, []string{"a"}, []int{}},
+		{"empty b", []string{"a"}, []string{}, []int{-1}},
+		{"insert", []string{"a", "c"}, []string{"a", "b", "c"}, []int{0, 2}},
+		{"delete", []string{"a", "b", "c"}, []string{"a", "c"}, []int{0, -1, 1}},
+		{"replace", []string{"a", "b", "c", "d"}, []string{"x", "b", "y", "d"}, []int{-1, 1, -1, 3}},
+		{"longest", []string{"a", "b", "c", "a", "b", "b", "a"}, []string{"c", "b", "a", "b", "a", "c"}, []int{-1, -1, 0, 2, 3, -1, 4}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, linediff.Match(c.a, c.b))
+		})
+	}
+}
+
+func Test_Unified(t *testing.T) {
+	cases := []struct {
+		name   string
+		a      string
+		b      string
+		n      int
+		expect string
+	}{
+		{
+			name:   "same",
+			a:      "a\nb\n",
+			b:      "a\nb\n",
+			n:      3,
+			expect: "",
+		},
+		{
+			name:   "two hunks",
+			a:      "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			b:      "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n",
+			n:      1,
+			expect: "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n@@ -10 +10,2 @@\n j\n+k\n",
+		},
+		{
+			name:   "hunks joined by the context",
+			a:      "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			b:      "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n",
+			n:      4,
+			expect: "--- from\n+++ to\n@@ -1,10 +1,11 @@\n a\n-b\n+B\n c\n d\n e\n f\n g\n h\n i\n j\n+k\n",
+		},
+		{
+			name:   "no context",
+			a:      "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			b:      "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n",
+			n:      0,
+			expect: "--- from\n+++ to\n@@ -2 +2 @@\n-b\n+B\n@@ -10,0 +11 @@\n+k\n",
+		},
+		{
+			name:   "negative context",
+			a:      "x\ny\n",
+			b:      "x\nz\n",
+			n:      -1,
+			expect: "--- from\n+++ to\n@@ -2 +2 @@\n-y\n+z\n",
+		},
+		{
+			name:   "no newline at end of file",
+			a:      "a\nb",
+			b:      "a\nc\n",
+			n:      3,
+			expect: "--- from\n+++ to\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n",
+		},
+		{
+			name:   "from empty",
+			a:      "",
+			b:      "a\nc\n",
+			n:      3,
+			expect: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+c\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			asst.Equal(c.expect, linediff.Unified("from", "to", c.a, c.b, c.n))
+		})
+	}
+}